@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// version is set at build time with -ldflags "-X main.version=v1.2.3"
+var version = "dev"
+
+// latestReleaseURL is the GitHub API endpoint describing the newest release
+const latestReleaseURL = "https://api.github.com/repos/karlabo93/Genki-Quiz/releases/latest"
+
+// updateCheckTimeout limits how long the update check may take
+const updateCheckTimeout = 10 * time.Second
+
+// release is the part of a GitHub release the update check uses
+type release struct {
+	TagName string         `json:"tag_name"`
+	HTMLURL string         `json:"html_url"`
+	Assets  []releaseAsset `json:"assets"`
+}
+
+// releaseAsset is a file attached to a release
+type releaseAsset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// buildInfo describes the commit and toolchain the binary was built from
+func buildInfo() (commit, built string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			commit = setting.Value
+			if len(commit) > 12 {
+				commit = commit[:12]
+			}
+		case "vcs.time":
+			built = setting.Value
+		}
+	}
+	return commit, built
+}
+
+// fetchLatestRelease asks GitHub for the newest published release
+func fetchLatestRelease() (release, error) {
+	var r release
+	client := &http.Client{Timeout: updateCheckTimeout}
+	req, err := http.NewRequest(http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return r, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return r, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return r, fmt.Errorf("checking for updates: %s", resp.Status)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	return r, err
+}
+
+// parseVersion splits a version like "v1.2.3" into numbers; ok is false for other formats
+func parseVersion(v string) ([]int, bool) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(v), "v"), ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		numbers[i] = n
+	}
+	return numbers, true
+}
+
+// isNewerVersion reports whether latest is a later release than current.
+// Development builds are never considered out of date.
+func isNewerVersion(current, latest string) bool {
+	cur, okCur := parseVersion(current)
+	lat, okLat := parseVersion(latest)
+	if !okCur || !okLat {
+		return false
+	}
+	for i := 0; i < max(len(cur), len(lat)); i++ {
+		var c, l int
+		if i < len(cur) {
+			c = cur[i]
+		}
+		if i < len(lat) {
+			l = lat[i]
+		}
+		if c != l {
+			return l > c
+		}
+	}
+	return false
+}
+
+// showAbout shows the version and build information, with an optional update check
+func (qa *quizApp) showAbout() {
+	commit, built := buildInfo()
+	info := widget.NewForm(
+		widget.NewFormItem("Version", widget.NewLabel(version)),
+		widget.NewFormItem("Go", widget.NewLabel(fmt.Sprintf("%s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH))),
+	)
+	info.Append("Data Folder", widget.NewLabel(store.data))
+	if commit != "" {
+		info.Append("Commit", widget.NewLabel(commit))
+	}
+	if built != "" {
+		info.Append("Built", widget.NewLabel(built))
+	}
+
+	status := widget.NewLabel("")
+	link := widget.NewHyperlink("", nil)
+	link.Hide()
+	var latest release
+	installButton := widget.NewButton("Install Update", func() {
+		qa.updateNow(latest)
+	})
+	installButton.Importance = widget.HighImportance
+	installButton.Hide()
+	var checkButton *widget.Button
+	checkButton = widget.NewButton("Check for Updates", func() {
+		checkButton.Disable()
+		status.SetText("Checking…")
+		go func() {
+			r, err := fetchLatestRelease()
+			qa.serialized(func() {
+				defer checkButton.Enable()
+				latest = r
+				switch {
+				case err != nil:
+					status.SetText("Could not check for updates: " + err.Error())
+				case isNewerVersion(version, latest.TagName):
+					status.SetText(fmt.Sprintf("Version %s is available.", latest.TagName))
+					if u, err := url.Parse(latest.HTMLURL); err == nil {
+						link.SetText("Download " + latest.TagName)
+						link.SetURL(u)
+						link.Show()
+					}
+					if canSelfUpdate(latest) {
+						installButton.Show()
+					}
+				case version == "dev":
+					status.SetText(fmt.Sprintf("This is a development build; the latest release is %s.", latest.TagName))
+				default:
+					status.SetText("You have the latest version.")
+				}
+			})()
+		}()
+	})
+
+	dialog.ShowCustom("About Genki Quiz", "Close", container.NewVBox(
+		widget.NewLabelWithStyle("Genki Quiz", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel("Vocabulary practice for the Genki textbooks."),
+		info,
+		checkButton,
+		status,
+		link,
+		installButton,
+	), qa.window)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"fyne.io/fyne/v2/dialog"
+)
+
+// Limits of an adaptive placement test
+const (
+	adaptiveMinItems = 6   // Never stop before this many answers
+	adaptiveMaxItems = 20  // Always stop after this many answers
+	adaptiveTargetSE = 0.5 // Stop once the ability estimate is this precise
+	adaptiveComfort  = 0.8 // Chance of a correct answer that counts as knowing a chapter
+)
+
+// adaptiveTest estimates the learner's level with a one-parameter (Rasch) model.
+// Each chapter is one step of difficulty; personal statistics shift items within it.
+type adaptiveTest struct {
+	chapters  []string
+	questions []Question
+	progress  *Progress
+	asked     map[string]bool
+	responses []adaptiveResponse
+	grid      []float64 // Ability values for the posterior
+}
+
+// adaptiveResponse is one answered item
+type adaptiveResponse struct {
+	difficulty float64
+	correct    bool
+}
+
+// newAdaptiveTest prepares a placement test over the given chapters
+func newAdaptiveTest(questions []Question, chapters []string, progress *Progress) *adaptiveTest {
+	t := &adaptiveTest{
+		chapters:  chapters,
+		questions: getQuestionsByChapters(questions, chapters),
+		progress:  progress,
+		asked:     make(map[string]bool),
+	}
+	for theta := -3.0; theta <= float64(len(chapters))+2; theta += 0.05 {
+		t.grid = append(t.grid, theta)
+	}
+	return t
+}
+
+// chapterIndex returns the position of a chapter in the test's chapter list
+func (t *adaptiveTest) chapterIndex(chapter string) int {
+	for i, c := range t.chapters {
+		if c == chapter {
+			return i
+		}
+	}
+	return 0
+}
+
+// difficulty places an item on the ability scale: its chapter index, shifted by how
+// hard the learner has found it (logit of the smoothed error rate, halved)
+func (t *adaptiveTest) difficulty(q Question) float64 {
+	p := itemDifficulty(t.progress.stats(q.QID))
+	return float64(t.chapterIndex(q.QChapter)) + math.Log(p/(1-p))/2
+}
+
+// pCorrect is the Rasch probability of answering an item of difficulty b at ability theta
+func pCorrect(theta, b float64) float64 {
+	return 1 / (1 + math.Exp(b-theta))
+}
+
+// estimate returns the posterior mean ability and its standard error,
+// using a wide normal prior centered on the middle chapter
+func (t *adaptiveTest) estimate() (float64, float64) {
+	center := float64(len(t.chapters)-1) / 2
+	var sum, mean float64
+	weights := make([]float64, len(t.grid))
+	for i, theta := range t.grid {
+		w := math.Exp(-(theta - center) * (theta - center) / 8)
+		for _, r := range t.responses {
+			p := pCorrect(theta, r.difficulty)
+			if r.correct {
+				w *= p
+			} else {
+				w *= 1 - p
+			}
+		}
+		weights[i] = w
+		sum += w
+		mean += w * theta
+	}
+	mean /= sum
+
+	var variance float64
+	for i, theta := range t.grid {
+		variance += weights[i] / sum * (theta - mean) * (theta - mean)
+	}
+	return mean, math.Sqrt(variance)
+}
+
+// done reports whether the ability is estimated precisely enough to stop
+func (t *adaptiveTest) done() bool {
+	n := len(t.responses)
+	if n >= adaptiveMaxItems || len(t.asked) >= len(t.questions) {
+		return true
+	}
+	_, se := t.estimate()
+	return n >= adaptiveMinItems && se <= adaptiveTargetSE
+}
+
+// next picks the unasked item whose difficulty is closest to the current estimate
+func (t *adaptiveTest) next() (Question, bool) {
+	if t.done() {
+		return Question{}, false
+	}
+	theta, _ := t.estimate()
+	var best Question
+	bestGap := math.Inf(1)
+	for _, q := range t.questions {
+		if t.asked[q.QID] {
+			continue
+		}
+		if gap := math.Abs(t.difficulty(q) - theta); gap < bestGap {
+			best, bestGap = q, gap
+		}
+	}
+	t.asked[best.QID] = true
+	return best, true
+}
+
+// answer records the outcome of the last item
+func (t *adaptiveTest) answer(q Question, correct bool) {
+	t.responses = append(t.responses, adaptiveResponse{difficulty: t.difficulty(q), correct: correct})
+}
+
+// recommendedChapter is the first chapter the learner is not yet comfortable with,
+// or "" if every chapter is comfortable
+func (t *adaptiveTest) recommendedChapter() string {
+	theta, _ := t.estimate()
+	for i, chapter := range t.chapters {
+		if pCorrect(theta, float64(i)) < adaptiveComfort {
+			return chapter
+		}
+	}
+	return ""
+}
+
+// startAdaptiveTest runs a placement test that adapts to the learner's answers
+func (qa *quizApp) startAdaptiveTest() {
+	state := qa.state
+	chapters := qa.availableChapters()
+	test := newAdaptiveTest(qa.studyQuestions(), chapters, qa.progress)
+	if len(test.questions) == 0 {
+		return
+	}
+
+	state.chapterQuestions = test.questions
+	state.totalQuestions = adaptiveMaxItems
+	state.currentChapter = "Placement Test"
+	state.quizName = "Placement Test"
+	state.nextQuestion = test.next
+	state.onAnswer = test.answer
+	state.onFinish = func(result quizResult) {
+		theta, se := test.estimate()
+		message := "You already know every chapter well — keep reviewing them all!"
+		if chapter := test.recommendedChapter(); chapter != "" {
+			message = fmt.Sprintf("We recommend starting with Chapter %s.", chapter)
+		}
+		dialog.ShowInformation("Placement Result",
+			fmt.Sprintf("Estimated level: %.1f of %d chapters (±%.1f)\n%s",
+				math.Max(0, math.Min(theta+1, float64(len(chapters)))), len(chapters), se, message),
+			qa.window)
+	}
+	qa.startQuiz()
+}
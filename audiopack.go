@@ -0,0 +1,393 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// audioSettings configure recorded audio and speech
+type audioSettings struct {
+	PackURL string  `json:"pack_url"` // Where the last audio pack was downloaded from
+	Speed   float64 `json:"speed"`    // Playback rate of recordings and speech, 0 for normal speed
+
+	MaxReplays int `json:"max_replays"` // Replays allowed per word in listening drills, 0 for no limit
+}
+
+// replayLimits are the replay limits offered, for exam-like listening practice
+var replayLimits = []int{0, 1, 2, 3, 5}
+
+// replayLimitName names a replay limit as offered
+func replayLimitName(limit int) string {
+	switch limit {
+	case 0:
+		return "No limit"
+	case 1:
+		return "1 replay"
+	}
+	return strconv.Itoa(limit) + " replays"
+}
+
+// audioSpeeds are the playback rates offered, from minAudioSpeed to maxAudioSpeed
+var audioSpeeds = []float64{0.5, 0.75, 1, 1.25, 1.5}
+
+// Playback rate limits
+const (
+	minAudioSpeed = 0.5
+	maxAudioSpeed = 1.5
+)
+
+// rate returns the playback rate, 1 being normal speed
+func (a audioSettings) rate() float64 {
+	if a.Speed == 0 {
+		return 1
+	}
+	return min(max(a.Speed, minAudioSpeed), maxAudioSpeed)
+}
+
+// speedName formats a playback rate like "0.75×"
+func speedName(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64) + "×"
+}
+
+// speedSelect picks the playback rate of recordings and speech, saving the choice
+func (qa *quizApp) speedSelect() *widget.Select {
+	var names []string
+	for _, speed := range audioSpeeds {
+		names = append(names, speedName(speed))
+	}
+	speed := widget.NewSelect(names, nil)
+	speed.SetSelected(speedName(qa.settings.Audio.rate()))
+	speed.OnChanged = func(selected string) {
+		for _, s := range audioSpeeds {
+			if speedName(s) == selected && s != qa.settings.Audio.rate() {
+				qa.settings.Audio.Speed = s
+				if err := qa.settings.save(); err != nil {
+					log.Printf("Failed to save settings: %v", err)
+				}
+			}
+		}
+	}
+	return speed
+}
+
+// audioFolder holds the installed audio packs, one folder each, in the data folder
+const audioFolder = "audio"
+
+// audioExtensions are the recording formats looked for, in order of preference
+var audioExtensions = []string{".mp3", ".ogg", ".wav", ".m4a"}
+
+// maxAudioPackSize limits how much an audio pack download may be
+const maxAudioPackSize = 500 << 20
+
+// audioPackDownloadTimeout limits how long downloading an audio pack may take
+const audioPackDownloadTimeout = 10 * time.Minute
+
+// errEmptyAudioPack is returned for a zip without any recordings named by QID
+var errEmptyAudioPack = errors.New("the audio pack has no recordings (files should be named by QID, e.g. 123.mp3)")
+
+// audioPackPath returns the folder of an installed pack
+func audioPackPath(name string) string {
+	return filepath.Join(dataPath(audioFolder), name)
+}
+
+// audioPacks returns the names of the installed packs, sorted
+func audioPacks() []string {
+	entries, err := os.ReadDir(dataPath(audioFolder))
+	if err != nil {
+		return nil
+	}
+	var packs []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") { // Not a pack being installed
+			packs = append(packs, entry.Name())
+		}
+	}
+	sort.Strings(packs)
+	return packs
+}
+
+// audioPackSize returns the number of recordings in an installed pack
+func audioPackSize(name string) int {
+	entries, err := os.ReadDir(audioPackPath(name))
+	if err != nil {
+		return 0
+	}
+	return len(entries)
+}
+
+// isRecording reports whether a file name looks like a recording in a supported format
+func isRecording(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	for _, e := range audioExtensions {
+		if ext == e {
+			return strings.TrimSuffix(name, path.Ext(name)) != ""
+		}
+	}
+	return false
+}
+
+// recordingPath returns the recording of a question in the first installed pack that has one,
+// or "" if no pack does
+func recordingPath(qid string) string {
+	if !filepath.IsLocal(qid) || filepath.Base(qid) != qid {
+		return ""
+	}
+	for _, pack := range audioPacks() {
+		for _, ext := range audioExtensions {
+			file := filepath.Join(audioPackPath(pack), qid+ext)
+			if _, err := os.Stat(file); err == nil {
+				return file
+			}
+		}
+	}
+	return ""
+}
+
+// recordingFor returns the recording of the deck word written as text, or ""
+func (qa *quizApp) recordingFor(text string) string {
+	for _, q := range qa.questions {
+		if q.QHirakata == text {
+			if file := recordingPath(q.QID); file != "" {
+				return file
+			}
+		}
+	}
+	return ""
+}
+
+// audioPackName turns a zip's file name or URL into a pack folder name
+func audioPackName(name string) string {
+	if u, err := url.Parse(name); err == nil && u.Path != "" {
+		name = u.Path
+	}
+	name = strings.TrimSuffix(path.Base(filepath.ToSlash(name)), path.Ext(name))
+	if !validProfile(name) {
+		return "pack"
+	}
+	return name
+}
+
+// installAudioPack unpacks the recordings of a zip into the pack folder name,
+// replacing a pack of that name. Folders inside the zip are ignored, so recordings
+// may be nested, but only files named by QID with a supported format are kept.
+func installAudioPack(name string, data []byte) (int, error) {
+	if guestMode {
+		return 0, errGuestMode
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return 0, err
+	}
+
+	// Unpack next to the installed packs, then swap the folder in
+	if err := os.MkdirAll(dataPath(audioFolder), 0o755); err != nil {
+		return 0, err
+	}
+	tmp, err := os.MkdirTemp(dataPath(audioFolder), ".installing-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(tmp)
+
+	count := 0
+	for _, file := range archive.File {
+		base := path.Base(file.Name)
+		if file.FileInfo().IsDir() || strings.HasPrefix(base, ".") || !isRecording(base) {
+			continue
+		}
+		ext := path.Ext(base)
+		if err := unzipFile(file, filepath.Join(tmp, strings.TrimSuffix(base, ext)+strings.ToLower(ext))); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	if count == 0 {
+		return 0, errEmptyAudioPack
+	}
+
+	dest := audioPackPath(name)
+	if err := os.RemoveAll(dest); err != nil {
+		return 0, err
+	}
+	return count, os.Rename(tmp, dest)
+}
+
+// unzipFile writes one file of a zip
+func unzipFile(file *zip.File, dest string) error {
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	w, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// downloadAudioPack fetches an audio pack zip, refusing anything larger than maxAudioPackSize
+func downloadAudioPack(packURL string) ([]byte, error) {
+	client := &http.Client{Timeout: audioPackDownloadTimeout}
+	resp, err := client.Get(packURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", packURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAudioPackSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxAudioPackSize {
+		return nil, errors.New("the audio pack is too large")
+	}
+	return data, nil
+}
+
+// showAudioSettings manages the installed audio packs
+func (qa *quizApp) showAudioSettings() {
+	packList := container.NewVBox()
+	var refresh func()
+	refresh = func() {
+		packList.Objects = nil
+		packs := audioPacks()
+		if len(packs) == 0 {
+			packList.Add(widget.NewLabel("No audio packs are installed; questions are read by text-to-speech."))
+		}
+		for _, pack := range packs {
+			pack := pack
+			packList.Add(container.NewBorder(nil, nil, nil,
+				widget.NewButton("Remove", func() {
+					dialog.ShowConfirm("Remove Audio Pack", "Remove the audio pack "+pack+"?", func(ok bool) {
+						if !ok {
+							return
+						}
+						if err := os.RemoveAll(audioPackPath(pack)); err != nil {
+							dialog.ShowError(err, qa.window)
+						}
+						refresh()
+					}, qa.window)
+				}),
+				widget.NewLabel(fmt.Sprintf("%s — %d recordings", pack, audioPackSize(pack))),
+			))
+		}
+		packList.Refresh()
+	}
+	refresh()
+
+	install := func(name string, data []byte) {
+		count, err := installAudioPack(name, data)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		refresh()
+		dialog.ShowInformation("Audio Pack Installed",
+			fmt.Sprintf("Installed %d recordings as %s. They are played instead of text-to-speech.", count, name), qa.window)
+	}
+
+	urlEntry := widget.NewEntry()
+	urlEntry.SetText(qa.settings.Audio.PackURL)
+	urlEntry.SetPlaceHolder("https://example.com/genki-audio.zip")
+	downloadButton := widget.NewButton("Download", func() {
+		packURL := strings.TrimSpace(urlEntry.Text)
+		if packURL == "" {
+			return
+		}
+		qa.settings.Audio.PackURL = packURL
+		if err := qa.settings.save(); err != nil {
+			log.Printf("Failed to save settings: %v", err)
+		}
+		progress := dialog.NewCustomWithoutButtons("Downloading", widget.NewProgressBarInfinite(), qa.window)
+		progress.Show()
+		go func() {
+			data, err := downloadAudioPack(packURL)
+			progress.Hide()
+			if err != nil {
+				dialog.ShowError(err, qa.window)
+				return
+			}
+			install(audioPackName(packURL), data)
+		}()
+	})
+	fileButton := widget.NewButton("Install from File…", func() {
+		open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, qa.window)
+				return
+			}
+			if reader == nil {
+				return // Cancelled
+			}
+			defer reader.Close()
+			data, err := io.ReadAll(io.LimitReader(reader, maxAudioPackSize))
+			if err != nil {
+				dialog.ShowError(err, qa.window)
+				return
+			}
+			install(audioPackName(reader.URI().Name()), data)
+		}, qa.window)
+		open.SetFilter(storage.NewExtensionFileFilter([]string{".zip"}))
+		open.Show()
+	})
+
+	var limitNames []string
+	for _, limit := range replayLimits {
+		limitNames = append(limitNames, replayLimitName(limit))
+	}
+	limitSelect := widget.NewSelect(limitNames, nil)
+	limitSelect.SetSelected(replayLimitName(qa.settings.Audio.MaxReplays))
+	limitSelect.OnChanged = func(selected string) {
+		for _, limit := range replayLimits {
+			if replayLimitName(limit) == selected && limit != qa.settings.Audio.MaxReplays {
+				qa.settings.Audio.MaxReplays = limit
+				if err := qa.settings.save(); err != nil {
+					log.Printf("Failed to save settings: %v", err)
+				}
+			}
+		}
+	}
+
+	content := container.NewVBox(
+		settingsHeading("Playback"),
+		widget.NewForm(
+			widget.NewFormItem("Speed", qa.speedSelect()),
+			widget.NewFormItem("Listening Replays", limitSelect),
+		),
+		settingsHeading("Installed Audio Packs"),
+		packList,
+		settingsHeading("Install an Audio Pack"),
+		widget.NewLabel("A pack is a zip of recordings named by question ID, e.g. 123.mp3."),
+		container.NewBorder(nil, nil, nil, downloadButton, urlEntry),
+		fileButton,
+	)
+	d := dialog.NewCustom("Audio Settings", "Close", content, qa.window)
+	d.Resize(fyne.NewSize(520, 0))
+	d.Show()
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// BlueprintSection asks for a number of questions of one category
+type BlueprintSection struct {
+	Category string `json:"category"` // Question category (see questionCategory)
+	Count    int    `json:"count"`    // Number of questions to draw
+}
+
+// Blueprint describes the fixed layout of a practice test, like a course exam
+type Blueprint struct {
+	Name     string             `json:"name"`
+	Chapters []string           `json:"chapters"`
+	Sections []BlueprintSection `json:"sections"`
+}
+
+// blueprintFile holds custom blueprints, loaded from the data folder if present
+const blueprintFile = "blueprints.json"
+
+// defaultBlueprints mirror the chapter exams of the course
+var defaultBlueprints = []Blueprint{
+	{
+		Name:     "Chapters 1–2 Exam",
+		Chapters: []string{"1", "2"},
+		Sections: []BlueprintSection{{Category: "vocab", Count: 20}},
+	},
+	{
+		Name:     "Chapters 1–3 Exam",
+		Chapters: []string{"1", "2", "3"},
+		Sections: []BlueprintSection{{Category: "vocab", Count: 15}, {Category: "verb", Count: 5}},
+	},
+	{
+		Name:     "Chapters 3–4 Exam",
+		Chapters: []string{"3", "4"},
+		Sections: []BlueprintSection{{Category: "vocab", Count: 15}, {Category: "verb", Count: 10}},
+	},
+}
+
+// questionCategory groups question types into the categories used by blueprints
+func questionCategory(q Question) string {
+	qType := strings.ToLower(strings.TrimSpace(q.QType))
+	switch {
+	case qType == "" || qType == "nil":
+		return "vocab"
+	case strings.HasSuffix(qType, "_verb"):
+		return "verb"
+	}
+	return qType
+}
+
+// loadBlueprints returns the default blueprints followed by any custom ones from blueprintFile
+func loadBlueprints() []Blueprint {
+	blueprints := append([]Blueprint(nil), defaultBlueprints...)
+
+	data, err := os.ReadFile(dataPath(blueprintFile))
+	if err != nil {
+		return blueprints // No custom blueprints
+	}
+	var custom []Blueprint
+	if err := json.Unmarshal(data, &custom); err != nil {
+		log.Printf("Ignoring %s: %v", blueprintFile, err)
+		return blueprints
+	}
+	return append(blueprints, custom...)
+}
+
+// getQuestionsByChapters filters questions belonging to any of the given chapters
+func getQuestionsByChapters(questions []Question, chapters []string) []Question {
+	var filtered []Question
+	for _, chapter := range chapters {
+		filtered = append(filtered, getQuestionsByChapter(questions, chapter)...)
+	}
+	return filtered
+}
+
+// buildPracticeTest draws the questions for a blueprint from its chapters' questions, section
+// by section. Sections with fewer available questions than requested use all of them, so
+// sizes holds how many were drawn for each section.
+func buildPracticeTest(pool *questionIndex, bp Blueprint) (test []Question, sizes []int) {
+	used := make(map[string]bool)
+
+	for _, section := range bp.Sections {
+		var candidates []Question
+		for _, q := range pool.byType[section.Category] {
+			if !used[q.QID] {
+				candidates = append(candidates, q)
+			}
+		}
+		rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		if len(candidates) > section.Count {
+			candidates = candidates[:section.Count]
+		}
+		for _, q := range candidates {
+			used[q.QID] = true
+		}
+		test = append(test, candidates...)
+		sizes = append(sizes, len(candidates))
+	}
+	return test, sizes
+}
+
+// writePracticeTest writes a printable version of a practice test with an answer key. The
+// test is written in order, each section under its heading, as sized by buildPracticeTest.
+func writePracticeTest(w io.Writer, bp Blueprint, test []Question, sizes []int, pool []Question) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", bp.Name)
+	fmt.Fprintf(&b, "Chapters: %s\n\n", strings.Join(bp.Chapters, ", "))
+	fmt.Fprintf(&b, "Name: ____________________   Date: ____________   Score: ____ / %d\n", len(test))
+
+	answers := newAnswerPool(pool)
+	letters := []string{"a", "b", "c", "d"}
+	var key []string
+	start, number := 0, 0
+	for i, section := range bp.Sections {
+		questions := test[start : start+sizes[i]]
+		start += sizes[i]
+		if len(questions) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n== %s ==\n", strings.ToUpper(section.Category))
+		for _, q := range questions {
+			number++
+
+			// Same options as the in-app quiz: three distractors and the answer, shuffled
+			options := append(answers.random(q.QAnswer, 3), q.QAnswer)
+			rand.Shuffle(len(options), func(i, j int) {
+				options[i], options[j] = options[j], options[i]
+			})
+
+			fmt.Fprintf(&b, "\n%d. %s\n", number, q.QHirakata)
+			for i, opt := range options {
+				fmt.Fprintf(&b, "   %s) %s\n", letters[i], opt)
+				if opt == q.QAnswer {
+					key = append(key, fmt.Sprintf("%d. %s) %s", number, letters[i], opt))
+				}
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "\n\nAnswer Key\n")
+	for _, line := range key {
+		fmt.Fprintf(&b, "%s\n", line)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// availableBlueprint limits a blueprint to the chapters that can be selected, as in kiosk mode
+func (qa *quizApp) availableBlueprint(bp Blueprint) Blueprint {
+	available := qa.availableChapters()
+	var chapters []string
+	for _, chapter := range bp.Chapters {
+		if slices.Contains(available, chapter) {
+			chapters = append(chapters, chapter)
+		}
+	}
+	bp.Chapters = chapters
+	return bp
+}
+
+// startPracticeTest runs a blueprint test in the quiz screen
+func (qa *quizApp) startPracticeTest(bp Blueprint) {
+	bp = qa.availableBlueprint(bp)
+	state := qa.state
+	state.quizName = bp.Name
+	state.chapterQuestions = qa.studyChapters(bp.Chapters)
+	state.quizQuestions, _ = buildPracticeTest(newQuestionIndex(state.chapterQuestions), bp)
+	state.currentChapter = strings.Join(bp.Chapters, ", ")
+	state.totalQuestions = len(state.quizQuestions)
+	if state.totalQuestions == 0 {
+		state.reset()
+		dialog.ShowInformation("Practice Test", "No questions match this blueprint.", qa.window)
+		return
+	}
+	qa.startQuiz()
+}
+
+// exportPracticeTest asks for a destination and writes a printable practice test
+func (qa *quizApp) exportPracticeTest(bp Blueprint) {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		pool := qa.index.inChapters(bp.Chapters)
+		test, sizes := buildPracticeTest(newQuestionIndex(pool), bp)
+		if err := writePracticeTest(writer, bp, test, sizes, pool); err != nil {
+			dialog.ShowError(err, qa.window)
+		}
+	}, qa.window)
+	save.SetFileName("practice-test.txt")
+	save.Show()
+}
+
+// showPracticeTestSelection lists the available blueprints
+func (qa *quizApp) showPracticeTestSelection() {
+	rows := container.NewVBox(widget.NewLabelWithStyle(
+		"Practice Tests",
+		fyne.TextAlignCenter,
+		fyne.TextStyle{Bold: true},
+	))
+
+	for _, bp := range loadBlueprints() {
+		bp := bp
+		var parts []string
+		for _, section := range bp.Sections {
+			parts = append(parts, fmt.Sprintf("%d %s", section.Count, section.Category))
+		}
+		rows.Add(widget.NewLabel(fmt.Sprintf("%s (%s)", bp.Name, strings.Join(parts, ", "))))
+		actions := container.NewGridWithColumns(2, widget.NewButton("Start", qa.serialized(func() {
+			qa.startPracticeTest(bp)
+		})))
+		if !qa.kiosk {
+			actions.Add(widget.NewButton("Export", qa.serialized(func() {
+				qa.exportPracticeTest(bp)
+			})))
+		}
+		rows.Add(actions)
+	}
+
+	rows.Add(widget.NewButton("Back to Chapter Selection", qa.serialized(func() {
+		qa.showChapterSelection()
+	})))
+	qa.showScreen(container.NewCenter(rows))
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// bossQuizSize is the number of questions in a chapter's boss quiz
+const bossQuizSize = 15
+
+// bossTimeLimit is the time to answer each question of a boss quiz
+const bossTimeLimit = 20 * time.Second
+
+// bossPassShare is the share of a boss quiz to answer right to conquer the chapter
+const bossPassShare = 0.8
+
+// conquered reports whether the boss quiz of a chapter was passed
+func (p *Progress) conquered(chapter string) bool {
+	_, ok := p.Conquered[chapter]
+	return ok
+}
+
+// bossUnlocked reports whether enough of a chapter is mastered for its boss quiz,
+// the same share that unlocks the next chapter in progression mode
+func (qa *quizApp) bossUnlocked(chapter string) bool {
+	return chapterMastery(qa.questions, chapter, qa.progress) >= qa.settings.Progression.unlockShare()
+}
+
+// bossButton starts the boss quiz of the current chapter, or tells what unlocks it
+func (qa *quizApp) bossButton() *widget.Button {
+	chapter := qa.state.currentChapter
+	label := "Boss Quiz"
+	if qa.progress.conquered(chapter) {
+		label = "👑 Boss Quiz (conquered)"
+	}
+	button := widget.NewButton(label, qa.serialized(qa.startBossQuiz))
+	if !qa.bossUnlocked(chapter) {
+		button.SetText(fmt.Sprintf("🔒 Boss Quiz (master %.0f%% of the chapter)", qa.settings.Progression.unlockShare()*100))
+		button.Disable()
+	}
+	return button
+}
+
+// startBossQuiz tests the current chapter with typed answers in both directions, against
+// the clock and without romaji or near misses. Passing it conquers the chapter.
+func (qa *quizApp) startBossQuiz() {
+	chapter := qa.state.currentChapter
+	questions := append([]Question(nil), qa.state.chapterQuestions...)
+	rand.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+	if len(questions) > bossQuizSize {
+		questions = questions[:bossQuizSize]
+	}
+	// Every other question asks for the Japanese
+	reversed := make(map[string]bool)
+	for i, q := range questions {
+		reversed[q.QID] = i%2 == 1
+	}
+
+	qa.startTypedDrill(&typedDrill{
+		name:      "Boss Quiz",
+		questions: questions,
+		english: func(q Question) bool {
+			return !reversed[q.QID]
+		},
+		timeLimit: bossTimeLimit,
+		prompt: func(q Question) string {
+			if reversed[q.QID] {
+				return "In Japanese: " + q.QAnswer
+			}
+			return q.QHirakata
+		},
+		answer: func(q Question) string {
+			if reversed[q.QID] {
+				return q.QHirakata
+			}
+			return q.QAnswer
+		},
+		check: func(q Question, input string) (bool, string) {
+			if reversed[q.QID] {
+				return qa.checkSpelling(q, input)
+			}
+			return qa.checkMeaning(q, input)
+		},
+		onFinish: func(score float64, answered int) {
+			if answered < len(questions) {
+				return
+			}
+			if score < bossPassShare*float64(len(questions)) {
+				dialog.ShowInformation("Boss Quiz",
+					fmt.Sprintf("Not this time. Answer %.0f%% right to conquer chapter %s.", bossPassShare*100, chapter), qa.window)
+				return
+			}
+			if qa.progress.Conquered == nil {
+				qa.progress.Conquered = make(map[string]time.Time)
+			}
+			if !qa.progress.conquered(chapter) {
+				qa.progress.Conquered[chapter] = time.Now()
+				if err := qa.progress.save(); err != nil {
+					log.Printf("Failed to save progress: %v", err)
+				}
+				qa.progressChanged()
+			}
+			dialog.ShowInformation("Boss Quiz", fmt.Sprintf("👑 Chapter %s conquered!", chapter), qa.window)
+		},
+	})
+}
+
+// conqueredChapters lists the conquered chapters among those given
+func (qa *quizApp) conqueredChapters(chapters []string) []string {
+	var conquered []string
+	for _, chapter := range chapters {
+		if qa.progress.conquered(chapter) {
+			conquered = append(conquered, chapter)
+		}
+	}
+	return conquered
+}
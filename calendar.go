@@ -0,0 +1,285 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// calendarRounds is the number of dates asked in a calendar drill
+const calendarRounds = 10
+
+// monthKana are the readings of the months, from 1月 to 12月
+var monthKana = []string{"", "いちがつ", "にがつ", "さんがつ", "しがつ", "ごがつ", "ろくがつ",
+	"しちがつ", "はちがつ", "くがつ", "じゅうがつ", "じゅういちがつ", "じゅうにがつ"}
+
+// dayKana are the readings of the days of the month that are not a number and にち
+var dayKana = map[int]string{
+	1: "ついたち", 2: "ふつか", 3: "みっか", 4: "よっか", 5: "いつか",
+	6: "むいか", 7: "なのか", 8: "ようか", 9: "ここのか", 10: "とおか",
+	14: "じゅうよっか", 20: "はつか", 24: "にじゅうよっか",
+}
+
+// weekdayKanji are the days of the week as headed on a Japanese calendar, from Sunday
+var weekdayKanji = []string{"日", "月", "火", "水", "木", "金", "土"}
+
+// dayReading reads a day of the month, e.g. 14 is じゅうよっか and 19 is じゅうくにち
+func dayReading(day int) string {
+	if reading, ok := dayKana[day]; ok {
+		return reading
+	}
+	reading := numberReading(day)
+	switch day % 10 {
+	case 7:
+		reading = strings.TrimSuffix(reading, "なな") + "しち"
+	case 9:
+		reading = strings.TrimSuffix(reading, "きゅう") + "く"
+	}
+	return reading + "にち"
+}
+
+// dateReadings returns the ways to say a date, the usual one first. じゅうななにち and
+// じゅうきゅうにち are heard too.
+func dateReadings(month, day int) []string {
+	readings := []string{monthKana[month] + dayReading(day)}
+	if day > 10 && (day%10 == 7 || day%10 == 9) {
+		readings = append(readings, monthKana[month]+numberReading(day)+"にち")
+	}
+	return readings
+}
+
+// naiveDateReading reads a date as a number and にち, the way learners misread the irregular days
+func naiveDateReading(month, day int) string {
+	return numberReading(month) + "がつ" + numberReading(day) + "にち"
+}
+
+// dateChoices returns the usual reading of a date and three wrong ones, shuffled: the
+// date read as plain numbers, and the irregular days that sound alike, such as よっか
+// and ようか, or はつか and はちにち
+func dateChoices(month, day int) []string {
+	right := dateReadings(month, day)
+	var wrong []string
+	add := func(reading string) {
+		for _, r := range right {
+			if r == reading {
+				return
+			}
+		}
+		for _, w := range wrong {
+			if w == reading {
+				return
+			}
+		}
+		wrong = append(wrong, reading)
+	}
+	add(naiveDateReading(month, day))
+	alike := map[int][]int{4: {8, 14}, 8: {4, 20}, 14: {4, 24}, 20: {8, 2}, 24: {14, 4},
+		1: {7, 2}, 2: {20, 3}, 3: {6, 8}, 6: {3, 7}, 7: {6, 1}, 9: {10, 19}, 10: {9, 5}, 5: {10, 1}}
+	for _, other := range alike[day] {
+		add(monthKana[month] + dayReading(other))
+	}
+	add(monthKana[month%12+1] + dayReading(day))
+	rand.Shuffle(len(wrong), func(i, j int) { wrong[i], wrong[j] = wrong[j], wrong[i] })
+	for len(wrong) < 3 {
+		add(monthKana[month] + dayReading(rand.Intn(31)+1))
+	}
+
+	choices := append([]string{right[0]}, wrong[:3]...)
+	rand.Shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	return choices
+}
+
+// randomDate picks a day of this year, mostly one of the days with an irregular reading
+func randomDate(now time.Time) time.Time {
+	date := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.Local).AddDate(0, 0, rand.Intn(365))
+	if rand.Intn(3) > 0 {
+		irregular := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 14, 20, 24}
+		date = time.Date(date.Year(), date.Month(), irregular[rand.Intn(len(irregular))], 0, 0, 0, 0, time.Local)
+	}
+	return date
+}
+
+// calendarPage draws the month of a date as on a wall calendar, with the date highlighted
+func calendarPage(date time.Time) fyne.CanvasObject {
+	grid := container.NewGridWithColumns(7)
+	for _, weekday := range weekdayKanji {
+		grid.Add(widget.NewLabelWithStyle(weekday, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))
+	}
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.Local)
+	for i := 0; i < int(first.Weekday()); i++ {
+		grid.Add(widget.NewLabel(""))
+	}
+	days := first.AddDate(0, 1, -1).Day()
+	for day := 1; day <= days; day++ {
+		label := widget.NewLabelWithStyle(strconv.Itoa(day), fyne.TextAlignCenter, fyne.TextStyle{})
+		if day != date.Day() {
+			grid.Add(label)
+			continue
+		}
+		label.TextStyle = fyne.TextStyle{Bold: true}
+		mark := canvas.NewRectangle(theme.PrimaryColor())
+		mark.CornerRadius = 6
+		grid.Add(container.NewStack(mark, label))
+	}
+	return container.NewVBox(
+		widget.NewLabelWithStyle(fmt.Sprintf("%d年 %d月", date.Year(), date.Month()), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		grid,
+	)
+}
+
+// calendarDrill shows dates on a calendar and asks for their Japanese reading, picked or typed
+type calendarDrill struct {
+	qa      *quizApp
+	typed   bool
+	round   int
+	correct int
+	missed  []string
+}
+
+// showCalendarDrill lets the learner choose to pick or type the readings
+func (qa *quizApp) showCalendarDrill() {
+	mode := widget.NewRadioGroup([]string{"Choose the reading", "Type the reading"}, nil)
+	mode.SetSelected("Choose the reading")
+	startButton := widget.NewButton("Start", qa.serialized(func() {
+		d := &calendarDrill{qa: qa, typed: mode.Selected == "Type the reading"}
+		d.showRound()
+	}))
+	startButton.Importance = widget.HighImportance
+
+	qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Calendar Dates", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("Say %d dates in Japanese, with the irregular days such as ついたち and はつか.", calendarRounds)),
+		mode,
+		startButton,
+		widget.NewButton("Back to Chapter Selection", qa.serialized(func() {
+			qa.showChapterSelection()
+		})),
+	)))
+}
+
+// showRound shows a calendar with a date marked and the choices or an answer field
+func (d *calendarDrill) showRound() {
+	if d.round >= calendarRounds {
+		d.showResults()
+		return
+	}
+	date := randomDate(time.Now())
+	month, day := int(date.Month()), date.Day()
+	readings := dateReadings(month, day)
+	feedback := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{})
+	answered := false
+	next := widget.NewButton("Next", d.qa.serialized(d.showRound))
+	next.Importance = widget.HighImportance
+	next.Hide()
+
+	grade := func(correct bool) {
+		answered = true
+		d.round++
+		answer := fmt.Sprintf("%d月%d日 is %s", month, day, strings.Join(readings, " or "))
+		if correct {
+			d.correct++
+			feedback.SetText("✅ " + answer)
+		} else {
+			d.missed = append(d.missed, answer)
+			feedback.SetText("❌ " + answer)
+		}
+		next.Show()
+	}
+
+	var answer fyne.CanvasObject
+	var entry *widget.Entry
+	if d.typed {
+		entry = widget.NewEntry()
+		entry.SetPlaceHolder("Type the date in kana or romaji, then press Enter")
+		entry.OnSubmitted = func(input string) {
+			if answered {
+				d.showRound()
+				return
+			}
+			if strings.TrimSpace(input) == "" {
+				return
+			}
+			typed := strings.ReplaceAll(typedKana(input), " ", "")
+			correct := false
+			for _, reading := range readings {
+				correct = correct || typed == reading
+			}
+			grade(correct)
+		}
+		answer = entry
+	} else {
+		choices := dateChoices(month, day)
+		buttons := make([]*widget.Button, len(choices))
+		grid := container.NewGridWithColumns(2)
+		for i, choice := range choices {
+			choice := choice
+			buttons[i] = widget.NewButton(choice, d.qa.serialized(func() {
+				if answered {
+					return
+				}
+				for j, button := range buttons {
+					if choices[j] == readings[0] {
+						button.SetText("✅ " + button.Text)
+					} else if choices[j] == choice {
+						button.SetText("❌ " + button.Text)
+					}
+				}
+				grade(choice == readings[0])
+			}))
+			grid.Add(buttons[i])
+		}
+		answer = grid
+	}
+
+	d.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Calendar Dates — %d/%d — Score: %d", d.round+1, calendarRounds, d.correct),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		container.NewGridWithColumns(2,
+			next,
+			widget.NewButton("End Drill", d.qa.serialized(d.showResults)),
+		),
+		nil, nil,
+		container.NewVBox(
+			layoutSpacer(),
+			widget.NewLabelWithStyle("What is the marked date? なんがつなんにちですか。", fyne.TextAlignCenter, fyne.TextStyle{}),
+			container.NewCenter(calendarPage(date)),
+			answer,
+			feedback,
+		),
+	))
+	if entry != nil {
+		d.qa.window.Canvas().Focus(entry)
+	}
+}
+
+// showResults shows how many dates were read right and the ones missed
+func (d *calendarDrill) showResults() {
+	if d.round >= calendarRounds {
+		d.qa.earnPerfectXP(float64(d.correct), calendarRounds)
+	}
+	results := container.NewVBox(
+		widget.NewLabelWithStyle("Calendar Dates Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("You read %d of %d dates correctly.", d.correct, d.round)),
+	)
+	if len(d.missed) > 0 {
+		results.Add(widget.NewLabel("To review:\n" + strings.Join(d.missed, "\n")))
+	}
+	results.Add(widget.NewButton("Try Again", d.qa.serialized(func() {
+		d.qa.showCalendarDrill()
+	})))
+	results.Add(widget.NewButton("Return to Chapter Selection", d.qa.serialized(func() {
+		d.qa.showChapterSelection()
+	})))
+	d.qa.showScreen(container.NewCenter(results))
+}
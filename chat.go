@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// chatBackend reads a live stream's chat so viewers can answer a hosted quiz
+type chatBackend interface {
+	// listen calls onMessage for every chat message until stop is closed or the connection fails
+	listen(stop <-chan struct{}, onMessage func(user, text string)) error
+	// String names the chat for the host, e.g. "Twitch #genki"
+	String() string
+}
+
+// chatSettings remembers the chat a hosted quiz was last connected to
+type chatSettings struct {
+	Backend        string `json:"backend"` // "twitch" or "discord"
+	TwitchChannel  string `json:"twitch_channel"`
+	DiscordChannel string `json:"discord_channel"`
+}
+
+// discordToken is the Discord bot token last entered, kept for this run only so it is
+// never written to the settings. The bot needs the Message Content intent.
+var discordToken = os.Getenv(envDiscordToken)
+
+// chatVote reads an answer command like "!2" or "!b" from a chat message.
+// It returns the 0-based option.
+func chatVote(text string, options int) (int, bool) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if len(text) != 2 || text[0] != '!' {
+		return 0, false
+	}
+	option := -1
+	switch c := text[1]; {
+	case c >= '1' && c <= '9':
+		option = int(c - '1')
+	case c >= 'a' && c <= 'z':
+		option = int(c - 'a')
+	}
+	return option, option >= 0 && option < options
+}
+
+// twitchChat reads a Twitch channel's chat anonymously, without an account
+type twitchChat struct {
+	channel string
+}
+
+// String names the channel
+func (t twitchChat) String() string {
+	return "Twitch #" + t.channel
+}
+
+// listen reads the chat over Twitch's IRC interface
+func (t twitchChat) listen(stop <-chan struct{}, onMessage func(user, text string)) error {
+	conn, err := tls.Dial("tcp", "irc.chat.twitch.tv:6697", nil)
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stop:
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	// justinfan users are Twitch's read-only anonymous logins
+	channel := strings.ToLower(strings.TrimPrefix(t.channel, "#"))
+	if _, err := fmt.Fprintf(conn, "PASS SCHMOOPIIE\r\nNICK justinfan%d\r\nJOIN #%s\r\n", 10000+rand.Intn(80000), channel); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "PING") {
+			fmt.Fprintf(conn, "PONG%s\r\n", strings.TrimPrefix(line, "PING"))
+			continue
+		}
+		// :user!user@user.tmi.twitch.tv PRIVMSG #channel :text
+		prefix, rest, ok := strings.Cut(line, " PRIVMSG ")
+		if !ok || !strings.HasPrefix(prefix, ":") {
+			continue
+		}
+		user, _, _ := strings.Cut(prefix[1:], "!")
+		if _, text, ok := strings.Cut(rest, " :"); ok {
+			onMessage(user, text)
+		}
+	}
+	select {
+	case <-stop:
+		return nil
+	default:
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return errors.New("twitch closed the connection")
+}
+
+// discordChat reads one channel of a Discord server through a bot
+type discordChat struct {
+	token   string
+	channel string // Channel ID
+}
+
+// String names the channel
+func (d discordChat) String() string {
+	return "Discord channel " + d.channel
+}
+
+// discordGatewayURL is Discord's real-time gateway
+const discordGatewayURL = "wss://gateway.discord.gg/?v=10&encoding=json"
+
+// Gateway intents for reading server messages
+const discordIntents = 1<<9 | 1<<15 // GUILD_MESSAGES | MESSAGE_CONTENT
+
+// discordPayload is a message on the gateway
+type discordPayload struct {
+	Op int             `json:"op"`
+	D  json.RawMessage `json:"d"`
+	S  *int            `json:"s,omitempty"`
+	T  string          `json:"t,omitempty"`
+}
+
+// listen reads the channel's messages over the gateway
+func (d discordChat) listen(stop <-chan struct{}, onMessage func(user, text string)) error {
+	conn, err := websocket.Dial(discordGatewayURL, "", "https://discord.com")
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stop:
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	var hello discordPayload
+	if err := websocket.JSON.Receive(conn, &hello); err != nil {
+		return err
+	}
+	var helloData struct {
+		HeartbeatInterval int `json:"heartbeat_interval"`
+	}
+	if err := json.Unmarshal(hello.D, &helloData); err != nil || helloData.HeartbeatInterval <= 0 {
+		return errors.New("unexpected greeting from discord")
+	}
+
+	// The gateway closes the connection unless it hears a heartbeat with the last sequence number
+	sequence := make(chan *int, 1)
+	go func() {
+		ticker := time.NewTicker(time.Duration(helloData.HeartbeatInterval) * time.Millisecond)
+		defer ticker.Stop()
+		var last *int
+		for {
+			select {
+			case <-done:
+				return
+			case last = <-sequence:
+			case <-ticker.C:
+				websocket.JSON.Send(conn, map[string]any{"op": 1, "d": last})
+			}
+		}
+	}()
+
+	identify := map[string]any{
+		"op": 2,
+		"d": map[string]any{
+			"token":   d.token,
+			"intents": discordIntents,
+			"properties": map[string]string{
+				"os":      runtime.GOOS,
+				"browser": "GenkiQuiz",
+				"device":  "GenkiQuiz",
+			},
+		},
+	}
+	if err := websocket.JSON.Send(conn, identify); err != nil {
+		return err
+	}
+
+	for {
+		var payload discordPayload
+		if err := websocket.JSON.Receive(conn, &payload); err != nil {
+			select {
+			case <-stop:
+				return nil
+			default:
+			}
+			return err
+		}
+		if payload.S != nil {
+			select {
+			case <-sequence: // Replace a number the heartbeat has not picked up yet
+			default:
+			}
+			sequence <- payload.S
+		}
+		switch payload.Op {
+		case 7, 9: // Reconnect, Invalid Session
+			return errors.New("discord ended the session; check the bot token and intents")
+		case 0:
+			if payload.T != "MESSAGE_CREATE" {
+				continue
+			}
+			var message struct {
+				ChannelID string `json:"channel_id"`
+				Content   string `json:"content"`
+				Author    struct {
+					Username string `json:"username"`
+					Bot      bool   `json:"bot"`
+				} `json:"author"`
+			}
+			if json.Unmarshal(payload.D, &message) == nil && message.ChannelID == d.channel && !message.Author.Bot {
+				onMessage(message.Author.Username, message.Content)
+			}
+		}
+	}
+}
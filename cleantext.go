@@ -0,0 +1,21 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// invisibleChars are characters with no width that spreadsheets and web pages leave in
+// text: zero-width space, non-joiner and joiner, word joiner, byte order mark and soft hyphen
+var invisibleChars = strings.NewReplacer("\u200B", "", "\u200C", "", "\u200D", "", "\u2060", "", "\uFEFF", "", "\u00AD", "")
+
+// cleanText normalizes text loaded from a deck or an import, or typed into an edit, so
+// that typed answers and duplicates are not thrown off by what cannot be seen: it is
+// composed to NFC, invisible characters are removed, and runs of spaces of any width,
+// tabs and line breaks become one space, with none at either end
+func cleanText(text string) string {
+	text = invisibleChars.Replace(norm.NFC.String(text))
+	return strings.Join(strings.FieldsFunc(text, unicode.IsSpace), " ")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// contextMenuArea shows a menu when its content is right-clicked or long-pressed
+type contextMenuArea struct {
+	widget.BaseWidget
+	content fyne.CanvasObject
+	menu    func() *fyne.Menu // Built when opened, so it reflects the current state
+	window  fyne.Window
+}
+
+// newContextMenuArea wraps content so it opens menu on a secondary tap
+func newContextMenuArea(content fyne.CanvasObject, window fyne.Window, menu func() *fyne.Menu) *contextMenuArea {
+	area := &contextMenuArea{content: content, menu: menu, window: window}
+	area.ExtendBaseWidget(area)
+	return area
+}
+
+// CreateRenderer draws the wrapped content
+func (a *contextMenuArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(a.content)
+}
+
+// TappedSecondary opens the menu where the area was right-clicked or long-pressed
+func (a *contextMenuArea) TappedSecondary(e *fyne.PointEvent) {
+	widget.ShowPopUpMenuAtPosition(a.menu(), a.window.Canvas(), e.AbsolutePosition)
+}
+
+// copyToClipboard puts text on the clipboard
+func (qa *quizApp) copyToClipboard(text string) {
+	qa.window.Clipboard().SetContent(text)
+}
+
+// questionMenu offers to copy the question being shown, and the curation actions on it
+func (qa *quizApp) questionMenu() *fyne.Menu {
+	q := qa.state.current
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem("Copy Question", func() {
+			qa.copyToClipboard(fmt.Sprintf("%s (%s) — %s", q.QHirakata, q.QRomaji, q.QAnswer))
+		}),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Copy Kana", func() {
+			qa.copyToClipboard(q.QHirakata)
+		}),
+		fyne.NewMenuItem("Copy Romaji", func() {
+			qa.copyToClipboard(q.QRomaji)
+		}),
+		fyne.NewMenuItem("Copy Answer", func() {
+			qa.copyToClipboard(q.QAnswer)
+		}),
+	)
+	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
+	menu.Items = append(menu.Items, qa.curationItems(q)...)
+	return menu
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// clockRounds is the number of clocks shown in a clock reading drill
+const clockRounds = 10
+
+// clockSize is the width and height of the clock face
+const clockSize = 220
+
+// clockTime is a time of the day on the 12-hour clock
+type clockTime struct {
+	hour, minute int
+}
+
+// randomClockTime picks a time, mostly on five minutes as in the textbook, sometimes on any minute
+func randomClockTime() clockTime {
+	minute := rand.Intn(12) * 5
+	if rand.Intn(4) == 0 {
+		minute = rand.Intn(60)
+	}
+	return clockTime{hour: rand.Intn(12) + 1, minute: minute}
+}
+
+// readings returns the ways to say a time, the usual one first: half past is はん,
+// and ten minutes may be じっぷん as well as じゅっぷん
+func (t clockTime) readings() []string {
+	var readings []string
+	if t.minute == 30 {
+		readings = append(readings, hourReading(t.hour)+"はん")
+	}
+	reading := timeReading(t.hour, t.minute)
+	readings = append(readings, reading)
+	if strings.Contains(reading, "じゅっぷん") {
+		readings = append(readings, strings.Replace(reading, "じゅっぷん", "じっぷん", 1))
+	}
+	return readings
+}
+
+// naiveClockReading reads a time with no sound changes, the way learners often misread it:
+// よんじ for よじ, さんふん for さんぷん
+func naiveClockReading(t clockTime) string {
+	reading := numberReading(t.hour) + "じ"
+	if t.minute > 0 {
+		reading += numberReading(t.minute) + "ふん"
+	}
+	return reading
+}
+
+// clockChoices returns the usual reading of a time and three wrong ones, shuffled. The
+// wrong ones are the mistakes a learner makes: missed sound changes, an hour hand read
+// one hour off, or the hands taken for each other.
+func clockChoices(t clockTime) []string {
+	right := t.readings()
+	isRight := func(reading string) bool {
+		for _, r := range right {
+			if r == reading {
+				return true
+			}
+		}
+		return false
+	}
+	var wrong []string
+	add := func(reading string) {
+		for _, w := range wrong {
+			if w == reading {
+				return
+			}
+		}
+		if !isRight(reading) {
+			wrong = append(wrong, reading)
+		}
+	}
+	add(naiveClockReading(t))
+	add(timeReading(t.hour%12+1, t.minute))
+	if t.minute%5 == 0 && t.minute > 0 && t.hour < 12 {
+		add(timeReading(t.minute/5, t.hour*5))
+	}
+	add(timeReading((t.hour+10)%12+1, t.minute))
+	rand.Shuffle(len(wrong), func(i, j int) { wrong[i], wrong[j] = wrong[j], wrong[i] })
+	for len(wrong) < 3 {
+		add(timeReading(rand.Intn(12)+1, rand.Intn(12)*5))
+	}
+
+	choices := append([]string{right[0]}, wrong[:3]...)
+	rand.Shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	return choices
+}
+
+// clockFace draws an analog clock showing a time
+func clockFace(t clockTime) fyne.CanvasObject {
+	ink := theme.ForegroundColor()
+	center := fyne.NewPos(clockSize/2, clockSize/2)
+	radius := float32(clockSize/2 - 4)
+	point := func(angle float64, length float32) fyne.Position {
+		// Angles are in clock turns from twelve
+		rad := angle * 2 * math.Pi
+		return fyne.NewPos(center.X+length*float32(math.Sin(rad)), center.Y-length*float32(math.Cos(rad)))
+	}
+
+	frame := canvas.NewRectangle(color.Transparent)
+	frame.SetMinSize(fyne.NewSize(clockSize, clockSize))
+	face := canvas.NewCircle(color.Transparent)
+	face.StrokeColor = ink
+	face.StrokeWidth = 3
+	face.Move(fyne.NewPos(center.X-radius, center.Y-radius))
+	face.Resize(fyne.NewSize(2*radius, 2*radius))
+	objects := []fyne.CanvasObject{frame, face}
+
+	for i := 0; i < 60; i++ {
+		inner := radius * 0.94
+		if i%5 == 0 {
+			inner = radius * 0.86
+		}
+		tick := canvas.NewLine(ink)
+		tick.StrokeWidth = 1
+		if i%5 == 0 {
+			tick.StrokeWidth = 2
+		}
+		tick.Position1 = point(float64(i)/60, inner)
+		tick.Position2 = point(float64(i)/60, radius)
+		objects = append(objects, tick)
+	}
+	for hour := 1; hour <= 12; hour++ {
+		number := canvas.NewText(strconv.Itoa(hour), ink)
+		number.TextSize = 16
+		size := number.MinSize()
+		pos := point(float64(hour)/12, radius*0.7)
+		number.Move(fyne.NewPos(pos.X-size.Width/2, pos.Y-size.Height/2))
+		objects = append(objects, number)
+	}
+
+	hand := func(angle float64, length float32, width float32, c color.Color) *canvas.Line {
+		line := canvas.NewLine(c)
+		line.StrokeWidth = width
+		line.Position1 = center
+		line.Position2 = point(angle, length)
+		return line
+	}
+	hourAngle := (float64(t.hour%12) + float64(t.minute)/60) / 12
+	objects = append(objects,
+		hand(hourAngle, radius*0.5, 6, ink),
+		hand(float64(t.minute)/60, radius*0.8, 3, theme.PrimaryColor()),
+	)
+	return container.NewCenter(container.NewWithoutLayout(objects...))
+}
+
+// clockDrill shows clocks and asks for the Japanese time, picked or typed
+type clockDrill struct {
+	qa      *quizApp
+	typed   bool
+	round   int
+	correct int
+}
+
+// showClockDrill lets the learner choose to pick or type the times
+func (qa *quizApp) showClockDrill() {
+	mode := widget.NewRadioGroup([]string{"Choose the reading", "Type the reading"}, nil)
+	mode.SetSelected("Choose the reading")
+	startButton := widget.NewButton("Start", qa.serialized(func() {
+		d := &clockDrill{qa: qa, typed: mode.Selected == "Type the reading"}
+		d.showRound()
+	}))
+	startButton.Importance = widget.HighImportance
+
+	qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Clock Reading", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("Say the time on %d clocks in Japanese, with 半 and the sounds of 分.", clockRounds)),
+		mode,
+		startButton,
+		widget.NewButton("Back to Chapter Selection", qa.serialized(func() {
+			qa.showChapterSelection()
+		})),
+	)))
+}
+
+// showRound shows a clock with the choices or an answer field
+func (d *clockDrill) showRound() {
+	if d.round >= clockRounds {
+		d.showResults()
+		return
+	}
+	t := randomClockTime()
+	readings := t.readings()
+	feedback := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{})
+	answered := false
+	next := widget.NewButton("Next", d.qa.serialized(d.showRound))
+	next.Importance = widget.HighImportance
+	next.Hide()
+
+	grade := func(correct bool) {
+		answered = true
+		d.round++
+		if correct {
+			d.correct++
+		}
+		answer := fmt.Sprintf("%d:%02d is %s", t.hour, t.minute, strings.Join(readings, " or "))
+		if correct {
+			feedback.SetText("✅ " + answer)
+		} else {
+			feedback.SetText("❌ " + answer)
+		}
+		next.Show()
+	}
+
+	var answer fyne.CanvasObject
+	var entry *widget.Entry
+	if d.typed {
+		entry = widget.NewEntry()
+		entry.SetPlaceHolder("Type the time in kana or romaji, then press Enter")
+		entry.OnSubmitted = func(input string) {
+			if answered {
+				d.showRound()
+				return
+			}
+			if strings.TrimSpace(input) == "" {
+				return
+			}
+			typed := typedKana(input)
+			correct := false
+			for _, reading := range readings {
+				correct = correct || typed == reading
+			}
+			grade(correct)
+		}
+		answer = entry
+	} else {
+		choices := clockChoices(t)
+		buttons := make([]*widget.Button, len(choices))
+		grid := container.NewGridWithColumns(2)
+		for i, choice := range choices {
+			choice := choice
+			buttons[i] = widget.NewButton(choice, d.qa.serialized(func() {
+				if answered {
+					return
+				}
+				for j, button := range buttons {
+					if choices[j] == readings[0] {
+						button.SetText("✅ " + button.Text)
+					} else if choices[j] == choice {
+						button.SetText("❌ " + button.Text)
+					}
+				}
+				grade(choice == readings[0])
+			}))
+			grid.Add(buttons[i])
+		}
+		answer = grid
+	}
+
+	d.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Clock Reading — %d/%d — Score: %d", d.round+1, clockRounds, d.correct),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		container.NewGridWithColumns(2,
+			next,
+			widget.NewButton("End Drill", d.qa.serialized(d.showResults)),
+		),
+		nil, nil,
+		container.NewVBox(
+			layoutSpacer(),
+			widget.NewLabelWithStyle("What time is it? なんじですか。", fyne.TextAlignCenter, fyne.TextStyle{}),
+			clockFace(t),
+			answer,
+			feedback,
+		),
+	))
+	if entry != nil {
+		d.qa.window.Canvas().Focus(entry)
+	}
+}
+
+// showResults shows how many clocks were read right
+func (d *clockDrill) showResults() {
+	if d.round >= clockRounds {
+		d.qa.earnPerfectXP(float64(d.correct), clockRounds)
+	}
+	d.qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Clock Reading Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("You read %d of %d clocks correctly.", d.correct, d.round)),
+		widget.NewButton("Try Again", d.qa.serialized(func() {
+			d.qa.showClockDrill()
+		})),
+		widget.NewButton("Return to Chapter Selection", d.qa.serialized(func() {
+			d.qa.showChapterSelection()
+		})),
+	)))
+}
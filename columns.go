@@ -0,0 +1,359 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xuri/excelize/v2"
+)
+
+// headerSearchRows is how many rows from the top of a sheet may hold the header, below a title for example
+const headerSearchRows = 10
+
+// minHeaderMatches is how many columns a row must name, by columnAliases, to be taken for the header
+const minHeaderMatches = 2
+
+// columnAliases are the header names each field is found by, compared by headerKey
+var columnAliases = map[string][]string{
+	"QID":       {"qid", "id", "questionid", "no", "number"},
+	"QChapter":  {"qchapter", "chapter", "lesson", "ch", "課"},
+	"QAnswer":   {"qanswer", "answer", "english", "meaning", "translation", "definition"},
+	"QHirakata": {"qhirakata", "hiragana", "kana", "hirakata", "reading", "japanese", "かな", "ひらがな"},
+	"QRomaji":   {"qromaji", "romaji", "romanization"},
+	"QType":     {"qtype", "type", "category", "partofspeech", "pos", "wordtype"},
+	"QExample":  {"qexample", "example", "sentence", "examplesentence"},
+	"QKanji":    {"qkanji", "kanji", "漢字"},
+	"QRequires": {"qrequires", "requires", "prerequisites"},
+	"QOrigin":   {"qorigin", "origin", "source", "sourceword", "loanword", "etymology"},
+}
+
+// columnLabels name the fields in the column mapping dialog
+var columnLabels = map[string]string{
+	"QID":       "ID",
+	"QChapter":  "Chapter",
+	"QAnswer":   "Answer (English)",
+	"QHirakata": "Kana",
+	"QRomaji":   "Romaji",
+	"QType":     "Type",
+	"QExample":  "Example",
+	"QKanji":    "Kanji",
+	"QRequires": "Requires",
+	"QOrigin":   "Origin",
+}
+
+// neededColumns are the fields a question cannot do without; the others are filled in when
+// missing, and so is the kana of a deck with romaji
+var neededColumns = []string{"QHirakata", "QAnswer"}
+
+// errEmptySheet is returned for a question sheet without any rows
+var errEmptySheet = errors.New("the question sheet is empty")
+
+// errColumnsCancelled is returned when the learner does not confirm the columns of a deck
+var errColumnsCancelled = errors.New("the columns of the deck were not confirmed")
+
+// headerKey simplifies a header name for comparing: no case, spaces, underscores or hyphens
+func headerKey(name string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "", "　", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// deckLayout tells which row of a question sheet is the header and which column holds each field
+type deckLayout struct {
+	HeaderRow int              // 1-based
+	Header    []string         // Cells of the header row
+	Named     bool             // Columns were found by name; otherwise they are in the order of deckColumns
+	Columns   map[string]int   // 0-based column of each field by deckColumns name, missing when there is none
+	Choices   map[string][]int // Fields more than one column could hold or that no column names, with the columns to choose from
+	Romaji    romajiStyle      // How romaji missing from the deck is spelled
+}
+
+// matchColumns returns, for each field, the columns whose header names it
+func matchColumns(header []string) map[string][]int {
+	matches := make(map[string][]int)
+	for i, name := range header {
+		key := headerKey(name)
+		for field, aliases := range columnAliases {
+			for _, alias := range aliases {
+				if key == alias {
+					matches[field] = append(matches[field], i)
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// detectLayout finds the header among the first rows of a sheet: the first row naming the most
+// fields. A sheet without one keeps the layout of deckColumns, with the header in the first row.
+// saved are column mappings the learner confirmed before, by headerSignature.
+func detectLayout(rows [][]string, saved map[string]map[string]int) deckLayout {
+	best, bestMatches := -1, 0
+	for i, row := range rows {
+		if n := len(matchColumns(row)); n > bestMatches {
+			best, bestMatches = i, n
+		}
+	}
+	if best < 0 || bestMatches < minHeaderMatches || isFixedHeader(rows[best]) {
+		return fixedLayout(rows)
+	}
+
+	header := rows[best]
+	layout := deckLayout{HeaderRow: best + 1, Header: header, Named: true, Columns: make(map[string]int), Choices: make(map[string][]int)}
+	if columns, ok := saved[headerSignature(header)]; ok {
+		for field, column := range columns {
+			layout.Columns[field] = column
+		}
+		return layout
+	}
+	for field, columns := range matchColumns(header) {
+		layout.Columns[field] = columns[0]
+		if len(columns) > 1 {
+			layout.Choices[field] = columns
+		}
+	}
+	for _, field := range layout.missingColumns() {
+		all := make([]int, len(header))
+		for i := range all {
+			all[i] = i
+		}
+		layout.Choices[field] = all
+	}
+	return layout
+}
+
+// isFixedHeader reports whether a header starts with the columns of deckColumns, in their order
+func isFixedHeader(header []string) bool {
+	for i, name := range requiredColumns {
+		if i >= len(header) || strings.TrimSpace(header[i]) != name {
+			return false
+		}
+	}
+	return true
+}
+
+// fixedLayout is the layout of deckColumns: required columns by position, optional ones by name
+func fixedLayout(rows [][]string) deckLayout {
+	layout := deckLayout{HeaderRow: 1, Columns: make(map[string]int)}
+	if len(rows) > 0 {
+		layout.Header = rows[0]
+	}
+	for i, name := range requiredColumns {
+		layout.Columns[name] = i
+	}
+	for i, name := range layout.Header {
+		for _, optional := range deckColumns[len(requiredColumns):] {
+			if strings.TrimSpace(name) == optional {
+				layout.Columns[optional] = i
+			}
+		}
+	}
+	return layout
+}
+
+// headerSignature identifies a header, to remember the columns confirmed for it
+func headerSignature(header []string) string {
+	keys := make([]string, len(header))
+	for i, name := range header {
+		keys[i] = headerKey(name)
+	}
+	return strings.Join(keys, "|")
+}
+
+// missingColumns returns the fields of neededColumns that no column holds. Kana is not
+// missing from a deck with romaji, as it is made from the romaji.
+func (l deckLayout) missingColumns() []string {
+	var missing []string
+	for _, field := range neededColumns {
+		_, found := l.Columns[field]
+		if _, romaji := l.Columns["QRomaji"]; !found && !(field == "QHirakata" && romaji) {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// needsConfirmation reports whether the learner should confirm which column holds which field
+func (l deckLayout) needsConfirmation() bool {
+	return len(l.Choices) > 0
+}
+
+// question reads the question in a row below the header. Rows without kana or an answer are
+// skipped, as are rows short of the required cells in the layout of deckColumns. Missing kana is
+// made from the romaji and missing romaji from the kana, a missing ID from the row number, and a
+// missing chapter is 1.
+func (l deckLayout) question(number int, row []string) (Question, bool) {
+	cell := func(field string) string {
+		if i, ok := l.Columns[field]; ok && i < len(row) {
+			return cleanText(row[i])
+		}
+		return ""
+	}
+	if !l.Named && len(row) < len(requiredColumns) {
+		return Question{}, false
+	}
+	q := Question{
+		QID:       cell("QID"),
+		QChapter:  cell("QChapter"),
+		QAnswer:   cell("QAnswer"),
+		QHirakata: cell("QHirakata"),
+		QRomaji:   cell("QRomaji"),
+		QType:     cell("QType"),
+		QExample:  cell("QExample"),
+		QKanji:    cell("QKanji"),
+		QRequires: cell("QRequires"),
+		QOrigin:   cell("QOrigin"),
+	}
+	if q.QHirakata == "" && q.QRomaji != "" {
+		q.QHirakata, q.KanaCheck = kanaFromRomaji(q.QRomaji)
+		q.KanaFromRomaji = true
+	}
+	if q.QRomaji == "" {
+		q.QRomaji = questionRomaji(q, l.Romaji)
+	}
+	if !l.Named {
+		return q, true
+	}
+	if q.QHirakata == "" || q.QAnswer == "" {
+		return Question{}, false
+	}
+	if q.QID == "" {
+		q.QID = fmt.Sprintf("R%d", number)
+	}
+	if q.QChapter == "" {
+		q.QChapter = "1"
+	}
+	return q, true
+}
+
+// sheetLayout detects the layout of a sheet from its first rows
+func sheetLayout(f *excelize.File, sheet string, saved map[string]map[string]int) (deckLayout, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return deckLayout{}, err
+	}
+	defer rows.Close()
+	var top [][]string
+	for len(top) < headerSearchRows && rows.Next() {
+		row, err := rows.Columns()
+		if err != nil {
+			return deckLayout{}, err
+		}
+		top = append(top, row)
+	}
+	if err := rows.Error(); err != nil {
+		return deckLayout{}, err
+	}
+	if len(top) == 0 {
+		return deckLayout{}, errEmptySheet
+	}
+	return detectLayout(top, saved), nil
+}
+
+// readQuestionsWith parses the questions below the header of the question sheet
+func readQuestionsWith(f *excelize.File, layout deckLayout) ([]Question, error) {
+	var questions []Question
+	err := eachRow(f, questionSheet, func(number int, row []string) {
+		if number <= layout.HeaderRow {
+			return
+		}
+		if q, ok := layout.question(number, row); ok {
+			questions = append(questions, q)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// readDeckConfirmed parses the questions of a workbook, first asking which column holds
+// which field if its header leaves that open, and calls done with them
+func (qa *quizApp) readDeckConfirmed(f *excelize.File, deck string, done func([]Question, error)) {
+	layout, err := sheetLayout(f, questionSheet, qa.settings.ColumnMappings)
+	if err != nil {
+		done(nil, err)
+		return
+	}
+	layout.Romaji = qa.settings.RomajiStyle
+	if !layout.needsConfirmation() {
+		done(readQuestionsWith(f, layout))
+		return
+	}
+	qa.confirmColumns(deck, layout, func(confirmed deckLayout, err error) {
+		if err != nil {
+			done(nil, err)
+			return
+		}
+		done(readQuestionsWith(f, confirmed))
+	})
+}
+
+// columnName describes a column for the mapping dialog, e.g. "C: English"
+func (l deckLayout) columnName(i int) string {
+	letter, _ := excelize.ColumnNumberToName(i + 1)
+	if i < len(l.Header) && strings.TrimSpace(l.Header[i]) != "" {
+		return letter + ": " + strings.TrimSpace(l.Header[i])
+	}
+	return letter
+}
+
+// confirmColumns asks which column holds each field when the header leaves it open, and calls
+// done with the layout confirmed. The choice is remembered for decks with the same header.
+func (qa *quizApp) confirmColumns(deck string, layout deckLayout, done func(deckLayout, error)) {
+	const none = "(none)"
+	names := []string{none}
+	for i := range layout.Header {
+		names = append(names, layout.columnName(i))
+	}
+
+	selects := make(map[string]*widget.Select)
+	var items []*widget.FormItem
+	for _, field := range deckColumns {
+		choice := widget.NewSelect(names, nil)
+		choice.SetSelected(none)
+		if i, ok := layout.Columns[field]; ok {
+			choice.SetSelected(layout.columnName(i))
+		}
+		label := columnLabels[field]
+		if _, open := layout.Choices[field]; open {
+			label = "⚠ " + label
+		}
+		selects[field] = choice
+		items = append(items, widget.NewFormItem(label, choice))
+	}
+	note := widget.NewLabel(fmt.Sprintf("The header in row %d of %s leaves open which column holds the fields marked ⚠.", layout.HeaderRow, deck))
+	items = append([]*widget.FormItem{widget.NewFormItem("", note)}, items...)
+
+	dialog.ShowForm("Deck Columns", "Load", "Cancel", items, func(ok bool) {
+		if !ok {
+			done(deckLayout{}, errColumnsCancelled)
+			return
+		}
+		confirmed := layout
+		confirmed.Columns = make(map[string]int)
+		confirmed.Choices = nil
+		for field, choice := range selects {
+			for i := range layout.Header {
+				if choice.Selected == layout.columnName(i) {
+					confirmed.Columns[field] = i
+				}
+			}
+		}
+		if len(confirmed.missingColumns()) > 0 {
+			dialog.ShowInformation("Deck Columns", "Please choose the columns of the answer and of the kana or the romaji.", qa.window)
+			qa.confirmColumns(deck, layout, done)
+			return
+		}
+		if qa.settings.ColumnMappings == nil {
+			qa.settings.ColumnMappings = make(map[string]map[string]int)
+		}
+		qa.settings.ColumnMappings[headerSignature(layout.Header)] = confirmed.Columns
+		if err := qa.settings.save(); err != nil {
+			log.Printf("Failed to save settings: %v", err)
+		}
+		done(confirmed, nil)
+	}, qa.window)
+}
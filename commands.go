@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// command is a subcommand that runs without the GUI and returns the exit code
+type command func(args []string, stdout, stderr io.Writer) int
+
+// commands are the subcommands, run as "GenkiQuiz <name> ..."
+var commands = map[string]command{
+	"serve":    runServe,
+	"stats":    runStats,
+	"validate": runValidate,
+}
+
+// runCommand runs the subcommand named by the first argument, if there is one
+func runCommand(args []string, stdout, stderr io.Writer) (int, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		if args[0] == "help" {
+			fmt.Fprintln(stdout, "usage: GenkiQuiz [flags]  or  GenkiQuiz <command> [args]")
+			names := make([]string, 0, len(commands))
+			for name := range commands {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Fprintln(stdout, "  "+name)
+			}
+			return 0, true
+		}
+		return 0, false
+	}
+	return cmd(args[1:], stdout, stderr), true
+}
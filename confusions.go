@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// confusableShown is how many confusable pairs the analytics screen lists
+const confusableShown = 20
+
+// maxConfusedDistractors is how many of the options may come from past mistakes
+const maxConfusedDistractors = 2
+
+// confusablePair is a question together with an answer it was mistaken for
+type confusablePair struct {
+	question Question
+	partner  Question // Question whose answer was picked, if it is in the deck
+	picked   string
+	count    int
+}
+
+// recordConfusion counts a wrong option picked for a question
+func (p *Progress) recordConfusion(qid, picked string) {
+	if p.Confusions == nil {
+		p.Confusions = make(map[string]map[string]int)
+	}
+	if p.Confusions[qid] == nil {
+		p.Confusions[qid] = make(map[string]int)
+	}
+	p.Confusions[qid][picked]++
+}
+
+// confusedAnswers returns the answers a question was mistaken for, most frequent first
+func (p *Progress) confusedAnswers(qid string) []string {
+	counts := p.Confusions[qid]
+	answers := make([]string, 0, len(counts))
+	for answer := range counts {
+		answers = append(answers, answer)
+	}
+	sort.Slice(answers, func(i, j int) bool {
+		if counts[answers[i]] != counts[answers[j]] {
+			return counts[answers[i]] > counts[answers[j]]
+		}
+		return answers[i] < answers[j]
+	})
+	return answers
+}
+
+// confusablePairs lists the most frequent mistakes across the deck
+func confusablePairs(questions []Question, progress *Progress) []confusablePair {
+	byID := make(map[string]Question)
+	byAnswer := make(map[string]Question)
+	for _, q := range questions {
+		byID[q.QID] = q
+		byAnswer[q.QAnswer] = q
+	}
+
+	var pairs []confusablePair
+	for qid, picks := range progress.Confusions {
+		q, ok := byID[qid]
+		if !ok {
+			continue // Question no longer in the deck
+		}
+		for picked, count := range picks {
+			pairs = append(pairs, confusablePair{
+				question: q,
+				partner:  byAnswer[picked],
+				picked:   picked,
+				count:    count,
+			})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].count != pairs[j].count {
+			return pairs[i].count > pairs[j].count
+		}
+		return pairs[i].question.QID < pairs[j].question.QID
+	})
+	return pairs
+}
+
+// getDistractors picks wrong options, preferring answers the learner has confused with
+// this question before, and filling up with random answers from the pool
+func getDistractors(pool *answerPool, q Question, count int, progress *Progress) []string {
+	var distractors []string
+	used := map[string]bool{q.QAnswer: true}
+	for _, answer := range progress.confusedAnswers(q.QID) {
+		if len(distractors) >= maxConfusedDistractors || len(distractors) >= count {
+			break
+		}
+		if pool.has(answer) && !used[answer] && !answersOverlap(answer, q.QAnswer) {
+			distractors = append(distractors, answer)
+			used[answer] = true
+		}
+	}
+
+	for _, answer := range pool.random(q.QAnswer, count) {
+		if len(distractors) >= count {
+			break
+		}
+		if !used[answer] && !overlapsAny(answer, distractors) {
+			distractors = append(distractors, answer)
+			used[answer] = true
+		}
+	}
+	return distractors
+}
+
+// startConfusionDrill drills the pairs the learner has mixed up, where both words are in the deck
+func (qa *quizApp) startConfusionDrill(pairs []confusablePair) {
+	var drill [][2]Question
+	for _, pair := range pairs {
+		if pair.partner.QID != "" {
+			drill = append(drill, [2]Question{pair.question, pair.partner})
+		}
+	}
+	qa.startPairDrill("Confusable Pairs Drill", drill)
+}
+
+// showConfusablePairs lists the words most often mistaken for each other
+func (qa *quizApp) showConfusablePairs() {
+	pairs := confusablePairs(qa.studyQuestions(), qa.progress)
+	if len(pairs) > confusableShown {
+		pairs = pairs[:confusableShown]
+	}
+
+	list := container.NewVBox(settingsHeading("Your Mistakes"))
+	for _, pair := range pairs {
+		partner := pair.picked
+		if pair.partner.QID != "" {
+			partner = fmt.Sprintf("%s (%s)", pair.partner.QHirakata, pair.picked)
+		}
+		list.Add(widget.NewLabel(fmt.Sprintf("%s (%s)  ↔  %s   ×%d",
+			pair.question.QHirakata, pair.question.QAnswer, partner, pair.count)))
+	}
+	if len(pairs) == 0 {
+		list.Add(widget.NewLabel("No mistakes recorded yet."))
+	}
+
+	drillButton := widget.NewButton("Drill These Pairs", qa.serialized(func() {
+		qa.startConfusionDrill(pairs)
+	}))
+	drillButton.Importance = widget.HighImportance
+	drillable := false
+	for _, pair := range pairs {
+		drillable = drillable || pair.partner.QID != ""
+	}
+	if !drillable {
+		drillButton.Disable()
+	}
+
+	// Look-alike and sound-alike words, curated and detected from the deck
+	lookAlikes := lookAlikePairs(qa.studyChapters(qa.availableChapters()))
+	list.Add(settingsHeading("Look-alike Words"))
+	for _, pair := range lookAlikes {
+		list.Add(widget.NewLabel(fmt.Sprintf("%s (%s)  ↔  %s (%s)",
+			pair[0].QHirakata, pair[0].QAnswer, pair[1].QHirakata, pair[1].QAnswer)))
+	}
+	if len(lookAlikes) == 0 {
+		list.Add(widget.NewLabel("No look-alike words in the selected chapters."))
+	}
+	lookAlikeButton := widget.NewButton("Drill Look-alike Words", qa.serialized(func() {
+		qa.startPairDrill("Look-alike Words Drill", lookAlikes)
+	}))
+	if len(lookAlikes) == 0 {
+		lookAlikeButton.Disable()
+	}
+
+	qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle("Confusable Pairs", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		container.NewGridWithColumns(2,
+			drillButton,
+			lookAlikeButton,
+			widget.NewButton("🔊 Listening Drill", qa.serialized(func() {
+				qa.startListeningDrill()
+			})),
+			widget.NewButton("Back to Chapter Selection", qa.serialized(func() {
+				qa.showChapterSelection()
+			})),
+		),
+		nil, nil,
+		container.NewVScroll(list),
+	))
+}
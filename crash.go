@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// crashOutputFile receives what the runtime prints when the app crashes, in the log folder.
+// The next start turns it into a report.
+const crashOutputFile = "crash-output.log"
+
+// crashReportsDir keeps the crash reports, in the data folder
+const crashReportsDir = "crash-reports"
+
+// crashSettingsKept are the settings whose text crash reports keep. Other text, such as
+// names, addresses, PINs and tokens, may be personal and is left out.
+var crashSettingsKept = map[string]bool{"theme": true, "backend": true, "hotkey": true, "chapters": true}
+
+// catchCrashes sends the output of a fatal error in any goroutine to crashOutputFile
+func catchCrashes() error {
+	f, err := os.OpenFile(logPath(crashOutputFile), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close() // The runtime keeps its own copy of the file
+	return debug.SetCrashOutput(f, debug.CrashOptions{})
+}
+
+// collectCrash makes a report of the crash that ended the last run, if it did, and returns its path
+func collectCrash(settings *Settings) (string, error) {
+	info, err := os.Stat(logPath(crashOutputFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	output, err := os.ReadFile(logPath(crashOutputFile))
+	if err != nil || strings.TrimSpace(string(output)) == "" {
+		return "", err
+	}
+
+	dir := dataPath(crashReportsDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "crash-"+info.ModTime().Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(crashReport(string(output), info.ModTime(), settings)), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// crashReport describes a crash for a bug report, without personal details
+func crashReport(output string, at time.Time, settings *Settings) string {
+	commit, built := buildInfo()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Genki Quiz crash report\n\n")
+	fmt.Fprintf(&b, "Crashed: %s\n", at.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version: %s (commit %s, built %s)\n", version, commit, built)
+	fmt.Fprintf(&b, "System:  %s/%s, %s\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	fmt.Fprintf(&b, "\nSettings, personal text left out:\n%s\n", anonymizedSettings(settings))
+	fmt.Fprintf(&b, "\nCrash:\n%s\n", sanitizeCrashText(output))
+	return b.String()
+}
+
+// anonymizedSettings returns the settings as JSON with personal text left out, see crashSettingsKept
+func anonymizedSettings(settings *Settings) string {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err.Error()
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err.Error()
+	}
+	data, err = json.MarshalIndent(anonymizeSetting("", fields), "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// anonymizeSetting leaves personal text out of a decoded setting, by the key it is under
+func anonymizeSetting(key string, value any) any {
+	switch v := value.(type) {
+	case string:
+		if v == "" || crashSettingsKept[key] {
+			return v
+		}
+		return "[left out]"
+	case []any:
+		for i := range v {
+			v[i] = anonymizeSetting(key, v[i])
+		}
+	case map[string]any:
+		anonymized := make(map[string]any, len(v))
+		n := 0
+		for k, child := range v {
+			if key == "schedules" {
+				// Schedules are keyed by deck file name
+				n++
+				k = fmt.Sprintf("deck %d", n)
+			}
+			anonymized[k] = anonymizeSetting(k, child)
+		}
+		return anonymized
+	}
+	return value
+}
+
+// sanitizeCrashText replaces the home folder and user name in crash output, e.g. in file paths
+func sanitizeCrashText(text string) string {
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		text = strings.ReplaceAll(text, home, "~")
+		text = strings.ReplaceAll(text, filepath.ToSlash(home), "~")
+	}
+	for _, name := range []string{os.Getenv("USER"), os.Getenv("USERNAME")} {
+		if len(name) >= 3 {
+			text = strings.ReplaceAll(text, name, "<user>")
+		}
+	}
+	return text
+}
+
+// offerCrashReport tells the learner the last run crashed and offers to open the report
+// to attach it to a bug report
+func (qa *quizApp) offerCrashReport(path string) {
+	message := fmt.Sprintf("Genki Quiz closed unexpectedly last time. A report without personal details was saved in\n%s\n\nAttaching it to a bug report helps fix the problem.", path)
+	// Kiosk computers do not open other apps
+	if qa.kiosk {
+		dialog.ShowInformation("Genki Quiz Crashed", message, qa.window)
+		return
+	}
+	dialog.ShowCustomConfirm("Genki Quiz Crashed", "Open Report", "Close", widget.NewLabel(message), func(open bool) {
+		if !open {
+			return
+		}
+		u, err := url.Parse(storage.NewFileURI(path).String())
+		if err == nil {
+			err = qa.app.OpenURL(u)
+		}
+		if err != nil {
+			log.Printf("Failed to open crash report: %v", err)
+			dialog.ShowError(err, qa.window)
+		}
+	}, qa.window)
+}
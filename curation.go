@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// starred reports whether a question was starred
+func (p *Progress) starred(qid string) bool {
+	_, ok := p.Starred[qid]
+	return ok
+}
+
+// star marks a question to come back to, e.g. to quiz it again or fix it in the deck
+func (p *Progress) star(qid string) {
+	if p.Starred == nil {
+		p.Starred = make(map[string]time.Time)
+	}
+	if _, ok := p.Starred[qid]; !ok {
+		p.Starred[qid] = time.Now().Truncate(time.Second)
+	}
+}
+
+// unstar removes the star of a question
+func (p *Progress) unstar(qid string) {
+	delete(p.Starred, qid)
+}
+
+// curationItems are the menu actions on a question shown during study: star it, take it
+// out of study, fix it in the deck or report a mistake in it
+func (qa *quizApp) curationItems(q Question) []*fyne.MenuItem {
+	star := fyne.NewMenuItem("Star", func() {
+		qa.progress.star(q.QID)
+		qa.saveCuration()
+	})
+	if qa.progress.starred(q.QID) {
+		star = fyne.NewMenuItem("Unstar", func() {
+			qa.progress.unstar(q.QID)
+			qa.saveCuration()
+		})
+	}
+	items := []*fyne.MenuItem{star}
+	// Suspending and editing change what everyone studies, so kiosk computers do not offer them
+	if !qa.kiosk {
+		if !qa.progress.suspended(q.QID) {
+			items = append(items, fyne.NewMenuItem("Suspend", func() {
+				qa.suspendQuestion(q)
+			}))
+		}
+		items = append(items, fyne.NewMenuItem("Edit…", func() {
+			qa.editQuestion(q)
+		}))
+	}
+	return append(items, fyne.NewMenuItem("Report a Problem…", func() {
+		qa.reportQuestion(q)
+	}))
+}
+
+// saveCuration saves the progress after a question was starred or unstarred
+func (qa *quizApp) saveCuration() {
+	if err := qa.progress.save(); err != nil {
+		log.Printf("Failed to save progress: %v", err)
+	}
+	qa.progressChanged()
+}
+
+// optionMenu offers to copy an answer option, and the curation actions on the question it
+// belongs to, if it is one of the deck's
+func (qa *quizApp) optionMenu(text string, q Question, inDeck bool) *fyne.Menu {
+	menu := fyne.NewMenu("", fyne.NewMenuItem("Copy Option", func() {
+		qa.copyToClipboard(text)
+	}))
+	if inDeck {
+		menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
+		menu.Items = append(menu.Items, qa.curationItems(q)...)
+	}
+	return menu
+}
+
+// replaceQuestion swaps the question with the same QID for q in the deck and in the quiz going on
+func (qa *quizApp) replaceQuestion(q Question) {
+	for _, questions := range [][]Question{qa.questions, qa.state.chapterQuestions, qa.state.quizQuestions} {
+		for i := range questions {
+			if questions[i].QID == q.QID {
+				questions[i] = q
+			}
+		}
+	}
+	if qa.state.current.QID == q.QID {
+		qa.state.current = q
+	}
+	qa.setQuestions(qa.questions)
+}
+
+// editQuestion corrects a question of the deck. The change applies at once and is kept when the deck is saved.
+func (qa *quizApp) editQuestion(q Question) {
+	kanaEntry := widget.NewEntry()
+	kanaEntry.SetText(q.QHirakata)
+	romajiEntry := widget.NewEntry()
+	romajiEntry.SetText(q.QRomaji)
+	answerEntry := widget.NewEntry()
+	answerEntry.SetText(q.QAnswer)
+	kanjiEntry := widget.NewEntry()
+	kanjiEntry.SetText(q.QKanji)
+	exampleEntry := widget.NewMultiLineEntry()
+	exampleEntry.SetText(q.QExample)
+	originEntry := widget.NewEntry()
+	originEntry.SetText(q.QOrigin)
+	originEntry.SetPlaceHolder("For loanwords, e.g. computer (English)")
+
+	dialog.ShowForm(fmt.Sprintf("Edit Question %s", q.QID), "Save", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Kana", kanaEntry),
+			widget.NewFormItem("Romaji", romajiEntry),
+			widget.NewFormItem("Answer", answerEntry),
+			widget.NewFormItem("Kanji", kanjiEntry),
+			widget.NewFormItem("Example", exampleEntry),
+			widget.NewFormItem("Origin", originEntry),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			edited := q
+			edited.QHirakata = cleanText(kanaEntry.Text)
+			edited.QRomaji = cleanText(romajiEntry.Text)
+			edited.QAnswer = cleanText(answerEntry.Text)
+			edited.QKanji = cleanText(kanjiEntry.Text)
+			edited.QExample = cleanText(exampleEntry.Text)
+			edited.QOrigin = cleanText(originEntry.Text)
+			if edited.QHirakata == "" || edited.QAnswer == "" {
+				dialog.ShowInformation("Edit Question", "A question needs its kana and its answer.", qa.window)
+				return
+			}
+			if edited == q {
+				return
+			}
+			if edited.QHirakata != q.QHirakata {
+				edited.KanaFromRomaji, edited.KanaCheck = false, "" // Written by the author now
+			}
+			qa.replaceQuestion(edited)
+			dialog.ShowConfirm("Edit Question", "The question is changed for this session.\nSave the deck to keep the change?", func(save bool) {
+				if save {
+					qa.saveDeck()
+				}
+			}, qa.window)
+		}, qa.window)
+}
+
+// showStarred lists the starred questions, each with a button to remove its star
+func (qa *quizApp) showStarred() {
+	qa.showSetAside("Starred Questions",
+		"No questions are starred. Right-click or long-press a question or an option during a quiz to star it.",
+		"starred", "Unstar",
+		qa.progress.Starred, qa.progress.unstar)
+}
+
+// startStarredQuiz quizzes the starred questions that are in study
+func (qa *quizApp) startStarredQuiz() {
+	state := qa.state
+	var starred []Question
+	for _, q := range qa.studyQuestions() {
+		if qa.progress.starred(q.QID) {
+			starred = append(starred, q)
+		}
+	}
+	if len(starred) == 0 {
+		dialog.ShowInformation("Starred Questions", "No starred questions to quiz.", qa.window)
+		return
+	}
+	rand.Shuffle(len(starred), func(i, j int) {
+		starred[i], starred[j] = starred[j], starred[i]
+	})
+	state.chapterQuestions = starred
+	state.quizQuestions = starred
+	state.totalQuestions = len(starred)
+	state.currentChapter = "Starred"
+	state.quizName = "Starred Questions"
+	qa.startQuiz()
+}
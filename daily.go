@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// dailyChallengeSize is the number of questions in the daily challenge
+const dailyChallengeSize = 10
+
+// dailyHistoryShown is how many past challenges the daily screen lists
+const dailyHistoryShown = 14
+
+// dateLayout formats calendar days in saved data
+const dateLayout = "2006-01-02"
+
+// dailyRecord is the first attempt at one day's challenge
+type dailyRecord struct {
+	Date  string `json:"date"`
+	Score int    `json:"score"`
+	Total int    `json:"total"`
+}
+
+// dailyChallenge picks the same questions for everyone on a given day. The questions are
+// sorted by QID before the date-seeded shuffle, so the order they come in does not matter.
+func dailyChallenge(questions []Question, day time.Time) []Question {
+	h := fnv.New64a()
+	h.Write([]byte(day.Format(dateLayout)))
+	r := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	pool := make([]Question, len(questions))
+	copy(pool, questions)
+	slices.SortFunc(pool, func(a, b Question) int {
+		return strings.Compare(a.QID, b.QID)
+	})
+	r.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+	if len(pool) > dailyChallengeSize {
+		pool = pool[:dailyChallengeSize]
+	}
+	return pool
+}
+
+// dailyRecordFor returns the recorded challenge of a day, if it was played
+func (p *Progress) dailyRecordFor(day time.Time) (dailyRecord, bool) {
+	date := day.Format(dateLayout)
+	for _, record := range p.Daily {
+		if record.Date == date {
+			return record, true
+		}
+	}
+	return dailyRecord{}, false
+}
+
+// dailyStreak counts consecutive days with a completed challenge, ending today
+// (or yesterday, so the streak is not lost before today's challenge is played)
+func (p *Progress) dailyStreak(today time.Time) int {
+	day := today
+	if _, ok := p.dailyRecordFor(day); !ok {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for {
+		if _, ok := p.dailyRecordFor(day); !ok {
+			return streak
+		}
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+}
+
+// startDailyChallenge runs today's challenge over all selectable chapters. It is drawn from
+// every question of the offered chapters, and those not studied now, such as suspended,
+// retired or locked ones, are left out afterwards, so the challenge stays the same all day.
+func (qa *quizApp) startDailyChallenge() {
+	state := qa.state
+	today := time.Now()
+
+	state.chapterQuestions = qa.studyChapters(qa.availableChapters())
+	studied := make(map[string]bool, len(state.chapterQuestions))
+	for _, q := range state.chapterQuestions {
+		studied[q.QID] = true
+	}
+	state.quizQuestions = nil
+	for _, q := range dailyChallenge(qa.index.inChapters(qa.offeredChapters()), today) {
+		if studied[q.QID] {
+			state.quizQuestions = append(state.quizQuestions, q)
+		}
+	}
+	state.totalQuestions = len(state.quizQuestions)
+	state.currentChapter = "Daily Challenge"
+	state.quizName = "Daily Challenge " + today.Format(dateLayout)
+	if state.totalQuestions == 0 {
+		state.reset()
+		return
+	}
+
+	// Only the first attempt of the day counts
+	if _, played := qa.progress.dailyRecordFor(today); !played {
+		state.onFinish = func(result quizResult) {
+			qa.progress.Daily = append(qa.progress.Daily, dailyRecord{
+				Date:  today.Format(dateLayout),
+				Score: result.Score,
+				Total: result.Total,
+			})
+			if err := qa.progress.save(); err != nil {
+				dialog.ShowError(err, qa.window)
+			}
+		}
+	}
+	qa.startQuiz()
+}
+
+// dailyButtonText describes today's challenge for the home screen
+func (qa *quizApp) dailyButtonText() string {
+	today := time.Now()
+	streak := qa.progress.dailyStreak(today)
+	if record, ok := qa.progress.dailyRecordFor(today); ok {
+		return fmt.Sprintf("Daily Challenge ✔ %d/%d (%d-day streak)", record.Score, record.Total, streak)
+	}
+	if streak > 0 {
+		return fmt.Sprintf("Daily Challenge (%d-day streak)", streak)
+	}
+	return "Daily Challenge"
+}
+
+// showDailyChallenge shows today's challenge with the streak and past results
+func (qa *quizApp) showDailyChallenge() {
+	today := time.Now()
+	progress := qa.progress
+
+	status := "Today's challenge: 10 questions from every chapter. Only your first attempt counts."
+	startText := "Start Today's Challenge"
+	if record, ok := progress.dailyRecordFor(today); ok {
+		status = fmt.Sprintf("Today's challenge done: %d/%d. You can play it again for practice.", record.Score, record.Total)
+		startText = "Practice Again"
+	}
+	startButton := widget.NewButton(startText, qa.serialized(func() {
+		qa.startDailyChallenge()
+	}))
+	startButton.Importance = widget.HighImportance
+
+	history := container.NewVBox()
+	for i := len(progress.Daily) - 1; i >= 0 && len(progress.Daily)-i <= dailyHistoryShown; i-- {
+		record := progress.Daily[i]
+		history.Add(widget.NewLabel(fmt.Sprintf("%s   %d/%d", record.Date, record.Score, record.Total)))
+	}
+	if len(progress.Daily) == 0 {
+		history.Add(widget.NewLabel("No challenges completed yet."))
+	}
+
+	qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Daily Challenge", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(status),
+		widget.NewLabel(fmt.Sprintf("Current streak: %d day(s)", progress.dailyStreak(today))),
+		startButton,
+		widget.NewLabelWithStyle("History", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		history,
+		widget.NewButton("Back to Chapter Selection", qa.serialized(func() {
+			qa.showChapterSelection()
+		})),
+	)))
+}
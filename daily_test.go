@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDailyChallenge(t *testing.T) {
+	var pool []Question
+	for i := range 30 {
+		pool = append(pool, Question{QID: fmt.Sprint(i)})
+	}
+	qids := func(questions []Question) []string {
+		var ids []string
+		for _, q := range questions {
+			ids = append(ids, q.QID)
+		}
+		return ids
+	}
+	day := time.Date(2024, 5, 10, 8, 0, 0, 0, time.Local)
+
+	first := qids(dailyChallenge(pool, day))
+	if len(first) != dailyChallengeSize {
+		t.Fatalf("%d questions, want %d", len(first), dailyChallengeSize)
+	}
+	// The same all day and whatever order the deck is in
+	reversed := slices.Clone(pool)
+	slices.Reverse(reversed)
+	if got := qids(dailyChallenge(reversed, day.Add(10*time.Hour))); !slices.Equal(got, first) {
+		t.Errorf("reversed deck later that day: %q, want %q", got, first)
+	}
+	if got := qids(dailyChallenge(pool, day.AddDate(0, 0, 1))); slices.Equal(got, first) {
+		t.Errorf("same challenge the next day: %q", got)
+	}
+}
+
+func TestWordOfTheDay(t *testing.T) {
+	var deck []Question
+	for i := range 20 {
+		deck = append(deck, Question{QID: fmt.Sprint(i)})
+	}
+	day := time.Date(2024, 5, 10, 8, 0, 0, 0, time.Local)
+	progress := &Progress{Items: map[string]*itemStats{}}
+
+	word, ok := wordOfTheDay(deck, progress, day)
+	if !ok {
+		t.Fatal("no word of the day")
+	}
+	// Mastering other words during the day does not change it
+	for _, q := range deck {
+		if q.QID != word.QID {
+			progress.Items[q.QID] = &itemStats{Streak: masteryStreak}
+			if got, _ := wordOfTheDay(deck, progress, day); got.QID != word.QID {
+				t.Fatalf("word changed from %s to %s after mastering %s", word.QID, got.QID, q.QID)
+			}
+		}
+	}
+	progress.Items[word.QID] = &itemStats{Streak: masteryStreak}
+	if _, ok := wordOfTheDay(deck, progress, day); ok {
+		t.Error("word of the day with every word mastered")
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxDeckChanges caps the change log kept in the progress
+const maxDeckChanges = 50
+
+// deckRecord is what the progress remembers of a deck's contents, to notice when the file changes
+type deckRecord struct {
+	Version int               `json:"version"` // Counts the changes noticed, from 1
+	Hash    string            `json:"hash"`    // See deckHash
+	Items   map[string]string `json:"items"`   // Fingerprint of each question by QID, see questionFingerprint
+	Checked time.Time         `json:"checked"` // When the deck was last loaded
+
+	Labels map[string]deckLabel `json:"labels,omitempty"` // Text of every question seen in the deck by QID, kept after it is removed
+}
+
+// deckLabel is the text a question had, to match its progress to it after its QID changed
+type deckLabel struct {
+	Kana   string `json:"kana"`
+	Answer string `json:"answer"`
+}
+
+// deckChange is an entry of the deck change log
+type deckChange struct {
+	At      time.Time `json:"at"`
+	Deck    string    `json:"deck"`
+	Version int       `json:"version"` // Version of the deck after the change
+	Added   []string  `json:"added,omitempty"`
+	Removed []string  `json:"removed,omitempty"`
+	Edited  []string  `json:"edited,omitempty"`
+}
+
+// questionFingerprint identifies the contents of a question, apart from its QID
+func questionFingerprint(q Question) string {
+	h := fnv.New64a()
+	fields := []string{q.QChapter, q.QAnswer, q.QHirakata, q.QRomaji, q.QType, q.QExample, q.QKanji, q.QRequires}
+	if q.QOrigin != "" {
+		fields = append(fields, q.QOrigin) // Added later, left out when empty to keep earlier fingerprints
+	}
+	h.Write([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// deckFingerprints returns the fingerprint of each question of a deck by QID
+func deckFingerprints(questions []Question) map[string]string {
+	items := make(map[string]string, len(questions))
+	for _, q := range questions {
+		items[q.QID] = questionFingerprint(q)
+	}
+	return items
+}
+
+// deckHash identifies the contents of a deck, whatever the order of its rows
+func deckHash(items map[string]string) string {
+	qids := make([]string, 0, len(items))
+	for qid := range items {
+		qids = append(qids, qid)
+	}
+	sort.Strings(qids)
+	h := sha256.New()
+	for _, qid := range qids {
+		fmt.Fprintf(h, "%s\x1f%s\n", qid, items[qid])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// trackDeck compares a deck with what was recorded of it and records its contents.
+// It returns the change when the deck is known and differs; a deck seen for the first time
+// is recorded without a change. Progress stays with the QIDs, so edited questions keep theirs.
+func (p *Progress) trackDeck(name string, questions []Question, now time.Time) (deckChange, bool) {
+	if p.Decks == nil {
+		p.Decks = make(map[string]*deckRecord)
+	}
+	items := deckFingerprints(questions)
+	hash := deckHash(items)
+	record := p.Decks[name]
+	if record == nil {
+		record = &deckRecord{Version: 1, Hash: hash, Items: items}
+		p.Decks[name] = record
+	}
+	if record.Labels == nil {
+		record.Labels = make(map[string]deckLabel)
+	}
+	for _, q := range questions {
+		record.Labels[q.QID] = deckLabel{Kana: q.QHirakata, Answer: q.QAnswer}
+	}
+	record.Checked = now
+	if record.Hash == hash {
+		return deckChange{}, false
+	}
+
+	change := deckChange{At: now, Deck: name, Version: record.Version + 1}
+	for qid, fingerprint := range items {
+		old, ok := record.Items[qid]
+		switch {
+		case !ok:
+			change.Added = append(change.Added, qid)
+		case old != fingerprint:
+			change.Edited = append(change.Edited, qid)
+		}
+	}
+	for qid := range record.Items {
+		if _, ok := items[qid]; !ok {
+			change.Removed = append(change.Removed, qid)
+		}
+	}
+	sort.Strings(change.Added)
+	sort.Strings(change.Removed)
+	sort.Strings(change.Edited)
+
+	record.Version = change.Version
+	record.Hash = hash
+	record.Items = items
+	p.DeckChanges = append(p.DeckChanges, change)
+	if len(p.DeckChanges) > maxDeckChanges {
+		p.DeckChanges = p.DeckChanges[len(p.DeckChanges)-maxDeckChanges:]
+	}
+	return change, true
+}
+
+// orphanedQIDs returns the QIDs of a change that were removed but still have progress
+func (p *Progress) orphanedQIDs(change deckChange) []string {
+	var orphaned []string
+	for _, qid := range change.Removed {
+		if p.stats(qid) != nil {
+			orphaned = append(orphaned, qid)
+		}
+	}
+	return orphaned
+}
+
+// summary describes a change in one line
+func (c deckChange) summary() string {
+	return fmt.Sprintf("Version %d: %d added, %d edited, %d removed", c.Version, len(c.Added), len(c.Edited), len(c.Removed))
+}
+
+// checkDeck records the loaded deck in the progress and tells the learner when it changed
+// since the last session. Encrypted progress is checked once it is unlocked.
+func (qa *quizApp) checkDeck() {
+	if qa.progress.locked {
+		return
+	}
+	change, changed := qa.progress.trackDeck(qa.deckName, qa.questions, time.Now().Truncate(time.Second))
+	if err := qa.progress.save(); err != nil {
+		log.Printf("Failed to save progress: %v", err)
+	}
+	if !changed {
+		return
+	}
+	message := fmt.Sprintf("%s changed since your last session.\n%s.\nYour progress stays with each question's QID.", qa.deckName, change.summary())
+	orphaned := qa.progress.orphanedQIDs(change)
+	if len(orphaned) == 0 {
+		dialog.ShowInformation("Deck Changed", message, qa.window)
+		return
+	}
+	message += fmt.Sprintf("\n%d removed questions have progress, which is kept in case they come back: %s",
+		len(orphaned), strings.Join(orphaned, ", "))
+	if len(reconcileMatches(qa.progress, qa.questions)) == 0 {
+		dialog.ShowInformation("Deck Changed", message, qa.window)
+		return
+	}
+	dialog.ShowConfirm("Deck Changed", message+"\n\nSome of them look like questions of the deck under a new QID. Match them now?", func(ok bool) {
+		if ok {
+			qa.showReconcile()
+		}
+	}, qa.window)
+}
+
+// showDeckChanges lists the changes noticed in the loaded deck, newest first
+func (qa *quizApp) showDeckChanges() {
+	list := container.NewVBox()
+	if record := qa.progress.Decks[qa.deckName]; record != nil {
+		list.Add(widget.NewLabelWithStyle(fmt.Sprintf("%s — version %d (%s)", qa.deckName, record.Version, record.Hash),
+			fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	}
+	changes := qa.progress.DeckChanges
+	shown := 0
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		if c.Deck != qa.deckName {
+			continue
+		}
+		shown++
+		text := c.At.Format("Jan 2, 2006 15:04") + " — " + c.summary()
+		for _, part := range []struct {
+			name string
+			qids []string
+		}{{"Added", c.Added}, {"Edited", c.Edited}, {"Removed", c.Removed}} {
+			if len(part.qids) > 0 {
+				text += fmt.Sprintf("\n  %s: %s", part.name, strings.Join(part.qids, ", "))
+			}
+		}
+		label := widget.NewLabel(text)
+		label.Wrapping = fyne.TextWrapWord
+		list.Add(label)
+	}
+	if shown == 0 {
+		list.Add(widget.NewLabel("No changes were noticed in this deck."))
+	}
+	d := dialog.NewCustom("Deck Change Log", "Close", container.NewVScroll(list), qa.window)
+	d.Resize(fyne.NewSize(560, 420))
+	d.Show()
+}
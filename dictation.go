@@ -0,0 +1,390 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/text/width"
+)
+
+// dictationRounds is the number of numbers read out in a dictation drill
+const dictationRounds = 10
+
+// Kinds of numbers a dictation drill reads out
+const (
+	dictationNumbers = "Numbers"
+	dictationPrices  = "Prices"
+	dictationTimes   = "Times"
+)
+
+// dictationKinds are the kinds of numbers offered, in order
+var dictationKinds = []string{dictationNumbers, dictationPrices, dictationTimes}
+
+// dictationRanges are the largest numbers and prices offered, up to the 万 that make
+// Japanese numbers hard to follow
+var dictationRanges = []int{100, 1000, 10000, 100000, 1000000, 100000000 - 1}
+
+// defaultDictationMax is the range used until the learner picks one
+const defaultDictationMax = 10000
+
+// dictationSettings configure the numbers dictation drill
+type dictationSettings struct {
+	Max   int      `json:"max"`   // Largest number or price read out, 0 for defaultDictationMax
+	Kinds []string `json:"kinds"` // dictationKinds to read out, none for all
+}
+
+// max returns the largest number read out
+func (s dictationSettings) max() int {
+	if s.Max <= 0 {
+		return defaultDictationMax
+	}
+	return s.Max
+}
+
+// digitKana are the readings of the digits 0 to 9
+var digitKana = []string{"ゼロ", "いち", "に", "さん", "よん", "ご", "ろく", "なな", "はち", "きゅう"}
+
+// Readings of the hundreds and thousands that change their sound, by digit
+var (
+	hundredKana  = map[int]string{1: "ひゃく", 3: "さんびゃく", 6: "ろっぴゃく", 8: "はっぴゃく"}
+	thousandKana = map[int]string{1: "せん", 3: "さんぜん", 8: "はっせん"}
+)
+
+// Readings of the hours and minutes that change their sound
+var (
+	hourKana   = map[int]string{4: "よじ", 7: "しちじ", 9: "くじ"}
+	minuteKana = []string{"", "いっぷん", "にふん", "さんぷん", "よんぷん", "ごふん", "ろっぷん", "ななふん", "はっぷん", "きゅうふん"}
+)
+
+// groupReading reads a number below 10000. inMan is set for the group before 万, where
+// a single thousand is いっせん.
+func groupReading(n int, inMan bool) string {
+	var b strings.Builder
+	if d := n / 1000; d > 0 {
+		switch {
+		case d == 1 && inMan:
+			b.WriteString("いっせん")
+		case thousandKana[d] != "":
+			b.WriteString(thousandKana[d])
+		default:
+			b.WriteString(digitKana[d] + "せん")
+		}
+	}
+	if d := n / 100 % 10; d > 0 {
+		if reading, ok := hundredKana[d]; ok {
+			b.WriteString(reading)
+		} else {
+			b.WriteString(digitKana[d] + "ひゃく")
+		}
+	}
+	if d := n / 10 % 10; d > 0 {
+		if d > 1 {
+			b.WriteString(digitKana[d])
+		}
+		b.WriteString("じゅう")
+	}
+	if d := n % 10; d > 0 {
+		b.WriteString(digitKana[d])
+	}
+	return b.String()
+}
+
+// numberReading reads a number below 100,000,000 in hiragana, e.g. 35000 is さんまんごせん
+func numberReading(n int) string {
+	if n == 0 {
+		return digitKana[0]
+	}
+	reading := ""
+	if man := n / 10000; man > 0 {
+		reading = groupReading(man, true) + "まん"
+	}
+	return reading + groupReading(n%10000, false)
+}
+
+// priceReading reads a price in yen. Four yen is よえん, not よんえん.
+func priceReading(yen int) string {
+	reading := numberReading(yen)
+	if yen%10 == 4 {
+		reading = strings.TrimSuffix(reading, "よん") + "よ"
+	}
+	return reading + "えん"
+}
+
+// hourReading reads an hour of the 12-hour clock, e.g. 4 is よじ
+func hourReading(hour int) string {
+	if reading, ok := hourKana[hour]; ok {
+		return reading
+	}
+	return numberReading(hour) + "じ"
+}
+
+// timeReading reads a time of the day on the 12-hour clock, e.g. 4:30 is よじさんじゅっぷん
+func timeReading(hour, minute int) string {
+	reading := hourReading(hour)
+	switch {
+	case minute == 0:
+	case minute < 10:
+		reading += minuteKana[minute]
+	case minute%10 == 0:
+		reading += strings.TrimSuffix(numberReading(minute), "う") + "っぷん"
+	default:
+		reading += numberReading(minute/10*10) + minuteKana[minute%10]
+	}
+	return reading
+}
+
+// dictationItem is one number read out and the digits to type
+type dictationItem struct {
+	kind    string
+	reading string // Hiragana read aloud
+	answer  string // Digits as written, e.g. "3,500円" or "4:30"
+	value   int    // The number, or the minutes after midnight of a time
+}
+
+// randomDictation makes a number, price or time no larger than max. Smaller numbers
+// come up as often as larger ones, taking a number of digits at random first.
+func randomDictation(kind string, max int) dictationItem {
+	if kind == dictationTimes {
+		hour, minute := rand.Intn(12)+1, rand.Intn(60)
+		return dictationItem{
+			kind:    kind,
+			reading: timeReading(hour, minute),
+			answer:  fmt.Sprintf("%d:%02d", hour, minute),
+			value:   hour*60 + minute,
+		}
+	}
+	digits := rand.Intn(len(strconv.Itoa(max))) + 1
+	low, high := 1, 1
+	for i := 1; i < digits; i++ {
+		low *= 10
+	}
+	high = min(low*10-1, max)
+	n := low + rand.Intn(high-low+1)
+	if kind == dictationPrices {
+		// Prices are mostly round, as in shops
+		if n >= 100 && rand.Intn(3) > 0 {
+			n -= n % 10
+		}
+		return dictationItem{kind: kind, reading: priceReading(n), answer: addCommas(n) + "円", value: n}
+	}
+	return dictationItem{kind: kind, reading: numberReading(n), answer: addCommas(n), value: n}
+}
+
+// addCommas writes a number with thousands separators
+func addCommas(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// typedNumbers reads the numbers in a typed answer, ignoring separators, units and
+// full-width digits: "3,500円" is 3500 and "4時30分" is 4 and 30
+func typedNumbers(text string) []int {
+	text = strings.ReplaceAll(width.Fold.String(text), ",", "")
+	var numbers []int
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsDigit(r) }) {
+		if n, err := strconv.Atoi(field); err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+// matches reports whether the typed digits are the number read out. A time may be
+// typed as 4:30, 4時30分 or 430.
+func (item dictationItem) matches(typed string) bool {
+	numbers := typedNumbers(typed)
+	if item.kind == dictationTimes {
+		switch len(numbers) {
+		case 1:
+			return numbers[0] >= 100 && numbers[0]/100*60+numbers[0]%100 == item.value
+		case 2:
+			return numbers[0]*60+numbers[1] == item.value
+		}
+		return false
+	}
+	return len(numbers) == 1 && numbers[0] == item.value
+}
+
+// dictationDrill reads out numbers for the learner to type in digits
+type dictationDrill struct {
+	qa      *quizApp
+	kinds   []string
+	max     int
+	round   int
+	correct int
+	missed  []dictationItem
+}
+
+// showNumberDictation lets the learner choose the numbers to practise hearing
+func (qa *quizApp) showNumberDictation() {
+	settings := qa.settings.Dictation
+	var rangeNames []string
+	for _, max := range dictationRanges {
+		rangeNames = append(rangeNames, "Up to "+addCommas(max))
+	}
+	ranges := widget.NewSelect(rangeNames, nil)
+	ranges.SetSelected("Up to " + addCommas(settings.max()))
+	if ranges.SelectedIndex() < 0 {
+		ranges.SetSelected("Up to " + addCommas(defaultDictationMax))
+	}
+	kinds := widget.NewCheckGroup(dictationKinds, nil)
+	kinds.Horizontal = true
+	kinds.SetSelected(settings.Kinds)
+	if len(settings.Kinds) == 0 {
+		kinds.SetSelected(dictationKinds)
+	}
+
+	startButton := widget.NewButton("Start", qa.serialized(func() {
+		if len(kinds.Selected) == 0 {
+			dialog.ShowInformation("Numbers Dictation", "Please choose what to listen to.", qa.window)
+			return
+		}
+		if !speechAvailable() {
+			dialog.ShowError(errSpeechUnsupported, qa.window)
+			return
+		}
+		qa.settings.Dictation = dictationSettings{Max: dictationRanges[ranges.SelectedIndex()], Kinds: kinds.Selected}
+		if err := qa.settings.save(); err != nil {
+			log.Printf("Failed to save settings: %v", err)
+		}
+		d := &dictationDrill{qa: qa, kinds: kinds.Selected, max: qa.settings.Dictation.max()}
+		d.showRound()
+	}))
+	startButton.Importance = widget.HighImportance
+
+	qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Numbers Dictation", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("Listen to %d numbers and type them in digits.", dictationRounds)),
+		kinds,
+		widget.NewForm(widget.NewFormItem("Numbers and Prices", ranges)),
+		startButton,
+		widget.NewButton("Back to Chapter Selection", qa.serialized(func() {
+			qa.showChapterSelection()
+		})),
+	)))
+}
+
+// showRound reads out a number and waits for its digits
+func (d *dictationDrill) showRound() {
+	if d.round >= dictationRounds {
+		d.showResults()
+		return
+	}
+	item := randomDictation(d.kinds[rand.Intn(len(d.kinds))], d.max)
+
+	placeholder := map[string]string{
+		dictationNumbers: "Type the number, e.g. 3500",
+		dictationPrices:  "Type the price in yen, e.g. 3500",
+		dictationTimes:   "Type the time, e.g. 4:30",
+	}[item.kind]
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder(placeholder)
+	reading := canvas.NewText("", theme.ForegroundColor())
+	reading.Alignment = fyne.TextAlignCenter
+	d.qa.japaneseText(reading, grammarTextSize)
+	feedback := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{})
+
+	replays := 0
+	limit := d.qa.settings.Audio.MaxReplays
+	replayButton := widget.NewButton("🔊 Play Again", nil)
+	replayButton.OnTapped = func() {
+		if limit > 0 && replays >= limit {
+			return
+		}
+		replays++
+		d.qa.sayRecorded(item.reading, "")
+		if limit > 0 && replays >= limit {
+			replayButton.Disable()
+		}
+	}
+
+	answered := false
+	entry.OnSubmitted = func(text string) {
+		if answered {
+			d.showRound()
+			return
+		}
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		answered = true
+		d.round++
+		correct := item.matches(text)
+		if correct {
+			d.correct++
+			feedback.SetText("✅ " + item.answer)
+		} else {
+			d.missed = append(d.missed, item)
+			feedback.SetText("❌ It was " + item.answer)
+		}
+		reading.Text = item.reading
+		reading.Refresh()
+		d.qa.progress.recordListening(correct, replays)
+		if err := d.qa.progress.save(); err != nil {
+			log.Printf("Failed to save progress: %v", err)
+		}
+		d.qa.progressChanged()
+		entry.SetText("")
+		entry.SetPlaceHolder("Press Enter for the next number")
+	}
+
+	d.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Numbers Dictation — %d/%d — Score: %d", d.round+1, dictationRounds, d.correct),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		container.NewGridWithColumns(2,
+			replayButton,
+			widget.NewButton("End Drill", d.showResults),
+		),
+		nil, nil,
+		container.NewVBox(
+			layoutSpacer(),
+			container.NewCenter(container.NewHBox(widget.NewLabel("Speed"), d.qa.speedSelect())),
+			entry,
+			feedback,
+			reading,
+		),
+	))
+	d.qa.window.Canvas().Focus(entry)
+	d.qa.sayRecorded(item.reading, "")
+}
+
+// showResults shows the numbers heard right and those missed, with their readings
+func (d *dictationDrill) showResults() {
+	if d.round >= dictationRounds {
+		d.qa.earnPerfectXP(float64(d.correct), dictationRounds)
+	}
+	results := container.NewVBox(
+		widget.NewLabelWithStyle("Numbers Dictation Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("You heard %d of %d numbers correctly.", d.correct, d.round)),
+	)
+	if len(d.missed) > 0 {
+		lines := make([]string, len(d.missed))
+		for i, item := range d.missed {
+			lines[i] = item.answer + "  " + item.reading
+		}
+		results.Add(widget.NewLabel("To listen for:\n" + strings.Join(lines, "\n")))
+	}
+	results.Add(widget.NewButton("Try Again", d.qa.serialized(func() {
+		d.qa.showNumberDictation()
+	})))
+	results.Add(widget.NewButton("Return to Chapter Selection", d.qa.serialized(func() {
+		d.qa.showChapterSelection()
+	})))
+	d.qa.showScreen(container.NewCenter(results))
+}
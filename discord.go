@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// discordClientID is the ID of the Discord application the status is shown for.
+// It is set at build time with -ldflags "-X main.discordClientID=..."; without it
+// Rich Presence is not available.
+var discordClientID = ""
+
+// presenceInterval keeps updates within Discord's rate limit of 5 per 20 seconds
+const presenceInterval = 4 * time.Second
+
+// Opcodes of Discord's local RPC protocol
+const (
+	discordHandshake = 0
+	discordFrame     = 1
+)
+
+// presenceActivity is the status shown on the learner's Discord profile
+type presenceActivity struct {
+	Details    string             `json:"details,omitempty"` // First line, e.g. "Reviewing Genki Chapter 4"
+	State      string             `json:"state,omitempty"`   // Second line, e.g. "32/50"
+	Timestamps *presenceTimestamp `json:"timestamps,omitempty"`
+}
+
+// presenceTimestamp makes Discord show the time elapsed since Start
+type presenceTimestamp struct {
+	Start int64 `json:"start"`
+}
+
+// discordPresence sends the study status to the Discord client running on this
+// computer. Updates are sent in the background; only the latest one is kept while
+// waiting for the rate limit.
+type discordPresence struct {
+	mu      sync.Mutex
+	conn    io.ReadWriteCloser // Nil while not connected
+	pending *presenceActivity  // Latest activity not sent yet; nil activity clears the status
+	dirty   bool               // Whether pending has to be sent
+	wake    chan struct{}
+	started time.Time // Start of the current session, shown as elapsed time
+}
+
+// presence is the connection to Discord, used when enabled in the settings
+var presence = &discordPresence{wake: make(chan struct{}, 1)}
+
+func init() {
+	go presence.run()
+}
+
+// set queues an activity to show, or clears the status if activity is nil
+func (p *discordPresence) set(activity *presenceActivity) {
+	p.mu.Lock()
+	p.pending = activity
+	p.dirty = true
+	p.mu.Unlock()
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run sends queued activities, at most one per presenceInterval
+func (p *discordPresence) run() {
+	for range p.wake {
+		p.mu.Lock()
+		activity, dirty := p.pending, p.dirty
+		p.dirty = false
+		p.mu.Unlock()
+		if !dirty {
+			continue
+		}
+		if err := p.send(activity); err != nil {
+			log.Printf("Failed to update Discord status: %v", err)
+			p.close()
+		}
+		time.Sleep(presenceInterval)
+	}
+}
+
+// send sets the activity, connecting to Discord first if needed
+func (p *discordPresence) send(activity *presenceActivity) error {
+	if p.conn == nil {
+		if activity == nil {
+			return nil // Nothing to clear
+		}
+		conn, err := dialDiscord()
+		if err != nil {
+			return err
+		}
+		p.conn = conn
+		if err := p.write(discordHandshake, map[string]any{"v": 1, "client_id": discordClientID}); err != nil {
+			return err
+		}
+		if _, err := p.read(); err != nil {
+			return err
+		}
+	}
+	args := map[string]any{"pid": os.Getpid()}
+	if activity != nil {
+		args["activity"] = activity
+	}
+	if err := p.write(discordFrame, map[string]any{
+		"cmd":   "SET_ACTIVITY",
+		"args":  args,
+		"nonce": fmt.Sprint(time.Now().UnixNano()),
+	}); err != nil {
+		return err
+	}
+	reply, err := p.read()
+	if err != nil {
+		return err
+	}
+	var response struct {
+		Evt  string `json:"evt"`
+		Data struct {
+			Message string `json:"message"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(reply, &response) == nil && response.Evt == "ERROR" {
+		return errors.New(response.Data.Message)
+	}
+	return nil
+}
+
+// write sends one frame: opcode and length as little-endian uint32, then the JSON payload
+func (p *discordPresence) write(opcode uint32, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	frame := make([]byte, 8, 8+len(data))
+	binary.LittleEndian.PutUint32(frame[0:], opcode)
+	binary.LittleEndian.PutUint32(frame[4:], uint32(len(data)))
+	_, err = p.conn.Write(append(frame, data...))
+	return err
+}
+
+// read receives one frame and returns its payload
+func (p *discordPresence) read() ([]byte, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(p.conn, header[:]); err != nil {
+		return nil, err
+	}
+	data := make([]byte, binary.LittleEndian.Uint32(header[4:]))
+	_, err := io.ReadFull(p.conn, data)
+	return data, err
+}
+
+// close drops the connection; the next update reconnects
+func (p *discordPresence) close() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+	}
+}
+
+// updatePresence shows what the learner is doing on Discord, if enabled.
+// An empty details clears the status.
+func (qa *quizApp) updatePresence(details, state string) {
+	if discordClientID == "" || !qa.settings.DiscordPresence || qa.kiosk {
+		return
+	}
+	if details == "" {
+		presence.set(nil)
+		return
+	}
+	presence.mu.Lock()
+	if presence.started.IsZero() {
+		presence.started = time.Now()
+	}
+	start := presence.started.Unix()
+	presence.mu.Unlock()
+	presence.set(&presenceActivity{
+		Details:    details,
+		State:      state,
+		Timestamps: &presenceTimestamp{Start: start},
+	})
+}
+
+// quizPresence shows the current quiz and question as the Discord status
+func (qa *quizApp) quizPresence() {
+	state := qa.state
+	name := state.quizName
+	if name == "" {
+		name = "Genki Chapter " + state.currentChapter
+	}
+	qa.updatePresence("Reviewing "+name, fmt.Sprintf("%d/%d", state.questionsAsked+1, state.totalQuestions))
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package main
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// dialDiscord connects to the Discord client's socket in the runtime or temporary folder
+func dialDiscord() (io.ReadWriteCloser, error) {
+	dir := os.TempDir()
+	for _, name := range []string{"XDG_RUNTIME_DIR", "TMPDIR", "TMP", "TEMP"} {
+		if v := os.Getenv(name); v != "" {
+			dir = v
+			break
+		}
+	}
+	return net.Dial("unix", filepath.Join(dir, "discord-ipc-0"))
+}
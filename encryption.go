@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/crypto/scrypt"
+)
+
+// encryptedMagic starts every encrypted file, followed by the salt, the nonce and the sealed data
+var encryptedMagic = []byte("GENKIQUIZ-ENCRYPTED-1\n")
+
+// encryptionSaltSize is the length of the random salt used to derive the key
+const encryptionSaltSize = 16
+
+// errProgressLocked is returned when the progress is encrypted and no passphrase was given
+var errProgressLocked = errors.New("progress data is encrypted")
+
+// errWrongPassphrase is returned when encrypted data cannot be opened with a passphrase
+var errWrongPassphrase = errors.New("wrong passphrase")
+
+// isEncrypted reports whether saved data was written by encrypt
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedMagic)
+}
+
+// passphraseCipher derives an AES-256-GCM cipher from a passphrase with scrypt
+func passphraseCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt seals data with a passphrase
+func encrypt(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := passphraseCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte(nil), encryptedMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, data, encryptedMagic), nil
+}
+
+// decrypt opens data sealed by encrypt
+func decrypt(data []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errProgressLocked
+	}
+	rest := data[len(encryptedMagic):]
+	if len(rest) < encryptionSaltSize {
+		return nil, errors.New("encrypted data is truncated")
+	}
+	salt, rest := rest[:encryptionSaltSize], rest[encryptionSaltSize:]
+	aead, err := passphraseCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, errors.New("encrypted data is truncated")
+	}
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, encryptedMagic)
+	if err != nil {
+		return nil, errWrongPassphrase
+	}
+	return plain, nil
+}
+
+// setProgressPassphrase encrypts the progress with a new passphrase, or decrypts it when
+// the passphrase is empty. Backups of older file versions are removed when encrypting,
+// as they would still be readable.
+func (qa *quizApp) setProgressPassphrase(passphrase string) error {
+	if passphrase == qa.progress.passphrase {
+		return nil
+	}
+	if err := qa.progress.saveWith(passphrase); err != nil {
+		return err
+	}
+	qa.progress.passphrase = passphrase
+	if passphrase == "" {
+		return nil
+	}
+	backups, err := filepath.Glob(dataPath(progressFile) + ".v*.bak")
+	if err != nil {
+		return err
+	}
+	for _, backup := range backups {
+		if err := os.Remove(backup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// showUnlock asks for the passphrase of encrypted progress before anything else is shown.
+// The main menu, tabs and hotkeys are left out until unlocked, as nothing could be saved
+// before, and unlocked is then called holding the UI lock.
+func (qa *quizApp) showUnlock(unlocked func()) {
+	passphraseEntry := widget.NewPasswordEntry()
+	passphraseEntry.SetPlaceHolder("Passphrase")
+	unlock := qa.serialized(func() {
+		progress, err := loadProgress(passphraseEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		qa.progress = progress
+		unlocked()
+	})
+	passphraseEntry.OnSubmitted = func(string) {
+		unlock()
+	}
+	unlockButton := widget.NewButton("Unlock", unlock)
+	unlockButton.Importance = widget.HighImportance
+
+	qa.window.SetContent(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Welcome back to Genki Quiz!", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel("Your progress is encrypted. Enter your passphrase to continue."),
+		passphraseEntry,
+		unlockButton,
+		widget.NewLabel(fmt.Sprintf("Forgot it? Delete %s to start over.", dataPath(progressFile))),
+		widget.NewButton("Quit", func() {
+			qa.app.Quit()
+		}),
+	)))
+	qa.window.Canvas().Focus(passphraseEntry)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// defaultSessionSize is the number of questions in an engine session when none is asked for
+const defaultSessionSize = 10
+
+// errNoSession is returned for unknown or finished session IDs
+var errNoSession = errors.New("no such session")
+
+// engineSession is one quiz run through the engine
+type engineSession struct {
+	questions []Question
+	answers   *answerPool // Answers the distractors are drawn from
+	index     int
+	score     int
+	options   []string // Options of the current question, shuffled once per question
+}
+
+// quizEngine runs quizzes without a window, for frontends other than the GUI.
+// It records and schedules answers in the same progress as the GUI.
+type quizEngine struct {
+	mu        sync.Mutex
+	questions []Question
+	index     *questionIndex
+	deckName  string // File name of the deck, which keys its review schedule
+	settings  *Settings
+	progress  *Progress
+	sessions  map[string]*engineSession
+	nextID    int
+}
+
+// newQuizEngine returns an engine over a deck and the learner's settings and progress
+func newQuizEngine(questions []Question, deckName string, settings *Settings, progress *Progress) *quizEngine {
+	return &quizEngine{
+		questions: questions,
+		index:     newQuestionIndex(questions),
+		deckName:  deckName,
+		settings:  settings,
+		progress:  progress,
+		sessions:  make(map[string]*engineSession),
+	}
+}
+
+// start begins a session of up to count random questions from the chapters (all if none)
+func (e *quizEngine) start(chapters []string, count int) (string, int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(chapters) == 0 {
+		chapters = e.index.chapters
+	}
+	pool := e.index.studyPool(chapters, e.progress)
+	if len(pool) == 0 {
+		return "", 0, fmt.Errorf("no questions in chapters %v", chapters)
+	}
+	pool = limitNewItems(pool, e.progress, func(q Question) int {
+		return scheduleFor(e.settings, e.deckName, q.QChapter).NewPerDay
+	}, time.Now())
+	if len(pool) == 0 {
+		return "", 0, errors.New("only new items are left and today's limit of new items is reached")
+	}
+	if count <= 0 {
+		count = defaultSessionSize
+	}
+	questions := make([]Question, len(pool))
+	copy(questions, pool)
+	rand.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+	if len(questions) > count {
+		questions = questions[:count]
+	}
+
+	e.nextID++
+	id := fmt.Sprintf("s%d", e.nextID)
+	e.sessions[id] = &engineSession{questions: questions, answers: newAnswerPool(pool)}
+	return id, len(questions), nil
+}
+
+// session returns a running session
+func (e *quizEngine) session(id string) (*engineSession, error) {
+	s, ok := e.sessions[id]
+	if !ok {
+		return nil, errNoSession
+	}
+	return s, nil
+}
+
+// SessionQuestion is the current question of a session as shown to a frontend
+type SessionQuestion struct {
+	Number  int      `json:"number"` // 1-based position in the session
+	Total   int      `json:"total"`
+	Score   int      `json:"score"`
+	Kana    string   `json:"kana"`
+	Romaji  string   `json:"romaji"`
+	Options []string `json:"options"`
+	Done    bool     `json:"done"` // Every question was answered; the other fields except Score and Total are empty
+}
+
+// AnswerResult is the outcome of an answer
+type AnswerResult struct {
+	Correct bool   `json:"correct"`
+	Answer  string `json:"answer"` // The correct answer
+	Score   int    `json:"score"`
+	Total   int    `json:"total"`
+	Done    bool   `json:"done"` // This was the last question
+}
+
+// question returns the current question of a session
+func (e *quizEngine) question(id string) (SessionQuestion, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s, err := e.session(id)
+	if err != nil {
+		return SessionQuestion{}, err
+	}
+	if s.index >= len(s.questions) {
+		return SessionQuestion{Total: len(s.questions), Score: s.score, Done: true}, nil
+	}
+	q := s.questions[s.index]
+	if s.options == nil {
+		s.options = append(s.answers.random(q.QAnswer, 3), q.QAnswer)
+		rand.Shuffle(len(s.options), func(i, j int) {
+			s.options[i], s.options[j] = s.options[j], s.options[i]
+		})
+	}
+	return SessionQuestion{
+		Number:  s.index + 1,
+		Total:   len(s.questions),
+		Score:   s.score,
+		Kana:    q.QHirakata,
+		Romaji:  q.QRomaji,
+		Options: s.options,
+	}, nil
+}
+
+// answer checks an answer to the current question, records it and moves to the next one
+func (e *quizEngine) answer(id, picked string) (AnswerResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s, err := e.session(id)
+	if err != nil {
+		return AnswerResult{}, err
+	}
+	if s.index >= len(s.questions) {
+		return AnswerResult{}, errors.New("the session is finished")
+	}
+	q := s.questions[s.index]
+	correct := e.progress.recordPick(q, picked, scheduleFor(e.settings, e.deckName, q.QChapter), e.settings.RetireAfter)
+	if correct {
+		s.score++
+	}
+	s.index++
+	s.options = nil
+
+	result := AnswerResult{
+		Correct: correct,
+		Answer:  q.QAnswer,
+		Score:   s.score,
+		Total:   len(s.questions),
+		Done:    s.index >= len(s.questions),
+	}
+	if err := e.progress.save(); err != nil {
+		return result, fmt.Errorf("failed to save progress: %w", err)
+	}
+	return result, nil
+}
+
+// end forgets a session
+func (e *quizEngine) end(id string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.sessions, id)
+}
+
+// summary reports the learner's progress over the whole deck
+func (e *quizEngine) summary() ProgressSummary {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return summarizeProgress(e.questions, e.progress, time.Now())
+}
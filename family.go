@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// familyRounds is the number of scenarios in a family terms drill
+const familyRounds = 10
+
+// familyTerm is a family member with the word for one's own, used when talking to others,
+// and the word for someone else's, also used to call one's own elder relatives
+type familyTerm struct {
+	english string
+	own     string // はは
+	others  string // おかあさん
+	elder   bool   // Called by the polite word within the family
+}
+
+// familyTerms are the family words of the textbook's family chapter
+var familyTerms = []familyTerm{
+	{"mother", "はは", "おかあさん", true},
+	{"father", "ちち", "おとうさん", true},
+	{"older sister", "あね", "おねえさん", true},
+	{"older brother", "あに", "おにいさん", true},
+	{"younger sister", "いもうと", "いもうとさん", false},
+	{"younger brother", "おとうと", "おとうとさん", false},
+	{"grandmother", "そぼ", "おばあさん", true},
+	{"grandfather", "そふ", "おじいさん", true},
+	{"husband", "おっと", "ごしゅじん", false},
+	{"wife", "つま", "おくさん", false},
+	{"child", "こども", "おこさん", false},
+	{"parents", "りょうしん", "ごりょうしん", false},
+	{"siblings", "きょうだい", "ごきょうだい", false},
+	{"family", "かぞく", "ごかぞく", false},
+}
+
+// Perspectives of a family terms scenario
+const (
+	familyOwn     = iota // Talking about one's own family to someone outside it
+	familyOthers         // Talking about or asking after someone else's family
+	familyAddress        // Calling one's own elder relative
+)
+
+// familyScenarios describe each perspective, with %s for the family member
+var familyScenarios = map[int][]string{
+	familyOwn: {
+		"Telling your teacher about your own %s",
+		"Telling a classmate about your own %s",
+		"Writing about your own %s in a self-introduction",
+	},
+	familyOthers: {
+		"Asking a classmate about their %s",
+		"Talking about your friend Mary's %s",
+		"Asking your teacher about their %s",
+	},
+	familyAddress: {
+		"Calling out to your own %s at home",
+		"Talking to your own %s on the phone",
+	},
+}
+
+// familyScenario is one situation, the family word it calls for and why
+type familyScenario struct {
+	term        familyTerm
+	perspective int
+	text        string
+}
+
+// answer returns the family word the scenario calls for
+func (s familyScenario) answer() string {
+	if s.perspective == familyOwn {
+		return s.term.own
+	}
+	return s.term.others
+}
+
+// explanation tells which word is used from which perspective
+func (s familyScenario) explanation() string {
+	text := fmt.Sprintf("%s is your own %s when talking to others; %s is someone else's.",
+		s.term.own, s.term.english, s.term.others)
+	if s.term.elder {
+		text += fmt.Sprintf(" Within the family, you call your own %s %s too.", s.term.english, s.term.others)
+	}
+	return text
+}
+
+// randomFamilyScenario picks a family member and a perspective. Only elder relatives are
+// called by the polite word, so only they come up for calling out.
+func randomFamilyScenario() familyScenario {
+	term := familyTerms[rand.Intn(len(familyTerms))]
+	perspective := rand.Intn(2)
+	if term.elder && rand.Intn(4) == 0 {
+		perspective = familyAddress
+	}
+	texts := familyScenarios[perspective]
+	return familyScenario{term: term, perspective: perspective, text: fmt.Sprintf(texts[rand.Intn(len(texts))], term.english)}
+}
+
+// familyChoices offers both words for the family member of a scenario and both for
+// another one, shuffled
+func familyChoices(s familyScenario) []string {
+	other := familyTerms[rand.Intn(len(familyTerms))]
+	for other.english == s.term.english {
+		other = familyTerms[rand.Intn(len(familyTerms))]
+	}
+	choices := []string{s.term.own, s.term.others, other.own, other.others}
+	rand.Shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	return choices
+}
+
+// familyDrill presents family scenarios and grades the word picked for each
+type familyDrill struct {
+	qa      *quizApp
+	round   int
+	correct int
+	missed  map[string]int // Family members answered wrong, by English
+}
+
+// startFamilyDrill starts a drill on the words for one's own and others' family
+func (qa *quizApp) startFamilyDrill() {
+	d := &familyDrill{qa: qa, missed: make(map[string]int)}
+	d.showRound()
+}
+
+// showRound shows a scenario with the words to choose from
+func (d *familyDrill) showRound() {
+	if d.round >= familyRounds {
+		d.showResults()
+		return
+	}
+	s := randomFamilyScenario()
+	feedback := wrappedLabel("")
+	answered := false
+	next := widget.NewButton("Next", d.qa.serialized(d.showRound))
+	next.Importance = widget.HighImportance
+	next.Hide()
+
+	choices := familyChoices(s)
+	buttons := make([]*widget.Button, len(choices))
+	grid := container.NewGridWithColumns(2)
+	for i, choice := range choices {
+		choice := choice
+		buttons[i] = widget.NewButton(choice, d.qa.serialized(func() {
+			if answered {
+				return
+			}
+			answered = true
+			d.round++
+			for j, button := range buttons {
+				if choices[j] == s.answer() {
+					button.SetText("✅ " + button.Text)
+				} else if choices[j] == choice {
+					button.SetText("❌ " + button.Text)
+				}
+			}
+			if choice == s.answer() {
+				d.correct++
+				feedback.SetText("✅ Correct! " + s.explanation())
+			} else {
+				d.missed[s.term.english]++
+				feedback.SetText("❌ " + s.explanation())
+			}
+			next.Show()
+		}))
+		grid.Add(buttons[i])
+	}
+
+	d.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Family Terms — %d/%d — Score: %d", d.round+1, familyRounds, d.correct),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		container.NewGridWithColumns(2,
+			next,
+			widget.NewButton("End Drill", d.qa.serialized(d.showResults)),
+		),
+		nil, nil,
+		container.NewVBox(
+			layoutSpacer(),
+			widget.NewLabelWithStyle(s.text+".", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle("Which word do you use?", fyne.TextAlignCenter, fyne.TextStyle{}),
+			grid,
+			feedback,
+		),
+	))
+}
+
+// showResults shows the scenarios answered right and the family members to review
+func (d *familyDrill) showResults() {
+	if d.round >= familyRounds {
+		d.qa.earnPerfectXP(float64(d.correct), familyRounds)
+	}
+	results := container.NewVBox(
+		widget.NewLabelWithStyle("Family Terms Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("You chose the right word %d of %d times.", d.correct, d.round)),
+	)
+	if len(d.missed) > 0 {
+		var lines []string
+		for _, term := range familyTerms {
+			if d.missed[term.english] > 0 {
+				lines = append(lines, fmt.Sprintf("%s: %s / %s", term.english, term.own, term.others))
+			}
+		}
+		results.Add(widget.NewLabel("To review (yours / others'):\n" + strings.Join(lines, "\n")))
+	}
+	results.Add(widget.NewButton("Try Again", d.qa.serialized(func() {
+		d.qa.startFamilyDrill()
+	})))
+	results.Add(widget.NewButton("Return to Chapter Selection", d.qa.serialized(func() {
+		d.qa.showChapterSelection()
+	})))
+	d.qa.showScreen(container.NewCenter(results))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Answer feedback timing
+const (
+	shakeTime = 400 * time.Millisecond
+	pulseTime = 500 * time.Millisecond
+	toastTime = 900 * time.Millisecond
+)
+
+// shakeDistance is how far a shaken option moves to each side at first
+const shakeDistance = 10
+
+// toastTextSize is the size of the 正解!/残念 toast
+const toastTextSize = 72
+
+// animationsOn reports whether the system allows animations
+func animationsOn() bool {
+	return fyne.CurrentApp().Settings().ShowAnimations()
+}
+
+// shake moves an object from side to side, dying down, to mark a wrong answer
+func shake(obj fyne.CanvasObject) {
+	if !animationsOn() {
+		return
+	}
+	origin := obj.Position()
+	animation := fyne.NewAnimation(shakeTime, func(done float32) {
+		offset := float32(math.Sin(float64(done)*6*math.Pi)) * shakeDistance * (1 - done)
+		obj.Move(origin.AddXY(offset, 0))
+	})
+	animation.Curve = fyne.AnimationLinear
+	animation.Start()
+}
+
+// pulse flashes a highlight over an option, to mark a right answer
+func pulse(highlight *canvas.Rectangle, c color.Color) {
+	if !animationsOn() {
+		return
+	}
+	base := color.NRGBAModel.Convert(c).(color.NRGBA)
+	animation := fyne.NewAnimation(pulseTime, func(done float32) {
+		flash := base
+		flash.A = uint8(float32(base.A) * 0.6 * float32(math.Sin(float64(done)*math.Pi)))
+		highlight.FillColor = flash
+		highlight.Refresh()
+	})
+	animation.Curve = fyne.AnimationLinear
+	animation.Start()
+}
+
+// showAnswerToast shows 正解! or 残念 in large text over the window, fading out
+func (qa *quizApp) showAnswerToast(correct bool) {
+	if !qa.settings.AnswerToast {
+		return
+	}
+	text, c := "残念…", theme.ErrorColor()
+	if correct {
+		text, c = "正解!", theme.SuccessColor()
+	}
+	toast := canvas.NewText(text, c)
+	qa.japaneseText(toast, toastTextSize)
+	toast.TextStyle.Bold = true
+	overlay := container.NewCenter(toast)
+
+	overlays := qa.window.Canvas().Overlays()
+	overlays.Add(overlay)
+	remove := func() {
+		overlays.Remove(overlay)
+	}
+	if !animationsOn() {
+		time.AfterFunc(toastTime, remove)
+		return
+	}
+	base := color.NRGBAModel.Convert(c).(color.NRGBA)
+	animation := fyne.NewAnimation(toastTime, func(done float32) {
+		faded := base
+		faded.A = uint8(float32(base.A) * (1 - done))
+		toast.Color = faded
+		toast.Refresh()
+		if done >= 1 {
+			remove()
+		}
+	})
+	animation.Curve = fyne.AnimationEaseIn
+	animation.Start()
+}
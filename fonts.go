@@ -0,0 +1,111 @@
+package main
+
+import (
+	"log"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+)
+
+// fontSettings chooses fonts for Japanese and for Latin text separately, since
+// the best fonts for each are rarely the same
+type fontSettings struct {
+	Latin         string  `json:"latin"`          // Font file for the interface, romaji and English; empty for the default
+	Japanese      string  `json:"japanese"`       // Font file for kana and kanji questions; empty for the system's
+	TextSize      float32 `json:"text_size"`      // Interface text size, 0 for the default
+	JapaneseScale int     `json:"japanese_scale"` // Size of Japanese questions in percent, 0 for 100
+}
+
+// Limits of the font sizes that can be set
+const (
+	minTextSize      = 10
+	maxTextSize      = 32
+	minJapaneseScale = 50
+	maxJapaneseScale = 300
+)
+
+// loadedFonts caches font files by path, nil for files that failed to load
+var (
+	loadedFontsMu sync.Mutex
+	loadedFonts   = make(map[string]fyne.Resource)
+)
+
+// loadFont reads a font file once, returning nil for an empty path or a file that cannot be read
+func loadFont(path string) fyne.Resource {
+	if path == "" {
+		return nil
+	}
+	loadedFontsMu.Lock()
+	defer loadedFontsMu.Unlock()
+	if font, ok := loadedFonts[path]; ok {
+		return font
+	}
+	font, err := fyne.LoadResourceFromPath(path)
+	if err != nil {
+		log.Printf("Failed to load font: %v", err)
+		font = nil
+	}
+	loadedFonts[path] = font
+	return font
+}
+
+// fontTheme replaces the interface font and text size of another theme
+type fontTheme struct {
+	fyne.Theme
+	latin    fyne.Resource // nil to keep the theme's font
+	textSize float32       // 0 to keep the theme's size
+}
+
+// Font returns the Latin font for regular text; monospace and symbols keep the theme's fonts
+func (t fontTheme) Font(style fyne.TextStyle) fyne.Resource {
+	if t.latin != nil && !style.Monospace && !style.Symbol {
+		return t.latin
+	}
+	return t.Theme.Font(style)
+}
+
+// Size returns the chosen text size, scaling the heading sizes along with it
+func (t fontTheme) Size(name fyne.ThemeSizeName) float32 {
+	size := t.Theme.Size(name)
+	if t.textSize <= 0 {
+		return size
+	}
+	switch name {
+	case theme.SizeNameText, theme.SizeNameHeadingText, theme.SizeNameSubHeadingText, theme.SizeNameCaptionText:
+		return size * t.textSize / t.Theme.Size(theme.SizeNameText)
+	}
+	return size
+}
+
+// withFonts applies the font settings to a theme
+func (s fontSettings) withFonts(base fyne.Theme) fyne.Theme {
+	latin := loadFont(s.Latin)
+	if latin == nil && s.TextSize <= 0 {
+		return base
+	}
+	return fontTheme{Theme: base, latin: latin, textSize: s.TextSize}
+}
+
+// japaneseText sets the Japanese font and size of a text showing kana or kanji.
+// The Japanese font has a single face, so bold text is shown in its regular weight.
+func (qa *quizApp) japaneseText(t *canvas.Text, size float32) {
+	fonts := qa.settings.Fonts
+	t.FontSource = loadFont(fonts.Japanese)
+	if fonts.JapaneseScale > 0 {
+		size = size * float32(fonts.JapaneseScale) / 100
+	}
+	t.TextSize = size
+	t.Refresh()
+}
+
+// japaneseRuby sets the Japanese font and size of text with furigana
+func (qa *quizApp) japaneseRuby(r *rubyText) {
+	fonts := qa.settings.Fonts
+	r.FontSource = loadFont(fonts.Japanese)
+	if fonts.JapaneseScale > 0 {
+		r.TextSize = theme.TextSize() * float32(fonts.JapaneseScale) / 100
+	}
+	r.Refresh()
+}
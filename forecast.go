@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// targetMastery is the share of a chapter that should be mastered
+const targetMastery = 0.8
+
+// paceWindow is the period over which the study pace is measured
+const paceWindow = 14 * 24 * time.Hour
+
+// hardestShown is how many of the hardest items the chapter dashboard lists
+const hardestShown = 3
+
+// itemDifficulty estimates how likely the item is to be answered wrongly (0 easy – 1 hard).
+// The error rate is smoothed towards 50% so items with few answers are not extreme.
+func itemDifficulty(stats *itemStats) float64 {
+	if stats == nil {
+		return 0.5
+	}
+	return float64(stats.Seen-stats.Correct+1) / float64(stats.Seen+2)
+}
+
+// countMastered counts the mastered questions
+func countMastered(questions []Question, progress *Progress) int {
+	mastered := 0
+	for _, q := range questions {
+		if progress.stats(q.QID).mastered() {
+			mastered++
+		}
+	}
+	return mastered
+}
+
+// masteryPace is the number of items newly mastered per day, measured over paceWindow
+func masteryPace(progress *Progress, now time.Time) float64 {
+	since := now.Add(-paceWindow)
+	recent := 0
+	for _, stats := range progress.Items {
+		if stats.MasteredAt.After(since) {
+			recent++
+		}
+	}
+	return float64(recent) / paceWindow.Hours() * 24
+}
+
+// forecastMastery estimates when the given share of the questions will be mastered at the
+// current pace. ok is false when no pace can be measured yet; a zero date means it is reached.
+func forecastMastery(questions []Question, progress *Progress, target float64, now time.Time) (time.Time, bool) {
+	needed := int(math.Ceil(target*float64(len(questions)))) - countMastered(questions, progress)
+	if needed <= 0 {
+		return time.Time{}, true
+	}
+	pace := masteryPace(progress, now)
+	if pace == 0 {
+		return time.Time{}, false
+	}
+	days := math.Ceil(float64(needed) / pace)
+	return now.AddDate(0, 0, int(days)), true
+}
+
+// hardestQuestions returns the answered questions with the highest estimated difficulty
+func hardestQuestions(questions []Question, progress *Progress, n int) []Question {
+	var answered []Question
+	for _, q := range questions {
+		if progress.stats(q.QID) != nil {
+			answered = append(answered, q)
+		}
+	}
+	sort.SliceStable(answered, func(i, j int) bool {
+		return itemDifficulty(progress.stats(answered[i].QID)) > itemDifficulty(progress.stats(answered[j].QID))
+	})
+	if len(answered) > n {
+		answered = answered[:n]
+	}
+	return answered
+}
+
+// chapterDashboard summarizes mastery, difficulty, the forecast and the boss quiz for a chapter
+func (qa *quizApp) chapterDashboard(chapter string, questions []Question) fyne.CanvasObject {
+	progress := qa.progress
+	mastered := countMastered(questions, progress)
+	dashboard := container.NewVBox(widget.NewLabel(fmt.Sprintf("Mastered: %d/%d (%.0f%%)",
+		mastered, len(questions), float64(mastered)/math.Max(1, float64(len(questions)))*100)))
+	if at, ok := progress.Conquered[chapter]; ok {
+		dashboard.Add(widget.NewLabel("👑 Conquered on " + at.Format("Jan 2, 2006")))
+	}
+
+	forecast := "Forecast: answer a few more questions to measure your pace"
+	if date, ok := forecastMastery(questions, progress, targetMastery, time.Now()); ok {
+		if date.IsZero() {
+			forecast = fmt.Sprintf("%.0f%% mastery reached!", targetMastery*100)
+		} else {
+			forecast = fmt.Sprintf("%.0f%% mastery expected by %s at your current pace",
+				targetMastery*100, date.Format("Jan 2"))
+		}
+	}
+	dashboard.Add(widget.NewLabel(forecast))
+
+	if hardest := hardestQuestions(questions, progress, hardestShown); len(hardest) > 0 {
+		var text string
+		for i, q := range hardest {
+			if i > 0 {
+				text += ", "
+			}
+			text += fmt.Sprintf("%s (%.0f%%)", q.QHirakata, itemDifficulty(progress.stats(q.QID))*100)
+		}
+		dashboard.Add(widget.NewLabel("Hardest: " + text))
+	}
+	return widget.NewCard("", "", dashboard)
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+//go:embed data/grammar_exercises.json
+var bundledExercisesJSON []byte
+
+// grammarFile holds custom grammar exercises, loaded from the data folder if present
+const grammarFile = "grammar.json"
+
+// grammarQuizSize is the number of sentences in a grammar quiz
+const grammarQuizSize = 10
+
+// grammarTextSize is the size of the sentence, smaller than a question to fit on one line
+const grammarTextSize = 20
+
+// blankNumbers mark the blanks of a sentence
+var blankNumbers = []string{"①", "②", "③", "④", "⑤", "⑥", "⑦", "⑧", "⑨"}
+
+// gapPattern finds the numbered gaps of a sentence, {1}, {2}…
+var gapPattern = regexp.MustCompile(`\{(\d)\}`)
+
+// GrammarExercise is a sentence of a grammar point with blanks filled in from choices,
+// like the exercises of the workbook
+type GrammarExercise struct {
+	Chapter     string         `json:"chapter"`
+	Point       string         `json:"point"`       // Grammar point practised
+	Sentence    string         `json:"sentence"`    // With a numbered gap for each blank: {1}, {2}…
+	Translation string         `json:"translation"` // English of the whole sentence
+	Blanks      []GrammarBlank `json:"blanks"`
+}
+
+// GrammarBlank is one blank of a sentence, such as a particle or a verb form
+type GrammarBlank struct {
+	Answer  string   `json:"answer"`
+	Options []string `json:"options"`        // Wrong choices offered with the answer
+	Hint    string   `json:"hint,omitempty"` // What goes in the blank, e.g. "particle"
+}
+
+// valid reports whether a sentence has a gap for each blank and each blank has choices
+func (e GrammarExercise) valid() bool {
+	gaps := gapPattern.FindAllStringSubmatch(e.Sentence, -1)
+	if len(e.Blanks) == 0 || len(e.Blanks) > len(blankNumbers) || len(gaps) != len(e.Blanks) {
+		return false
+	}
+	for i, gap := range gaps {
+		if gap[1] != fmt.Sprint(i+1) || e.Blanks[i].Answer == "" || len(e.Blanks[i].Options) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// filled writes the sentence with each gap replaced by fill
+func (e GrammarExercise) filled(fill func(blank int) string) string {
+	return gapPattern.ReplaceAllStringFunc(e.Sentence, func(gap string) string {
+		var n int
+		fmt.Sscanf(gap, "{%d}", &n)
+		return fill(n - 1)
+	})
+}
+
+// loadGrammarExercises returns the bundled grammar exercises followed by any custom ones
+// from grammarFile. Exercises whose gaps do not match their blanks are left out.
+func loadGrammarExercises() []GrammarExercise {
+	var exercises []GrammarExercise
+	if err := json.Unmarshal(bundledExercisesJSON, &exercises); err != nil {
+		log.Printf("Invalid bundled grammar exercises: %v", err)
+	}
+	if data, err := os.ReadFile(dataPath(grammarFile)); err == nil {
+		var custom []GrammarExercise
+		if err := json.Unmarshal(data, &custom); err != nil {
+			log.Printf("Ignoring %s: %v", grammarFile, err)
+		}
+		exercises = append(exercises, custom...)
+	}
+
+	var valid []GrammarExercise
+	for _, e := range exercises {
+		if !e.valid() {
+			log.Printf("Skipping grammar exercise %q: its gaps do not match its blanks", e.Sentence)
+			continue
+		}
+		valid = append(valid, e)
+	}
+	return valid
+}
+
+// grammarExercises returns the grammar exercises of a chapter
+func grammarExercises(chapter string) []GrammarExercise {
+	var exercises []GrammarExercise
+	for _, e := range loadGrammarExercises() {
+		if e.Chapter == chapter {
+			exercises = append(exercises, e)
+		}
+	}
+	return exercises
+}
+
+// grammarQuiz fills the blanks of sentences from choices, graded blank by blank
+type grammarQuiz struct {
+	qa        *quizApp
+	exercises []GrammarExercise
+	index     int
+	right     int            // Blanks filled in right
+	blanks    int            // Blanks graded
+	missed    map[string]int // Blanks filled in wrong by grammar point
+}
+
+// grammarButton starts a grammar quiz on the current chapter, disabled if it has no exercises
+func (qa *quizApp) grammarButton() *widget.Button {
+	exercises := grammarExercises(qa.state.currentChapter)
+	button := widget.NewButton(fmt.Sprintf("Grammar Exercises (%d)", len(exercises)), qa.serialized(func() {
+		qa.startGrammarQuiz(exercises)
+	}))
+	if len(exercises) == 0 {
+		button.Disable()
+	}
+	return button
+}
+
+// startGrammarQuiz asks up to grammarQuizSize of the exercises in random order
+func (qa *quizApp) startGrammarQuiz(exercises []GrammarExercise) {
+	exercises = append([]GrammarExercise(nil), exercises...)
+	rand.Shuffle(len(exercises), func(i, j int) { exercises[i], exercises[j] = exercises[j], exercises[i] })
+	if len(exercises) > grammarQuizSize {
+		exercises = exercises[:grammarQuizSize]
+	}
+	g := &grammarQuiz{qa: qa, exercises: exercises, missed: make(map[string]int)}
+	g.showExercise()
+}
+
+// showExercise shows the current sentence with a choice for each blank
+func (g *grammarQuiz) showExercise() {
+	if g.index >= len(g.exercises) {
+		g.showResults()
+		return
+	}
+	e := g.exercises[g.index]
+
+	sentence := canvas.NewText(e.filled(func(blank int) string {
+		return "（" + blankNumbers[blank] + "）"
+	}), theme.ForegroundColor())
+	sentence.Alignment = fyne.TextAlignCenter
+	g.qa.japaneseText(sentence, grammarTextSize)
+
+	choices := make([]*widget.Select, len(e.Blanks))
+	marks := make([]*widget.Label, len(e.Blanks))
+	form := container.New(layout.NewFormLayout())
+	for i, blank := range e.Blanks {
+		options := append([]string{blank.Answer}, blank.Options...)
+		rand.Shuffle(len(options), func(a, b int) { options[a], options[b] = options[b], options[a] })
+		choices[i] = widget.NewSelect(options, nil)
+		choices[i].PlaceHolder = "Choose"
+		marks[i] = widget.NewLabel("")
+		label := blankNumbers[i]
+		if blank.Hint != "" {
+			label += " " + blank.Hint
+		}
+		form.Add(widget.NewLabel(label))
+		form.Add(container.NewBorder(nil, nil, nil, marks[i], choices[i]))
+	}
+
+	feedback := wrappedLabel("")
+	var checkButton *widget.Button
+	answered := false
+	checkButton = widget.NewButton("Check", g.qa.serialized(func() {
+		if answered {
+			g.index++
+			g.showExercise()
+			return
+		}
+		for _, choice := range choices {
+			if choice.Selected == "" {
+				dialog.ShowInformation("Grammar Exercises", "Please fill in every blank.", g.qa.window)
+				return
+			}
+		}
+		answered = true
+		right := 0
+		for i, blank := range e.Blanks {
+			choices[i].Disable()
+			if choices[i].Selected == blank.Answer {
+				right++
+				marks[i].SetText("✅")
+			} else {
+				g.missed[e.Point]++
+				marks[i].SetText("❌ " + blank.Answer)
+			}
+		}
+		g.right += right
+		g.blanks += len(e.Blanks)
+		sentence.Text = e.filled(func(blank int) string { return e.Blanks[blank].Answer })
+		sentence.Refresh()
+		feedback.SetText(fmt.Sprintf("%d of %d blanks right. %s", right, len(e.Blanks), e.Translation))
+		checkButton.SetText("Next")
+	}))
+	checkButton.Importance = widget.HighImportance
+
+	g.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Grammar Exercises — Sentence %d/%d — %s", g.index+1, len(g.exercises), e.Point),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		container.NewGridWithColumns(2,
+			checkButton,
+			widget.NewButton("End Exercises", g.qa.serialized(g.showResults)),
+		),
+		nil, nil,
+		container.NewVBox(layoutSpacer(), sentence, form, feedback),
+	))
+}
+
+// showResults shows the blanks filled in right and the grammar points missed most
+func (g *grammarQuiz) showResults() {
+	if g.index >= len(g.exercises) {
+		g.qa.earnPerfectXP(float64(g.right), g.blanks)
+	}
+	results := container.NewVBox(
+		widget.NewLabelWithStyle("Grammar Exercises Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("Blanks right: %d/%d", g.right, g.blanks)),
+	)
+	var points []string
+	for point := range g.missed {
+		points = append(points, point)
+	}
+	sort.Slice(points, func(i, j int) bool { return g.missed[points[i]] > g.missed[points[j]] })
+	if len(points) > 0 {
+		lines := make([]string, len(points))
+		for i, point := range points {
+			lines[i] = fmt.Sprintf("%s: %d missed", point, g.missed[point])
+		}
+		results.Add(widget.NewLabel("To review:\n" + strings.Join(lines, "\n")))
+	}
+	results.Add(widget.NewButton("Return to Chapter Selection", g.qa.serialized(func() {
+		g.qa.showChapterSelection()
+	})))
+	g.qa.showScreen(container.NewCenter(results))
+}
@@ -0,0 +1,62 @@
+//go:build android
+
+package main
+
+/*
+#include <jni.h>
+#include <stdint.h>
+
+// hapticVirtualKey is HapticFeedbackConstants.VIRTUAL_KEY, the feedback of a key press
+#define hapticVirtualKey 1
+
+// performHapticFeedback asks the activity's view to vibrate, which needs no VIBRATE permission
+static void performHapticFeedback(uintptr_t jniEnv, uintptr_t ctx) {
+	JNIEnv *env = (JNIEnv*)jniEnv;
+	jobject activity = (jobject)ctx;
+
+	jclass activityClass = (*env)->GetObjectClass(env, activity);
+	jmethodID getWindow = (*env)->GetMethodID(env, activityClass, "getWindow", "()Landroid/view/Window;");
+	if (getWindow == NULL) {
+		(*env)->ExceptionClear(env);
+		return;
+	}
+	jobject window = (*env)->CallObjectMethod(env, activity, getWindow);
+	if (window == NULL) {
+		(*env)->ExceptionClear(env);
+		return;
+	}
+	jclass windowClass = (*env)->GetObjectClass(env, window);
+	jmethodID getDecorView = (*env)->GetMethodID(env, windowClass, "getDecorView", "()Landroid/view/View;");
+	jobject view = getDecorView == NULL ? NULL : (*env)->CallObjectMethod(env, window, getDecorView);
+	if (view == NULL) {
+		(*env)->ExceptionClear(env);
+		return;
+	}
+	jclass viewClass = (*env)->GetObjectClass(env, view);
+	jmethodID perform = (*env)->GetMethodID(env, viewClass, "performHapticFeedback", "(I)Z");
+	if (perform != NULL) {
+		(*env)->CallBooleanMethod(env, view, perform, hapticVirtualKey);
+	}
+	(*env)->ExceptionClear(env);
+}
+*/
+import "C"
+
+import (
+	"log"
+
+	"fyne.io/fyne/v2/driver"
+)
+
+// hapticFeedback gives a short vibration, like a key press on the on-screen keyboard
+func hapticFeedback() {
+	err := driver.RunNative(func(context any) error {
+		if android, ok := context.(*driver.AndroidContext); ok {
+			C.performHapticFeedback(C.uintptr_t(android.Env), C.uintptr_t(android.Ctx))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to give haptic feedback: %v", err)
+	}
+}
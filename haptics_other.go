@@ -0,0 +1,6 @@
+//go:build !android
+
+package main
+
+// hapticFeedback gives a short vibration. Only Android builds have a way to do this.
+func hapticFeedback() {}
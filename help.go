@@ -0,0 +1,33 @@
+package main
+
+import (
+	_ "embed"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// helpText documents the quizzes, shortcuts and deck format
+//
+//go:embed data/help.md
+var helpText string
+
+// showHelp shows the help text
+func (qa *quizApp) showHelp() {
+	text := widget.NewRichTextFromMarkdown(helpText)
+	text.Wrapping = fyne.TextWrapWord
+	d := dialog.NewCustom("Genki Quiz Help", "Close", container.NewVScroll(text), qa.window)
+	d.Resize(fyne.NewSize(640, 520))
+	d.Show()
+}
+
+// registerHelpKey opens the help with F1 when no text field has the focus
+func (qa *quizApp) registerHelpKey() {
+	qa.window.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
+		if key.Name == fyne.KeyF1 {
+			qa.showHelp()
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// errGlobalHotkeyUnsupported is returned on platforms without global hotkey support
+var errGlobalHotkeyUnsupported = errors.New("global hotkeys are not supported on this platform")
+
+// hotkeySpec is a parsed key combination such as "Ctrl+Alt+J"
+type hotkeySpec struct {
+	ctrl, alt, shift, super bool
+	key                     string // Upper-case letter, digit or F1–F12
+}
+
+// parseHotkey parses a key combination like "Ctrl+Alt+J" or "Shift+F9"
+func parseHotkey(text string) (hotkeySpec, error) {
+	var spec hotkeySpec
+	for _, part := range strings.Split(text, "+") {
+		part = strings.TrimSpace(part)
+		switch strings.ToLower(part) {
+		case "ctrl", "control":
+			spec.ctrl = true
+		case "alt", "option":
+			spec.alt = true
+		case "shift":
+			spec.shift = true
+		case "win", "super", "cmd", "command":
+			spec.super = true
+		default:
+			if spec.key != "" {
+				return spec, fmt.Errorf("hotkey %q has more than one key", text)
+			}
+			spec.key = strings.ToUpper(part)
+		}
+	}
+
+	switch {
+	case len(spec.key) == 1 && (spec.key[0] >= 'A' && spec.key[0] <= 'Z' || spec.key[0] >= '0' && spec.key[0] <= '9'):
+	case hotkeyFunctionKey(spec.key) > 0:
+	default:
+		return spec, fmt.Errorf("hotkey %q needs a letter, digit or F1–F12 key", text)
+	}
+	if !spec.ctrl && !spec.alt && !spec.super {
+		return spec, fmt.Errorf("hotkey %q needs Ctrl, Alt or Win so it does not clash with typing", text)
+	}
+	return spec, nil
+}
+
+// hotkeyFunctionKey returns n for the key "Fn" (1–12), or 0
+func hotkeyFunctionKey(key string) int {
+	var n int
+	if _, err := fmt.Sscanf(key, "F%d", &n); err != nil || n < 1 || n > 12 || key != fmt.Sprintf("F%d", n) {
+		return 0
+	}
+	return n
+}
+
+// dueQuestion picks the question most in need of review: unseen items first,
+// then unmastered items that were answered longest ago
+func dueQuestion(questions []Question, progress *Progress) (Question, bool) {
+	var best Question
+	var bestTime time.Time
+	found := false
+	for _, i := range rand.Perm(len(questions)) {
+		q := questions[i]
+		stats := progress.stats(q.QID)
+		if stats == nil {
+			return q, true
+		}
+		if stats.mastered() {
+			continue
+		}
+		if !found || stats.LastSeen.Before(bestTime) {
+			best, bestTime, found = q, stats.LastSeen, true
+		}
+	}
+	return best, found
+}
+
+// registerHotkey installs the global hotkey from the settings, replacing any previous one
+func (qa *quizApp) registerHotkey() {
+	if qa.stopHotkey != nil {
+		qa.stopHotkey()
+		qa.stopHotkey = nil
+	}
+	if qa.settings.Hotkey == "" {
+		return
+	}
+
+	spec, err := parseHotkey(qa.settings.Hotkey)
+	if err != nil {
+		log.Printf("Global hotkey disabled: %v", err)
+		return
+	}
+	// The hotkey fires on its own thread, so the popup is built holding the UI lock
+	stop, err := registerGlobalHotkey(spec, qa.serialized(qa.showPopupQuestion))
+	if err != nil {
+		log.Printf("Global hotkey disabled: %v", err)
+		return
+	}
+	qa.stopHotkey = stop
+}
+
+// showPopupQuestion shows one due question in a small window, which closes after it is answered
+func (qa *quizApp) showPopupQuestion() {
+	pool := qa.studyChapters(qa.availableChapters())
+	q, ok := dueQuestion(pool, qa.progress)
+	if !ok {
+		return
+	}
+
+	popup := qa.app.NewWindow("Genki Quiz")
+	question := canvas.NewText(q.QHirakata, theme.ForegroundColor())
+	question.TextStyle = fyne.TextStyle{Bold: true}
+	qa.japaneseText(question, 20)
+	question.Alignment = fyne.TextAlignCenter
+
+	options := append(qa.index.chapterAnswers(q.QChapter).random(q.QAnswer, 3), q.QAnswer)
+	rand.Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
+
+	grid := container.NewGridWithColumns(2)
+	buttons := make([]*widget.Button, len(options))
+	for i, opt := range options {
+		opt := opt
+		buttons[i] = widget.NewButton(opt, qa.serialized(func() {
+			qa.recordAnswer(q, opt)
+			for j, button := range buttons {
+				if options[j] == q.QAnswer {
+					button.Importance = widget.SuccessImportance
+				} else if options[j] == opt {
+					button.Importance = widget.DangerImportance
+				}
+				button.OnTapped = nil
+				button.Refresh()
+			}
+			time.AfterFunc(1500*time.Millisecond, qa.serialized(popup.Close))
+		}))
+		grid.Add(buttons[i])
+	}
+
+	popup.SetContent(container.NewBorder(question, nil, nil, nil, grid))
+	popup.Resize(miniWidgetSize)
+	popup.SetFixedSize(true)
+	popup.CenterOnScreen()
+	popup.Show()
+	popup.RequestFocus()
+	setAlwaysOnTop(popup)
+}
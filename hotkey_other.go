@@ -0,0 +1,9 @@
+//go:build !windows
+
+package main
+
+// registerGlobalHotkey is unavailable here: grabbing keys system-wide needs
+// native window system bindings that the app does not link against
+func registerGlobalHotkey(spec hotkeySpec, fn func()) (func(), error) {
+	return nil, errGlobalHotkeyUnsupported
+}
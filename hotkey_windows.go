@@ -0,0 +1,99 @@
+//go:build windows
+
+package main
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	user32                 = syscall.NewLazyDLL("user32.dll")
+	procRegisterHotKey     = user32.NewProc("RegisterHotKey")
+	procUnregisterHotKey   = user32.NewProc("UnregisterHotKey")
+	procGetMessageW        = user32.NewProc("GetMessageW")
+	procPostThreadMessageW = user32.NewProc("PostThreadMessageW")
+	procGetCurrentThreadId = syscall.NewLazyDLL("kernel32.dll").NewProc("GetCurrentThreadId")
+)
+
+// Win32 constants used for hotkey registration
+const (
+	modAlt      = 0x0001
+	modControl  = 0x0002
+	modShift    = 0x0004
+	modWin      = 0x0008
+	modNoRepeat = 0x4000
+	wmHotkey    = 0x0312
+	wmQuit      = 0x0012
+	vkF1        = 0x70
+	hotkeyID    = 1
+)
+
+// winMsg mirrors the Win32 MSG structure
+type winMsg struct {
+	hwnd    uintptr
+	message uint32
+	wParam  uintptr
+	lParam  uintptr
+	time    uint32
+	pt      struct{ x, y int32 }
+}
+
+// registerGlobalHotkey registers a system-wide hotkey and calls fn whenever it is pressed.
+// The returned function unregisters it again.
+func registerGlobalHotkey(spec hotkeySpec, fn func()) (func(), error) {
+	mods := uintptr(modNoRepeat)
+	if spec.ctrl {
+		mods |= modControl
+	}
+	if spec.alt {
+		mods |= modAlt
+	}
+	if spec.shift {
+		mods |= modShift
+	}
+	if spec.super {
+		mods |= modWin
+	}
+	vk := uintptr(spec.key[0]) // Letters and digits use their ASCII code
+	if n := hotkeyFunctionKey(spec.key); n > 0 {
+		vk = uintptr(vkF1 + n - 1)
+	}
+
+	// Hotkey messages are delivered to the registering thread, so it gets its own
+	registered := make(chan error)
+	threadID := make(chan uintptr, 1)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		tid, _, _ := procGetCurrentThreadId.Call()
+		if ok, _, err := procRegisterHotKey.Call(0, hotkeyID, mods, vk); ok == 0 {
+			registered <- err
+			return
+		}
+		defer procUnregisterHotKey.Call(0, hotkeyID)
+		threadID <- tid
+		registered <- nil
+
+		var msg winMsg
+		for {
+			ret, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&msg)), 0, 0, 0)
+			if int32(ret) <= 0 {
+				return // WM_QUIT or error
+			}
+			if msg.message == wmHotkey {
+				fn()
+			}
+		}
+	}()
+
+	if err := <-registered; err != nil {
+		return nil, err
+	}
+	tid := <-threadID
+	return func() {
+		procPostThreadMessageW.Call(tid, wmQuit, 0, 0)
+	}, nil
+}
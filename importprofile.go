@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ImportProfile maps the columns of a CSV dialect onto question fields, so repeated
+// imports from the same source need no mapping. Column numbers start at 1; 0 means
+// the source has no such column.
+type ImportProfile struct {
+	Name      string `json:"name"`
+	Delimiter string `json:"delimiter"` // "," "\t" or ";"
+	Header    bool   `json:"header"`    // The first row names the columns and is skipped
+	Kana      int    `json:"kana"`      // May also hold "漢字 (かな)"; see splitReading
+	Answer    int    `json:"answer"`
+	Romaji    int    `json:"romaji"` // Generated from the kana when missing
+	Kanji     int    `json:"kanji"`
+	Chapter   int    `json:"chapter"` // Rows go into the chapter chosen on import when missing
+}
+
+// importProfileFile holds custom import profiles, in the data folder
+const importProfileFile = "import_profiles.json"
+
+// defaultImportProfiles cover common community exports
+var defaultImportProfiles = []ImportProfile{
+	{Name: "Memrise (level, word, definition)", Delimiter: ",", Header: true, Chapter: 1, Kana: 2, Answer: 3},
+	{Name: "Anki plain text (front, back)", Delimiter: "\t", Kana: 1, Answer: 2},
+	{Name: "CSV (kana, English)", Delimiter: ",", Kana: 1, Answer: 2},
+	{Name: "CSV (kanji, kana, romaji, English)", Delimiter: ",", Header: true, Kanji: 1, Kana: 2, Romaji: 3, Answer: 4},
+}
+
+// delimiterNames are the delimiters offered for import, by the name shown
+var delimiterNames = map[string]string{
+	"Comma":     ",",
+	"Tab":       "\t",
+	"Semicolon": ";",
+}
+
+// delimiterName returns the name shown for a delimiter
+func delimiterName(delimiter string) string {
+	for name, d := range delimiterNames {
+		if d == delimiter {
+			return name
+		}
+	}
+	return "Comma"
+}
+
+// loadCustomImportProfiles returns the profiles saved in importProfileFile
+func loadCustomImportProfiles() []ImportProfile {
+	data, err := os.ReadFile(dataPath(importProfileFile))
+	if err != nil {
+		return nil // No custom profiles
+	}
+	var custom []ImportProfile
+	if err := json.Unmarshal(data, &custom); err != nil {
+		log.Printf("Ignoring %s: %v", importProfileFile, err)
+		return nil
+	}
+	return custom
+}
+
+// loadImportProfiles returns the default profiles followed by the custom ones
+func loadImportProfiles() []ImportProfile {
+	return append(append([]ImportProfile(nil), defaultImportProfiles...), loadCustomImportProfiles()...)
+}
+
+// saveImportProfile adds a custom profile, replacing one with the same name
+func saveImportProfile(profile ImportProfile) error {
+	custom := loadCustomImportProfiles()
+	replaced := false
+	for i := range custom {
+		if custom[i].Name == profile.Name {
+			custom[i] = profile
+			replaced = true
+		}
+	}
+	if !replaced {
+		custom = append(custom, profile)
+	}
+	if guestMode {
+		return errGuestMode
+	}
+	data, err := json.MarshalIndent(custom, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dataPath(importProfileFile), data, 0o644)
+}
+
+// readRecords splits a CSV file with a profile's delimiter, dropping the header row if it has one
+func readRecords(data []byte, profile ImportProfile) ([][]string, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\uFEFF"))))
+	if delimiter := []rune(profile.Delimiter); len(delimiter) == 1 {
+		r.Comma = delimiter[0]
+	}
+	r.FieldsPerRecord = -1 // Rows may differ in length
+	r.LazyQuotes = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if profile.Header && len(records) > 0 {
+		records = records[1:]
+	}
+	return records, nil
+}
+
+// profileQuestions maps records onto questions, numbered from firstID. Rows without
+// kana or an answer are skipped and counted.
+func profileQuestions(records [][]string, profile ImportProfile, chapter string, firstID int) (questions []Question, skipped int) {
+	column := func(record []string, n int) string {
+		if n <= 0 || n > len(record) {
+			return ""
+		}
+		return cleanText(record[n-1])
+	}
+	for _, record := range records {
+		kana, kanji := column(record, profile.Kana), column(record, profile.Kanji)
+		if kanji == "" {
+			kana, kanji = splitReading(kana)
+		}
+		answer := column(record, profile.Answer)
+		if kana == "" || answer == "" {
+			skipped++
+			continue
+		}
+		romaji := column(record, profile.Romaji)
+		if romaji == "" && isKana(kana) {
+			romaji = capitalizeRomaji(toRomaji(kana))
+		}
+		questionChapter := column(record, profile.Chapter)
+		if questionChapter == "" {
+			questionChapter = chapter
+		}
+		questions = append(questions, Question{
+			QID:       strconv.Itoa(firstID + len(questions)),
+			QChapter:  questionChapter,
+			QAnswer:   answer,
+			QHirakata: kana,
+			QRomaji:   romaji,
+			QType:     "nil",
+			QKanji:    kanji,
+		})
+	}
+	return questions, skipped
+}
+
+// importCSV adds the rows of a CSV file to the deck, mapped by an import profile
+func (qa *quizApp) importCSV() {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+		defer reader.Close()
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		qa.showImportMapping(data, strings.TrimSuffix(reader.URI().Name(), reader.URI().Extension()))
+	}, qa.window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".tsv", ".txt"}))
+	open.Show()
+}
+
+// showImportMapping lets the user pick or adjust a profile, previews the first row and imports the file
+func (qa *quizApp) showImportMapping(data []byte, name string) {
+	profiles := loadImportProfiles()
+	var profileNames []string
+	for _, p := range profiles {
+		profileNames = append(profileNames, p.Name)
+	}
+
+	delimiterSelect := widget.NewSelect([]string{"Comma", "Tab", "Semicolon"}, nil)
+	headerCheck := widget.NewCheck("First row is a header", nil)
+	columnEntries := map[string]*widget.Entry{}
+	for _, field := range []string{"Kana", "Answer", "Romaji", "Kanji", "Chapter"} {
+		entry := widget.NewEntry()
+		entry.SetPlaceHolder("none")
+		columnEntries[field] = entry
+	}
+	chapterEntry := widget.NewEntry()
+	chapterEntry.SetText(name)
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Name to save this mapping as")
+	preview := widget.NewLabel("")
+	preview.Wrapping = fyne.TextWrapWord
+
+	// current reads the mapping from the form
+	current := func() ImportProfile {
+		column := func(field string) int {
+			n, _ := strconv.Atoi(strings.TrimSpace(columnEntries[field].Text))
+			return n
+		}
+		return ImportProfile{
+			Name:      strings.TrimSpace(nameEntry.Text),
+			Delimiter: delimiterNames[delimiterSelect.Selected],
+			Header:    headerCheck.Checked,
+			Kana:      column("Kana"),
+			Answer:    column("Answer"),
+			Romaji:    column("Romaji"),
+			Kanji:     column("Kanji"),
+			Chapter:   column("Chapter"),
+		}
+	}
+	updatePreview := func() {
+		records, err := readRecords(data, current())
+		if err != nil {
+			preview.SetText("Cannot read the file: " + err.Error())
+			return
+		}
+		questions, skipped := profileQuestions(records, current(), chapterEntry.Text, 1)
+		if len(questions) == 0 {
+			preview.SetText(fmt.Sprintf("No rows match this mapping (%d skipped).", skipped))
+			return
+		}
+		q := questions[0]
+		preview.SetText(fmt.Sprintf("%d questions, %d rows skipped. First: %s %s = %s (%s), chapter %s",
+			len(questions), skipped, q.QKanji, q.QHirakata, q.QAnswer, q.QRomaji, q.QChapter))
+	}
+	delimiterSelect.OnChanged = func(string) { updatePreview() }
+	headerCheck.OnChanged = func(bool) { updatePreview() }
+	chapterEntry.OnChanged = func(string) { updatePreview() }
+	for _, entry := range columnEntries {
+		entry.OnChanged = func(string) { updatePreview() }
+	}
+
+	show := func(p ImportProfile) {
+		delimiterSelect.SetSelected(delimiterName(p.Delimiter))
+		headerCheck.SetChecked(p.Header)
+		for field, n := range map[string]int{"Kana": p.Kana, "Answer": p.Answer, "Romaji": p.Romaji, "Kanji": p.Kanji, "Chapter": p.Chapter} {
+			text := ""
+			if n > 0 {
+				text = strconv.Itoa(n)
+			}
+			columnEntries[field].SetText(text)
+		}
+		updatePreview()
+	}
+	profileSelect := widget.NewSelect(profileNames, func(selected string) {
+		for _, p := range profiles {
+			if p.Name == selected {
+				show(p)
+			}
+		}
+	})
+	profileSelect.SetSelectedIndex(0)
+
+	saveButton := widget.NewButton("Save Mapping", func() {
+		profile := current()
+		if profile.Name == "" {
+			dialog.ShowInformation("Import CSV", "Please enter a name for the mapping.", qa.window)
+			return
+		}
+		if err := saveImportProfile(profile); err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		dialog.ShowInformation("Import CSV", "Saved the mapping "+profile.Name+".", qa.window)
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Profile", profileSelect),
+		widget.NewFormItem("Delimiter", delimiterSelect),
+		widget.NewFormItem("", headerCheck),
+		widget.NewFormItem("Kana Column", columnEntries["Kana"]),
+		widget.NewFormItem("English Column", columnEntries["Answer"]),
+		widget.NewFormItem("Romaji Column", columnEntries["Romaji"]),
+		widget.NewFormItem("Kanji Column", columnEntries["Kanji"]),
+		widget.NewFormItem("Chapter Column", columnEntries["Chapter"]),
+		widget.NewFormItem("Default Chapter", chapterEntry),
+	)
+	content := container.NewVBox(
+		form,
+		preview,
+		container.NewBorder(nil, nil, nil, saveButton, nameEntry),
+	)
+
+	importDialog := dialog.NewCustomConfirm("Import CSV", "Import", "Cancel", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		profile := current()
+		records, err := readRecords(data, profile)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		chapter := strings.TrimSpace(chapterEntry.Text)
+		imported, skipped := profileQuestions(records, profile, chapter, nextQID(qa.questions))
+		if len(imported) == 0 {
+			dialog.ShowInformation("Import CSV", "No rows could be imported with this mapping.", qa.window)
+			return
+		}
+		qa.serialized(func() {
+			qa.setQuestions(append(qa.questions, imported...))
+			qa.state.reset()
+			qa.deckChanged()
+		})()
+		dialog.ShowInformation("Import CSV",
+			fmt.Sprintf("Imported %d questions (%d rows skipped). Use File › Save Deck As… to keep them.", len(imported), skipped),
+			qa.window)
+	}, qa.window)
+	importDialog.Resize(fyne.NewSize(480, 0))
+	importDialog.Show()
+}
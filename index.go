@@ -0,0 +1,167 @@
+package main
+
+import (
+	"math/rand"
+)
+
+// questionIndex looks up the questions of a deck by QID, chapter, type and tag, and the answers
+// of each chapter, so quizzes do not scan the whole deck. It is built when the deck is loaded
+// and rebuilt whenever it changes, see setQuestions.
+type questionIndex struct {
+	chapters  []string // See deckChapters
+	byQID     map[string]Question
+	byChapter map[string][]Question  // In deck order
+	byType    map[string][]Question  // By questionCategory
+	byTag     map[string][]Question  // By questionTags
+	answers   map[string]*answerPool // Answers of each chapter
+}
+
+// newQuestionIndex indexes a deck
+func newQuestionIndex(questions []Question) *questionIndex {
+	ix := &questionIndex{
+		chapters:  deckChapters(questions),
+		byQID:     deckByQID(questions),
+		byChapter: make(map[string][]Question),
+		byType:    make(map[string][]Question),
+		byTag:     make(map[string][]Question),
+		answers:   make(map[string]*answerPool),
+	}
+	for _, q := range questions {
+		ix.byChapter[q.QChapter] = append(ix.byChapter[q.QChapter], q)
+		ix.byType[questionCategory(q)] = append(ix.byType[questionCategory(q)], q)
+		for _, tag := range questionTags(q) {
+			ix.byTag[tag] = append(ix.byTag[tag], q)
+		}
+	}
+	for chapter, questions := range ix.byChapter {
+		ix.answers[chapter] = newAnswerPool(questions)
+	}
+	return ix
+}
+
+// inChapters returns the questions of the given chapters, chapter by chapter, like getQuestionsByChapters
+func (ix *questionIndex) inChapters(chapters []string) []Question {
+	var questions []Question
+	for _, chapter := range chapters {
+		questions = append(questions, ix.byChapter[chapter]...)
+	}
+	return questions
+}
+
+// studyPool returns the questions of the given chapters quizzes draw from, see studyPool
+func (ix *questionIndex) studyPool(chapters []string, progress *Progress) []Question {
+	var pool []Question
+	for _, q := range ix.inChapters(chapters) {
+		if q.QRequires != "" && len(missingPrerequisites(q, ix.byQID, progress)) > 0 {
+			continue
+		}
+		if !progress.suspended(q.QID) && !progress.retired(q.QID) {
+			pool = append(pool, q)
+		}
+	}
+	return pool
+}
+
+// chapterAnswers returns the answers of a chapter, empty for a chapter not in the deck
+func (ix *questionIndex) chapterAnswers(chapter string) *answerPool {
+	if answers, ok := ix.answers[chapter]; ok {
+		return answers
+	}
+	return newAnswerPool(nil)
+}
+
+// questionTags describes a question with a few words, to export and to search by
+func questionTags(q Question) []string {
+	return []string{"genki", "chapter-" + q.QChapter, questionCategory(q)}
+}
+
+// studyChapters returns the questions of the given chapters quizzes draw from, see studyPool
+func (qa *quizApp) studyChapters(chapters []string) []Question {
+	return qa.index.studyPool(chapters, qa.progress)
+}
+
+// setQuestions replaces the deck and rebuilds its index
+func (qa *quizApp) setQuestions(questions []Question) {
+	qa.questions = questions
+	qa.index = newQuestionIndex(questions)
+	qa.state.answers = nil
+}
+
+// answerPool holds the distinct answers of a pool of questions, each with the first question
+// that has it, so distractors are drawn without going through the pool
+type answerPool struct {
+	answers   []string
+	questions map[string]Question
+	from      []Question // See builtFrom
+}
+
+// newAnswerPool collects the answers of a pool of questions
+func newAnswerPool(questions []Question) *answerPool {
+	p := &answerPool{questions: make(map[string]Question), from: questions}
+	for _, q := range questions {
+		if _, ok := p.questions[q.QAnswer]; !ok {
+			p.questions[q.QAnswer] = q
+			p.answers = append(p.answers, q.QAnswer)
+		}
+	}
+	return p
+}
+
+// has reports whether a question of the pool has an answer
+func (p *answerPool) has(answer string) bool {
+	_, ok := p.questions[answer]
+	return ok
+}
+
+// question returns the first question of the pool with an answer
+func (p *answerPool) question(answer string) (Question, bool) {
+	q, ok := p.questions[answer]
+	return q, ok
+}
+
+// builtFrom reports whether the pool was collected from this very slice. Quizzes replace
+// their questions rather than change them in place, so the pool is then still up to date.
+func (p *answerPool) builtFrom(questions []Question) bool {
+	if len(p.from) != len(questions) {
+		return false
+	}
+	return len(questions) == 0 || &p.from[0] == &questions[0]
+}
+
+// random picks wrong answers, avoiding the correct answer and answers that share a meaning
+// with it or with each other
+func (p *answerPool) random(correctAnswer string, count int) []string {
+	var answers []string
+	tried := map[string]bool{correctAnswer: true}
+	try := func(candidate string) {
+		if tried[candidate] {
+			return
+		}
+		tried[candidate] = true
+		if !answersOverlap(candidate, correctAnswer) && !overlapsAny(candidate, answers) {
+			answers = append(answers, candidate)
+		}
+	}
+
+	// Random picks fill the options quickly from a large pool; a small or crowded one is gone through in random order
+	for attempts := 0; len(p.answers) > 0 && len(answers) < count && attempts < 4*count; attempts++ {
+		try(p.answers[rand.Intn(len(p.answers))])
+	}
+	if len(answers) < count {
+		for _, i := range rand.Perm(len(p.answers)) {
+			if len(answers) >= count {
+				break
+			}
+			try(p.answers[i])
+		}
+	}
+	return answers
+}
+
+// answerPool returns the answers of the quiz's questions, collected again only when they change
+func (s *gameState) answerPool() *answerPool {
+	if s.answers == nil || !s.answers.builtFrom(s.chapterQuestions) {
+		s.answers = newAnswerPool(s.chapterQuestions)
+	}
+	return s.answers
+}
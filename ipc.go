@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/signal"
+	"path/filepath"
+)
+
+// socketFile is the default unix socket of the serve subcommand, in the data folder
+const socketFile = "genkiquiz.sock"
+
+// QuizService is the quiz engine as served over JSON-RPC, for frontends such as a
+// terminal UI or an editor plugin. Methods are called as "Quiz.Start", "Quiz.Question"…
+type QuizService struct {
+	engine *quizEngine
+}
+
+// StartArgs asks for a new session
+type StartArgs struct {
+	Chapters []string `json:"chapters"` // Empty for every chapter
+	Count    int      `json:"count"`    // Zero for defaultSessionSize
+}
+
+// StartReply identifies a new session
+type StartReply struct {
+	Session string `json:"session"`
+	Total   int    `json:"total"`
+}
+
+// SessionArgs names a session
+type SessionArgs struct {
+	Session string `json:"session"`
+}
+
+// AnswerArgs answers the current question of a session
+type AnswerArgs struct {
+	Session string `json:"session"`
+	Answer  string `json:"answer"` // One of the options
+}
+
+// StatsArgs asks for the learner's progress; it has no parameters
+type StatsArgs struct{}
+
+// Start begins a session
+func (s *QuizService) Start(args StartArgs, reply *StartReply) error {
+	id, total, err := s.engine.start(args.Chapters, args.Count)
+	if err != nil {
+		return err
+	}
+	*reply = StartReply{Session: id, Total: total}
+	return nil
+}
+
+// Question returns the current question of a session
+func (s *QuizService) Question(args SessionArgs, reply *SessionQuestion) error {
+	q, err := s.engine.question(args.Session)
+	*reply = q
+	return err
+}
+
+// Answer answers the current question and moves to the next one
+func (s *QuizService) Answer(args AnswerArgs, reply *AnswerResult) error {
+	result, err := s.engine.answer(args.Session, args.Answer)
+	*reply = result
+	return err
+}
+
+// End forgets a session
+func (s *QuizService) End(args SessionArgs, reply *bool) error {
+	s.engine.end(args.Session)
+	*reply = true
+	return nil
+}
+
+// Stats returns the learner's progress over the deck
+func (s *QuizService) Stats(args StatsArgs, reply *ProgressSummary) error {
+	*reply = s.engine.summary()
+	return nil
+}
+
+// runServe implements "GenkiQuiz serve", answering JSON-RPC requests on a unix socket
+// until interrupted. Connections speak JSON-RPC 1.0 as implemented by net/rpc/jsonrpc.
+func runServe(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	portable := flags.Bool("portable", false, "use the files kept beside the executable")
+	profile := flags.String("profile", "", "learner whose progress is used (or set "+envProfile+")")
+	deckPath := flags.String("deck", "", "question sheet to quiz on (or set "+envDeck+")")
+	socket := flags.String("socket", "", "unix socket to listen on (default "+socketFile+" in the data folder)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: GenkiQuiz serve [flags]")
+		fmt.Fprintln(stderr, "Encrypted progress is unlocked with the passphrase in "+envPassphrase+".")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := setupStorage(*portable, *profile); err != nil {
+		fmt.Fprintf(stderr, "Failed to set up data folders: %v\n", err)
+		return 1
+	}
+	deck, err := resolveDeck(*deckPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load quiz questions: %v\n", err)
+		return 1
+	}
+	questions, err := loadQuestionsFromExcel(deck)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load quiz questions: %v\n", err)
+		return 1
+	}
+	settings, err := loadSettings()
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load settings: %v\n", err)
+		return 1
+	}
+	if err := lockProgress(); err != nil {
+		fmt.Fprintf(stderr, "Failed to open progress: %v\n", err)
+		return 1
+	}
+	progress, err := loadProgress(os.Getenv(envPassphrase))
+	if errors.Is(err, errProgressLocked) {
+		fmt.Fprintf(stderr, "Your progress is encrypted; set %s to unlock it\n", envPassphrase)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load progress: %v\n", err)
+		return 1
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("Quiz", &QuizService{engine: newQuizEngine(questions, filepath.Base(deck), settings, progress)}); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	path := *socket
+	if path == "" {
+		path = dataPath(socketFile)
+	}
+	os.Remove(path) // Left behind if a previous server was killed
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to listen on %s: %v\n", path, err)
+		return 1
+	}
+	defer os.Remove(path)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		listener.Close()
+	}()
+
+	fmt.Fprintf(stdout, "Listening on %s\n", path)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return 0
+			}
+			fmt.Fprintf(stderr, "Failed to accept connection: %v\n", err)
+			return 1
+		}
+		go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// romajiKana maps romaji syllables to hiragana. Both Hepburn and Nihon-shiki
+// spellings are accepted, as well as the x/l prefixes IMEs use for small kana.
+var romajiKana = map[string]string{
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"sa": "さ", "shi": "し", "si": "し", "su": "す", "se": "せ", "so": "そ",
+	"ta": "た", "chi": "ち", "ti": "ち", "tsu": "つ", "tu": "つ", "te": "て", "to": "と",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"ha": "は", "hi": "ひ", "fu": "ふ", "hu": "ふ", "he": "へ", "ho": "ほ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"wa": "わ", "wo": "を",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"za": "ざ", "ji": "じ", "zi": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"da": "だ", "di": "ぢ", "du": "づ", "de": "で", "do": "ど",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"sha": "しゃ", "shu": "しゅ", "sho": "しょ", "sya": "しゃ", "syu": "しゅ", "syo": "しょ",
+	"cha": "ちゃ", "chu": "ちゅ", "cho": "ちょ", "tya": "ちゃ", "tyu": "ちゅ", "tyo": "ちょ",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"ja": "じゃ", "ju": "じゅ", "jo": "じょ", "zya": "じゃ", "zyu": "じゅ", "zyo": "じょ",
+	"jya": "じゃ", "jyu": "じゅ", "jyo": "じょ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+
+	// Sounds mostly found in loanwords
+	"fa": "ふぁ", "fi": "ふぃ", "fe": "ふぇ", "fo": "ふぉ",
+	"she": "しぇ", "che": "ちぇ", "je": "じぇ",
+	"thi": "てぃ", "dhi": "でぃ", "wi": "うぃ", "we": "うぇ",
+
+	// Small kana
+	"xa": "ぁ", "xi": "ぃ", "xu": "ぅ", "xe": "ぇ", "xo": "ぉ",
+	"la": "ぁ", "li": "ぃ", "lu": "ぅ", "le": "ぇ", "lo": "ぉ",
+	"xya": "ゃ", "xyu": "ゅ", "xyo": "ょ", "lya": "ゃ", "lyu": "ゅ", "lyo": "ょ",
+	"xtu": "っ", "ltu": "っ",
+}
+
+// nonHepburn are the alternative spellings in romajiKana that romanization should not use
+var nonHepburn = map[string]bool{
+	"si": true, "ti": true, "tu": true, "hu": true, "zi": true, "di": true, "du": true,
+	"sya": true, "syu": true, "syo": true, "tya": true, "tyu": true, "tyo": true,
+	"zya": true, "zyu": true, "zyo": true, "jya": true, "jyu": true, "jyo": true,
+	"la": true, "li": true, "lu": true, "le": true, "lo": true,
+	"lya": true, "lyu": true, "lyo": true, "ltu": true,
+}
+
+// kanaRomaji is the Hepburn spelling of each kana syllable, built from romajiKana
+var kanaRomaji = make(map[string]string)
+
+func init() {
+	for romaji, kana := range romajiKana {
+		if !nonHepburn[romaji] {
+			kanaRomaji[kana] = romaji
+		}
+	}
+	kanaRomaji["ぢ"] = "ji"
+	kanaRomaji["づ"] = "zu"
+}
+
+// isRomajiVowel reports whether a byte starts a syllable that n can join
+func isRomajiVowel(c byte) bool {
+	return strings.IndexByte("aiueoy", c) >= 0
+}
+
+// romajiToKana converts romaji to hiragana the way an IME does: doubled consonants
+// become っ, "nn" or "n'" become ん and "-" becomes the long-vowel mark ー.
+// Anything that is not romaji, including kana, is kept as it is.
+func romajiToKana(text string) string {
+	s := strings.ToLower(text)
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		next := byte(0)
+		if i+1 < len(s) {
+			next = s[i+1]
+		}
+		switch {
+		case c == 'n' && next == '\'':
+			out.WriteString("ん")
+			i += 2
+			continue
+		case c == 'n' && next == 'n':
+			out.WriteString("ん")
+			// "konnichiwa": the second n starts the next syllable
+			if i+2 < len(s) && isRomajiVowel(s[i+2]) {
+				i++
+			} else {
+				i += 2
+			}
+			continue
+		case c == 'n' && !isRomajiVowel(next):
+			out.WriteString("ん")
+			i++
+			continue
+		case c == '-':
+			out.WriteString("ー")
+			i++
+			continue
+		case c >= 'a' && c <= 'z' && !isRomajiVowel(c) && (next == c || c == 't' && next == 'c'):
+			out.WriteString("っ")
+			i++
+			continue
+		}
+
+		matched := false
+		for length := 3; length >= 1; length-- {
+			if i+length > len(s) {
+				continue
+			}
+			if kana, ok := romajiKana[s[i:i+length]]; ok {
+				out.WriteString(kana)
+				i += length
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
+
+// toHiragana converts katakana to hiragana, leaving other characters alone
+func toHiragana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 'ァ' + 'ぁ'
+		}
+		return r
+	}, text)
+}
+
+// toKatakana converts hiragana to katakana, leaving other characters alone
+func toKatakana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r - 'ぁ' + 'ァ'
+		}
+		return r
+	}, text)
+}
+
+// isKatakana reports whether text is written in katakana
+func isKatakana(text string) bool {
+	for _, r := range text {
+		if unicode.In(r, unicode.Katakana) && r != 'ー' {
+			return true
+		}
+	}
+	return false
+}
+
+// isKana reports whether text consists of kana only
+func isKana(text string) bool {
+	for _, r := range text {
+		if !unicode.In(r, unicode.Hiragana, unicode.Katakana) && r != 'ー' {
+			return false
+		}
+	}
+	return text != ""
+}
+
+// smallKana are the kana that join the previous one into a single mora
+const smallKana = "ゃゅょぁぃぅぇぉゎャュョァィゥェォヮ"
+
+// splitMora splits kana into morae, keeping small ゃ/ゅ/ょ with the kana before them
+func splitMora(text string) []string {
+	var morae []string
+	for _, r := range text {
+		if strings.ContainsRune(smallKana, r) && len(morae) > 0 {
+			morae[len(morae)-1] += string(r)
+			continue
+		}
+		morae = append(morae, string(r))
+	}
+	return morae
+}
+
+// moraVowel returns the vowel a mora ends in, or 0 for ん, っ and ー
+func moraVowel(mora string) byte {
+	romaji := kanaRomaji[toHiragana(mora)]
+	if romaji == "" || romaji[0] == 'x' {
+		return 0
+	}
+	return romaji[len(romaji)-1]
+}
+
+// toRomaji spells kana in Hepburn romaji, the reverse of romajiToKana
+func toRomaji(kana string) string {
+	return romanize(kana, romajiDoubled)
+}
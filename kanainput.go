@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// Typed answers come either as ASCII romaji or as kana committed by a Japanese IME.
+// Fyne does not expose the IME's composition (pre-edit) text, only the committed
+// characters, so kana input is recognised from what arrives in the entry.
+
+// foldInputWidth turns the full-width letters and spaces a Japanese keyboard produces
+// into ASCII, and half-width katakana into normal katakana (joining ｶﾞ into ガ)
+func foldInputWidth(input string) string {
+	return norm.NFC.String(width.Fold.String(input))
+}
+
+// hasKanaInput reports whether text contains kana, i.e. it was typed with an IME
+func hasKanaInput(text string) bool {
+	for _, r := range text {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana) {
+			return true
+		}
+	}
+	return false
+}
+
+// typedKana converts a typed answer to hiragana, accepting romaji as well as IME kana
+func typedKana(input string) string {
+	return toHiragana(romajiToKana(strings.TrimSpace(foldInputWidth(input))))
+}
+
+// inputPreview describes how a typed answer will be read: romaji is shown
+// converted to kana, kana from an IME is acknowledged as it is
+func inputPreview(input string) string {
+	text := strings.TrimSpace(foldInputWidth(input))
+	switch {
+	case text == "":
+		return ""
+	case hasKanaInput(text) && !strings.ContainsFunc(text, func(r rune) bool { return r < unicode.MaxASCII && unicode.IsLetter(r) }):
+		return "Kana input"
+	}
+	return "→ " + romajiToKana(text)
+}
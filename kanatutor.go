@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// kanaTutorRounds is the number of kana flashed in one typing session
+const kanaTutorRounds = 20
+
+// kanaTutorSlowest is how many of the slowest kana the results list
+const kanaTutorSlowest = 10
+
+// kanaTypingStats records the typing speed and accuracy for one kana
+type kanaTypingStats struct {
+	Attempts  int   `json:"attempts"`
+	Correct   int   `json:"correct"`
+	TotalTime int64 `json:"total_ms"` // Summed time of correct answers, in milliseconds
+}
+
+// averageTime returns the mean time of the correct answers
+func (s *kanaTypingStats) averageTime() time.Duration {
+	if s == nil || s.Correct == 0 {
+		return 0
+	}
+	return time.Duration(s.TotalTime/int64(s.Correct)) * time.Millisecond
+}
+
+// recordKanaTyping updates the typing statistics of a kana
+func (p *Progress) recordKanaTyping(kana string, correct bool, elapsed time.Duration) {
+	if p.KanaTyping == nil {
+		p.KanaTyping = make(map[string]*kanaTypingStats)
+	}
+	s := p.KanaTyping[kana]
+	if s == nil {
+		s = &kanaTypingStats{}
+		p.KanaTyping[kana] = s
+	}
+	s.Attempts++
+	if correct {
+		s.Correct++
+		s.TotalTime += elapsed.Milliseconds()
+	}
+	p.earnXP(answerXP(correct), time.Now())
+}
+
+// basicKana lists the single-mora hiragana, without small kana and loanword sounds
+func basicKana() []string {
+	var kana []string
+	for k, romaji := range kanaRomaji {
+		if romaji[0] != 'x' && len([]rune(k)) == 1 && k != "ぢ" && k != "づ" {
+			kana = append(kana, k)
+		}
+	}
+	kana = append(kana, "ん")
+	sort.Strings(kana)
+	return kana
+}
+
+// kanaTutorWeight favours kana that were typed slowly, wrongly or not yet at all
+func kanaTutorWeight(s *kanaTypingStats) float64 {
+	if s == nil || s.Attempts == 0 {
+		return 3
+	}
+	errorRate := 1 - float64(s.Correct)/float64(s.Attempts)
+	return 1 + 4*errorRate + s.averageTime().Seconds()
+}
+
+// kanaTutor flashes kana and times how quickly their romaji is typed
+type kanaTutor struct {
+	qa       *quizApp
+	pool     []string
+	katakana bool
+	round    int
+	correct  int
+	total    time.Duration // Summed time of correct answers this session
+	ended    bool
+}
+
+// pick chooses a kana, or now and then a short sequence, weighted towards weak kana
+func (t *kanaTutor) pick() string {
+	weights := make([]float64, len(t.pool))
+	sum := 0.0
+	for i, k := range t.pool {
+		weights[i] = kanaTutorWeight(t.qa.progress.KanaTyping[k])
+		sum += weights[i]
+	}
+	choose := func() string {
+		r := rand.Float64() * sum
+		for i, w := range weights {
+			if r < w {
+				return t.pool[i]
+			}
+			r -= w
+		}
+		return t.pool[len(t.pool)-1]
+	}
+	kana := choose()
+	if rand.Intn(4) == 0 {
+		kana += choose()
+	}
+	return kana
+}
+
+// startKanaTutor starts a typing session with hiragana, katakana or both
+func (qa *quizApp) startKanaTutor(script string) {
+	tutor := &kanaTutor{qa: qa, pool: basicKana()}
+	switch script {
+	case "Katakana":
+		tutor.katakana = true
+	case "Both":
+		for _, k := range tutor.pool {
+			tutor.pool = append(tutor.pool, toKatakana(k))
+		}
+	}
+	if tutor.katakana {
+		for i, k := range tutor.pool {
+			tutor.pool[i] = toKatakana(k)
+		}
+	}
+	tutor.showRound()
+}
+
+// showRound flashes the next kana and waits for its romaji
+func (t *kanaTutor) showRound() {
+	if t.ended {
+		return
+	}
+	if t.round >= kanaTutorRounds {
+		t.showResults()
+		return
+	}
+	target := t.pick()
+	answer := toHiragana(target)
+
+	kanaText := canvas.NewText(target, theme.ForegroundColor())
+	t.qa.japaneseText(kanaText, projectorQuestionSize)
+	kanaText.TextStyle = fyne.TextStyle{Bold: true}
+	kanaText.Alignment = fyne.TextAlignCenter
+	feedback := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{})
+
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("Type the romaji")
+	shownAt := time.Now()
+	done := false
+	finish := func(correct bool) {
+		done = true
+		elapsed := time.Since(shownAt)
+		if len([]rune(target)) == 1 {
+			t.qa.progress.recordKanaTyping(toHiragana(target), correct, elapsed)
+		}
+		t.round++
+		delay := 300 * time.Millisecond
+		if correct {
+			t.correct++
+			t.total += elapsed
+			feedback.SetText(fmt.Sprintf("✅ %.2fs", elapsed.Seconds()))
+		} else {
+			feedback.SetText(fmt.Sprintf("❌ %s is %s", target, toRomaji(answer)))
+			delay = 1500 * time.Millisecond
+		}
+		t.qa.afterFunc(delay, t.showRound)
+	}
+	// A correct answer counts as soon as it is typed; Enter gives up on a wrong one
+	entry.OnChanged = func(text string) {
+		if !done && strings.TrimSpace(text) != "" && typedKana(text) == answer {
+			finish(true)
+		}
+	}
+	entry.OnSubmitted = func(text string) {
+		if !done && strings.TrimSpace(text) != "" {
+			finish(typedKana(text) == answer)
+		}
+	}
+
+	t.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Kana Typing — %d/%d — Correct: %d", t.round+1, kanaTutorRounds, t.correct),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		widget.NewButton("End Session", t.qa.serialized(func() {
+			t.ended = true
+			t.showResults()
+		})),
+		nil, nil,
+		container.NewVBox(layoutSpacer(), kanaText, entry, feedback),
+	))
+	t.qa.window.Canvas().Focus(entry)
+}
+
+// showResults shows the session's speed and accuracy and the slowest kana overall
+func (t *kanaTutor) showResults() {
+	t.ended = true
+	if err := t.qa.progress.save(); err != nil {
+		log.Printf("Failed to save progress: %v", err)
+	}
+
+	summary := fmt.Sprintf("Correct: %d/%d", t.correct, t.round)
+	if t.correct > 0 {
+		summary += fmt.Sprintf(", average %.2fs", (t.total / time.Duration(t.correct)).Seconds())
+	}
+
+	var typed []string
+	for k, s := range t.qa.progress.KanaTyping {
+		if s.Attempts > 0 {
+			typed = append(typed, k)
+		}
+	}
+	stats := t.qa.progress.KanaTyping
+	sort.Slice(typed, func(i, j int) bool {
+		return kanaTutorWeight(stats[typed[i]]) > kanaTutorWeight(stats[typed[j]])
+	})
+	slowest := container.NewGridWithColumns(3,
+		widget.NewLabelWithStyle("Kana", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Accuracy", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Average", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+	)
+	for i, k := range typed {
+		if i == kanaTutorSlowest {
+			break
+		}
+		s := stats[k]
+		slowest.Add(widget.NewLabel(fmt.Sprintf("%s  %s", k, toKatakana(k))))
+		slowest.Add(widget.NewLabel(fmt.Sprintf("%d/%d", s.Correct, s.Attempts)))
+		slowest.Add(widget.NewLabel(fmt.Sprintf("%.2fs", s.averageTime().Seconds())))
+	}
+
+	t.qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Kana Typing Results", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(summary),
+		settingsHeading("Kana to Practise"),
+		slowest,
+		widget.NewButton("Back to Kana Typing", t.qa.serialized(func() {
+			t.qa.showKanaTutor()
+		})),
+	)))
+}
+
+// showKanaTutor lets the learner choose which script to practise typing
+func (qa *quizApp) showKanaTutor() {
+	script := widget.NewRadioGroup([]string{"Hiragana", "Katakana", "Both"}, nil)
+	script.SetSelected("Hiragana")
+	startButton := widget.NewButton("Start", qa.serialized(func() {
+		qa.startKanaTutor(script.Selected)
+	}))
+	startButton.Importance = widget.HighImportance
+
+	qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Kana Typing Tutor", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("Type the romaji of each kana as fast as you can. %d kana per session.", kanaTutorRounds)),
+		script,
+		startButton,
+		widget.NewButton("Back to Chapter Selection", qa.serialized(func() {
+			qa.showChapterSelection()
+		})),
+	)))
+}
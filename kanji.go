@@ -0,0 +1,78 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+)
+
+//go:embed data/kanji_components.json
+var kanjiComponentsJSON []byte
+
+// kanjiComponentData maps kanji to their components, and components to their meanings
+type kanjiComponentData struct {
+	Kanji      map[string][]string `json:"kanji"`
+	Components map[string]string   `json:"components"`
+}
+
+// kanjiComponents is the bundled component breakdown, parsed on first use
+var kanjiComponents *kanjiComponentData
+
+// loadKanjiComponents parses the bundled component data
+func loadKanjiComponents() *kanjiComponentData {
+	if kanjiComponents == nil {
+		kanjiComponents = &kanjiComponentData{}
+		if err := json.Unmarshal(kanjiComponentsJSON, kanjiComponents); err != nil {
+			log.Printf("Invalid bundled kanji data: %v", err)
+		}
+	}
+	return kanjiComponents
+}
+
+// containsKanji reports whether text has any kanji in it
+func containsKanji(text string) bool {
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// questionKanji returns the kanji spelling of a question, if it has one
+func questionKanji(q Question) string {
+	if q.QKanji != "" {
+		return q.QKanji
+	}
+	if containsKanji(q.QHirakata) {
+		return q.QHirakata
+	}
+	return ""
+}
+
+// kanjiBreakdown describes the components of every known kanji in text,
+// e.g. "学 = ⺍ sparkle + 冖 cover + 子 child"
+func kanjiBreakdown(text string) []string {
+	data := loadKanjiComponents()
+	var lines []string
+	seen := make(map[rune]bool)
+	for _, r := range text {
+		if !unicode.Is(unicode.Han, r) || seen[r] {
+			continue
+		}
+		seen[r] = true
+		components, ok := data.Kanji[string(r)]
+		if !ok {
+			continue
+		}
+		parts := make([]string, len(components))
+		for i, c := range components {
+			parts[i] = strings.TrimSpace(c + " " + data.Components[c])
+		}
+		lines = append(lines, fmt.Sprintf("%c = %s", r, strings.Join(parts, " + ")))
+	}
+	return lines
+}
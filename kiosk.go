@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// kioskSettings configures the locked-down mode for shared classroom computers
+type kioskSettings struct {
+	PIN      string   `json:"pin"`      // Hash of the PIN required to exit the app in kiosk mode, see hashPIN
+	Chapters []string `json:"chapters"` // Chapters offered in kiosk mode, empty for all
+}
+
+// offeredChapters returns the chapters shown for selection, limited in kiosk mode.
+// With progression on, some of them may still be locked.
+func (qa *quizApp) offeredChapters() []string {
+	if qa.kiosk && len(qa.settings.Kiosk.Chapters) > 0 {
+		return qa.settings.Kiosk.Chapters
+	}
+	return qa.index.chapters
+}
+
+// availableChapters returns the chapters that can be studied: the offered ones that
+// progression has unlocked. Every mode draws its questions from these.
+func (qa *quizApp) availableChapters() []string {
+	var chapters []string
+	for _, chapter := range qa.offeredChapters() {
+		if qa.chapterUnlocked(chapter) {
+			chapters = append(chapters, chapter)
+		}
+	}
+	return chapters
+}
+
+// enableKiosk locks the window: full screen, and closing requires the kiosk PIN
+func (qa *quizApp) enableKiosk() error {
+	if qa.settings.Kiosk.PIN == "" {
+		return errors.New("kiosk mode requires a PIN; set one in Settings first")
+	}
+	qa.kiosk = true
+	qa.window.SetFullScreen(true)
+	qa.window.SetCloseIntercept(qa.confirmKioskExit)
+	return nil
+}
+
+// confirmKioskExit asks for the kiosk PIN and closes the app if it matches
+func (qa *quizApp) confirmKioskExit() {
+	pinEntry := widget.NewPasswordEntry()
+	dialog.ShowForm("Exit Kiosk Mode", "Exit", "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("PIN", pinEntry)},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			if !pinMatches(qa.settings.Kiosk.PIN, pinEntry.Text) {
+				dialog.ShowInformation("Exit Kiosk Mode", "Incorrect PIN.", qa.window)
+				return
+			}
+			qa.app.Quit()
+		}, qa.window)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// listeningRounds is the number of words played in a listening drill
+const listeningRounds = 10
+
+// lengthMora are the kana that make a sound longer without changing it:
+// the small tsu of geminate consonants, the long-vowel mark and vowel extensions
+const lengthMora = "っッーあいうえおアイウエオ"
+
+// lengthContrast reports whether two words differ only by one lengthening mora,
+// like おばさん/おばあさん or きって/きて
+func lengthContrast(a, b string) bool {
+	long, short := []rune(a), []rune(b)
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	if len(long) != len(short)+1 {
+		return false
+	}
+	for i, r := range long {
+		if string(long[:i])+string(long[i+1:]) == string(short) {
+			return strings.ContainsRune(lengthMora, r)
+		}
+	}
+	return false
+}
+
+// minimalPairs returns the curated and deck word pairs that differ only in length.
+// The curated ones do not need to be in the deck, as only their sound is used.
+func minimalPairs(questions []Question) [][2]string {
+	var pairs [][2]string
+	seen := make(map[[2]string]bool)
+	add := func(a, b string) {
+		if a > b {
+			a, b = b, a
+		}
+		if !seen[[2]string{a, b}] && lengthContrast(a, b) {
+			seen[[2]string{a, b}] = true
+			pairs = append(pairs, [2]string{a, b})
+		}
+	}
+	for _, pair := range curatedPairs() {
+		add(pair[0], pair[1])
+	}
+	for _, pair := range lookAlikePairs(questions) {
+		add(pair[0].QHirakata, pair[1].QHirakata)
+	}
+	return pairs
+}
+
+// listeningStats adds up the words of all listening drills
+type listeningStats struct {
+	Words   int `json:"words"`
+	Correct int `json:"correct"`
+	Replays int `json:"replays"` // Times a word was played again
+}
+
+// recordListening adds a word of a listening drill to the statistics
+func (p *Progress) recordListening(correct bool, replays int) {
+	if p.Listening == nil {
+		p.Listening = &listeningStats{}
+	}
+	p.Listening.Words++
+	if correct {
+		p.Listening.Correct++
+	}
+	p.Listening.Replays += replays
+	p.earnXP(answerXP(correct), time.Now())
+}
+
+// listeningDrill plays one word of a minimal pair and asks which one it was
+type listeningDrill struct {
+	qa      *quizApp
+	pairs   [][2]string
+	round   int
+	score   int
+	spoken  string // Word played in the current round
+	choices [2]string
+	ended   bool // Set when the drill is left, so a pending round does not show
+}
+
+// startListeningDrill starts a minimal-pair listening drill
+func (qa *quizApp) startListeningDrill() {
+	pairs := minimalPairs(qa.studyChapters(qa.availableChapters()))
+	if !speechAvailable() {
+		dialog.ShowError(errSpeechUnsupported, qa.window)
+		return
+	}
+	if len(pairs) == 0 {
+		return
+	}
+	drill := &listeningDrill{qa: qa, pairs: pairs}
+	drill.showRound()
+}
+
+// showRound plays a word and offers both words of its pair
+func (d *listeningDrill) showRound() {
+	if d.ended {
+		return
+	}
+	if d.round >= listeningRounds {
+		d.showResults()
+		return
+	}
+	pair := d.pairs[rand.Intn(len(d.pairs))]
+	d.choices = pair
+	if rand.Intn(2) == 1 {
+		d.choices[0], d.choices[1] = d.choices[1], d.choices[0]
+	}
+	d.spoken = pair[rand.Intn(2)]
+
+	answered := false
+	replays := 0
+	limit := d.qa.settings.Audio.MaxReplays
+	replayButton := widget.NewButton("", nil)
+	updateReplay := func() {
+		if limit == 0 {
+			replayButton.SetText("🔊 Play Again")
+			return
+		}
+		replayButton.SetText(fmt.Sprintf("🔊 Play Again (%d left)", limit-replays))
+		if replays >= limit {
+			replayButton.Disable()
+		}
+	}
+	replayButton.OnTapped = func() {
+		if limit > 0 && replays >= limit {
+			return
+		}
+		replays++
+		updateReplay()
+		d.qa.say(d.spoken)
+	}
+	updateReplay()
+
+	buttons := make([]*widget.Button, len(d.choices))
+	options := container.NewGridWithColumns(2)
+	for i, choice := range d.choices {
+		choice := choice
+		buttons[i] = widget.NewButton(choice, d.qa.serialized(func() {
+			if answered {
+				return
+			}
+			answered = true
+			for j, button := range buttons {
+				if d.choices[j] == d.spoken {
+					button.SetText("✅ " + button.Text)
+				} else if d.choices[j] == choice {
+					button.SetText("❌ " + button.Text)
+				}
+			}
+			if choice == d.spoken {
+				d.score++
+			}
+			d.qa.progress.recordListening(choice == d.spoken, replays)
+			if err := d.qa.progress.save(); err != nil {
+				log.Printf("Failed to save progress: %v", err)
+			}
+			d.qa.progressChanged()
+			d.round++
+			d.qa.afterFunc(1500*time.Millisecond, d.showRound)
+		}))
+		options.Add(buttons[i])
+	}
+
+	prompt := canvas.NewText("Which word did you hear?", theme.ForegroundColor())
+	prompt.TextSize = 20
+	prompt.Alignment = fyne.TextAlignCenter
+
+	d.qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Listening Drill — %d/%d — Score: %d", d.round+1, listeningRounds, d.score),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		prompt,
+		container.NewHBox(
+			replayButton,
+			widget.NewLabel("Speed"),
+			d.qa.speedSelect(),
+		),
+		options,
+		widget.NewButton("End Drill", d.qa.serialized(func() {
+			d.ended = true
+			d.qa.showConfusablePairs()
+		})),
+	)))
+	d.qa.say(d.spoken)
+}
+
+// showResults shows how many words were told apart correctly
+func (d *listeningDrill) showResults() {
+	d.qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Listening Drill Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("You heard %d of %d words correctly.", d.score, listeningRounds)),
+		widget.NewButton("Try Again", d.qa.serialized(func() {
+			d.qa.startListeningDrill()
+		})),
+		widget.NewButton("Back to Confusable Pairs", d.qa.serialized(func() {
+			d.qa.showConfusablePairs()
+		})),
+	)))
+}
@@ -0,0 +1,813 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/net/websocket"
+)
+
+// livePage is the page participants open on their phones
+//
+//go:embed data/live.html
+var livePage []byte
+
+// livePort is the port the live quiz server tries first
+const livePort = 8765
+
+// liveRounds is the number of questions in a live quiz
+const liveRounds = 15
+
+// liveRoundTime is how long participants have to answer each question
+const liveRoundTime = 20 * time.Second
+
+// liveMaxPoints is awarded for an instant correct answer; the last second earns half of it
+const liveMaxPoints = 1000
+
+// liveMaxNameLength keeps participant names readable on the leaderboard
+const liveMaxNameLength = 20
+
+// liveSendTimeout is how long an update may take to reach a participant before they are dropped
+const liveSendTimeout = 5 * time.Second
+
+// liveMessage is a message from a participant
+type liveMessage struct {
+	Type   string `json:"type"` // "join" or "answer"
+	Name   string `json:"name"`
+	Option int    `json:"option"`
+}
+
+// liveUpdate is a message to a participant
+type liveUpdate struct {
+	Type    string   `json:"type"` // "welcome", "question", "result" or "end"
+	Name    string   `json:"name,omitempty"`
+	Number  int      `json:"number,omitempty"`
+	Total   int      `json:"total,omitempty"`
+	Kana    string   `json:"kana,omitempty"`
+	Options []string `json:"options,omitempty"`
+
+	Correct       bool   `json:"correct"`
+	CorrectOption int    `json:"correct_option"`
+	Answer        string `json:"answer,omitempty"`
+	Points        int    `json:"points"`
+	Score         int    `json:"score"`
+	Rank          int    `json:"rank"`
+	Players       int    `json:"players"`
+}
+
+// livePlayer is a participant of a live quiz
+type livePlayer struct {
+	name   string
+	conn   *websocket.Conn // Nil once the participant disconnected
+	score  int
+	answer int // Option picked this round, -1 if none
+	points int // Points earned this round
+}
+
+// liveOutgoing is an update for a participant, sent once h.mu is released
+type liveOutgoing struct {
+	player *livePlayer
+	conn   *websocket.Conn
+	update liveUpdate
+}
+
+// liveHost runs a live quiz: it serves the participant page and shows the
+// question and leaderboard on the host's screen
+type liveHost struct {
+	qa        *quizApp
+	questions []Question
+	answers   *answerPool // Answers the distractors are drawn from
+	server    *http.Server
+	joinURL   string
+	closeOnce sync.Once
+	screen    int // Counts the screens of the live quiz shown, see showScreen
+
+	mu       sync.Mutex
+	players  []*livePlayer
+	index    int
+	options  []string
+	shownAt  time.Time
+	open     bool          // Answers are accepted for the current question
+	stop     chan struct{} // Closed to stop the countdown of the current question
+	onChange func()        // Refreshes the host screen after players join or answer
+
+	chat       chatBackend    // Viewers' chat answering along, nil if not connected
+	chatStop   chan struct{}  // Closed to disconnect from the chat
+	chatStatus string         // Connection state shown to the host
+	votes      map[string]int // Chat answers to the current question: user → option
+}
+
+// showLiveHost starts a live quiz on the selected chapter and shows the lobby
+func (qa *quizApp) showLiveHost() {
+	pool := qa.state.chapterQuestions
+	if len(pool) == 0 {
+		return
+	}
+	questions := make([]Question, len(pool))
+	copy(questions, pool)
+	rand.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+	if len(questions) > liveRounds {
+		questions = questions[:liveRounds]
+	}
+
+	host := &liveHost{qa: qa, questions: questions, answers: newAnswerPool(pool)}
+	if err := host.listen(); err != nil {
+		dialog.ShowError(fmt.Errorf("could not start the live quiz server: %w", err), qa.window)
+		return
+	}
+	host.showLobby()
+}
+
+// listen starts the web server on livePort, or any free port if it is taken
+func (h *liveHost) listen() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", livePort))
+	if err != nil {
+		if listener, err = net.Listen("tcp", ":0"); err != nil {
+			return err
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(livePage)
+	})
+	mux.Handle("/ws", websocket.Handler(h.serveParticipant))
+	h.server = &http.Server{Handler: mux}
+	h.joinURL = fmt.Sprintf("http://%s:%d/", localAddress(), listener.Addr().(*net.TCPAddr).Port)
+
+	go func() {
+		if err := h.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("Live quiz server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// localAddress returns the LAN address participants can reach this computer on
+func localAddress() string {
+	// No packets are sent; this only picks the interface of the default route
+	if conn, err := net.Dial("udp", "192.0.2.1:80"); err == nil {
+		defer conn.Close()
+		return conn.LocalAddr().(*net.UDPAddr).IP.String()
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err == nil {
+		for _, addr := range addrs {
+			if ip, ok := addr.(*net.IPNet); ok && !ip.IP.IsLoopback() && ip.IP.To4() != nil {
+				return ip.IP.String()
+			}
+		}
+	}
+	return "localhost"
+}
+
+// serveParticipant handles one participant's connection until it closes
+func (h *liveHost) serveParticipant(conn *websocket.Conn) {
+	var player *livePlayer
+	defer func() {
+		h.mu.Lock()
+		if player != nil {
+			player.conn = nil
+		}
+		h.mu.Unlock()
+		h.changed()
+	}()
+
+	for {
+		var msg liveMessage
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			return
+		}
+		switch {
+		case msg.Type == "join" && player == nil:
+			player = h.join(conn, msg.Name)
+		case msg.Type == "answer" && player != nil:
+			h.answer(player, msg.Option)
+		}
+	}
+}
+
+// join adds a participant, making the name unique, and catches them up on the current question
+func (h *liveHost) join(conn *websocket.Conn, name string) *livePlayer {
+	name = strings.TrimSpace(name)
+	if runes := []rune(name); len(runes) > liveMaxNameLength {
+		name = string(runes[:liveMaxNameLength])
+	}
+	if name == "" {
+		name = "Player"
+	}
+
+	h.mu.Lock()
+	unique := name
+	for n := 2; h.playerNamed(unique) != nil; n++ {
+		unique = fmt.Sprintf("%s %d", name, n)
+	}
+	player := &livePlayer{name: unique, conn: conn, answer: -1}
+	h.players = append(h.players, player)
+	out := h.queue(nil, player, liveUpdate{Type: "welcome", Name: unique})
+	if h.open {
+		out = h.queue(out, player, h.questionUpdate())
+	}
+	h.mu.Unlock()
+
+	h.deliver(out)
+	h.changed()
+	return player
+}
+
+// playerNamed returns the participant with a name, if any. h.mu must be held.
+func (h *liveHost) playerNamed(name string) *livePlayer {
+	for _, player := range h.players {
+		if player.name == name {
+			return player
+		}
+	}
+	return nil
+}
+
+// queue adds an update for a connected participant to out, to be sent by deliver. h.mu must be held.
+func (h *liveHost) queue(out []liveOutgoing, player *livePlayer, update liveUpdate) []liveOutgoing {
+	if player.conn == nil {
+		return out
+	}
+	return append(out, liveOutgoing{player: player, conn: player.conn, update: update})
+}
+
+// deliver sends queued updates without holding h.mu, so a slow participant holds up
+// nobody else. A participant whose update fails or times out is disconnected.
+func (h *liveHost) deliver(out []liveOutgoing) {
+	for _, o := range out {
+		o.conn.SetWriteDeadline(time.Now().Add(liveSendTimeout))
+		if err := websocket.JSON.Send(o.conn, o.update); err != nil {
+			o.conn.Close()
+			h.mu.Lock()
+			if o.player.conn == o.conn {
+				o.player.conn = nil
+			}
+			h.mu.Unlock()
+		}
+	}
+}
+
+// questionUpdate describes the current question. h.mu must be held.
+func (h *liveHost) questionUpdate() liveUpdate {
+	return liveUpdate{
+		Type:    "question",
+		Number:  h.index + 1,
+		Total:   len(h.questions),
+		Kana:    h.questions[h.index].QHirakata,
+		Options: h.options,
+	}
+}
+
+// answer records a participant's answer; faster correct answers earn more points
+func (h *liveHost) answer(player *livePlayer, option int) {
+	h.mu.Lock()
+	if !h.open || player.answer >= 0 || option < 0 || option >= len(h.options) {
+		h.mu.Unlock()
+		return
+	}
+	player.answer = option
+	if h.options[option] == h.questions[h.index].QAnswer {
+		remaining := liveRoundTime - time.Since(h.shownAt)
+		if remaining < 0 {
+			remaining = 0
+		}
+		player.points = liveMaxPoints/2 + int(float64(liveMaxPoints/2)*float64(remaining)/float64(liveRoundTime))
+	}
+	allAnswered := true
+	for _, p := range h.players {
+		if p.conn != nil && p.answer < 0 {
+			allAnswered = false
+		}
+	}
+	h.mu.Unlock()
+
+	h.changed()
+	if allAnswered {
+		h.reveal()
+	}
+}
+
+// changed refreshes the host screen in the background, holding the UI lock. It is called
+// from the connection and countdown goroutines as well as the host's buttons, which hold
+// the lock already, so it must not be called holding h.mu.
+func (h *liveHost) changed() {
+	go h.qa.serialized(func() {
+		h.mu.Lock()
+		onChange := h.onChange
+		h.mu.Unlock()
+		if onChange != nil {
+			onChange()
+		}
+	})()
+}
+
+// ranking returns the participants by score, best first. h.mu must be held.
+func (h *liveHost) ranking() []*livePlayer {
+	ranked := append([]*livePlayer(nil), h.players...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+	return ranked
+}
+
+// rankOf returns a participant's place, sharing places on equal scores. h.mu must be held.
+func (h *liveHost) rankOf(player *livePlayer) int {
+	rank := 1
+	for _, p := range h.players {
+		if p.score > player.score {
+			rank++
+		}
+	}
+	return rank
+}
+
+// leaderboardText lists the participants by score
+func (h *liveHost) leaderboardText() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.players) == 0 {
+		return "Nobody has joined yet."
+	}
+	var b strings.Builder
+	for _, player := range h.ranking() {
+		status := ""
+		if player.conn == nil {
+			status = " (left)"
+		} else if h.open && player.answer >= 0 {
+			status = " ✔"
+		}
+		fmt.Fprintf(&b, "%d. %s — %d%s\n", h.rankOf(player), player.name, player.score, status)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// answeredText counts the answers to the current question
+func (h *liveHost) answeredText() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	answered, connected := 0, 0
+	for _, player := range h.players {
+		if player.conn != nil {
+			connected++
+			if player.answer >= 0 {
+				answered++
+			}
+		}
+	}
+	return fmt.Sprintf("%d/%d answered", answered, connected)
+}
+
+// stopCountdown cancels the running countdown, if any. h.mu must be held.
+func (h *liveHost) stopCountdown() {
+	if h.stop != nil {
+		close(h.stop)
+		h.stop = nil
+	}
+}
+
+// close stops the countdown, the chat and the server, and disconnects the participants.
+// It may be called more than once.
+func (h *liveHost) close() {
+	h.closeOnce.Do(func() {
+		h.mu.Lock()
+		h.stopCountdown()
+		h.open = false
+		h.onChange = nil
+		var conns []*websocket.Conn
+		for _, player := range h.players {
+			if player.conn != nil {
+				conns = append(conns, player.conn)
+			}
+		}
+		h.mu.Unlock()
+		h.disconnectChat()
+		h.server.Close()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	})
+}
+
+// end stops the server and leaves the live quiz
+func (h *liveHost) end() {
+	h.close()
+	h.qa.showChapterSelection()
+}
+
+// showScreen shows a screen of the live quiz. Leaving it for anything but the next screen
+// of the live quiz, such as another tab's screen or a menu item, closes the server.
+func (h *liveHost) showScreen(content fyne.CanvasObject) {
+	h.screen++
+	shown := h.screen
+	h.qa.showScreen(content)
+	h.qa.router.currentSession().onEnd(func() {
+		if h.screen == shown {
+			h.close()
+		}
+	})
+}
+
+// showLobby shows the join address and the participants until the host starts
+func (h *liveHost) showLobby() {
+	leaderboard := widget.NewLabel(h.leaderboardText())
+	startButton := widget.NewButton("Start Quiz", h.qa.serialized(func() {
+		h.showQuestion()
+	}))
+	startButton.Importance = widget.HighImportance
+
+	chatLabel := widget.NewLabel(h.chatText())
+	chatButton := widget.NewButton("Connect Stream Chat…", h.qa.serialized(h.showChatSetup))
+
+	h.mu.Lock()
+	h.onChange = func() {
+		leaderboard.SetText(h.leaderboardText())
+		chatLabel.SetText(h.chatText())
+	}
+	h.mu.Unlock()
+
+	link := widget.NewLabelWithStyle(h.joinURL, fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	h.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Live Quiz", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel("Participants on the same network scan the code or open:"),
+		container.NewCenter(joinCode(h.joinURL, joinCodeSize)),
+		link,
+		widget.NewLabel(fmt.Sprintf("%d questions, %d seconds each. Faster answers score more.", len(h.questions), int(liveRoundTime.Seconds()))),
+		widget.NewLabelWithStyle("Players", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		leaderboard,
+		container.NewBorder(nil, nil, nil, chatButton, chatLabel),
+		startButton,
+		widget.NewButton("Cancel", h.qa.serialized(h.end)),
+	)))
+}
+
+// showQuestion sends the current question to every participant and starts the countdown
+func (h *liveHost) showQuestion() {
+	h.mu.Lock()
+	if h.index >= len(h.questions) {
+		h.mu.Unlock()
+		h.showResults()
+		return
+	}
+	q := h.questions[h.index]
+	h.options = append(h.answers.random(q.QAnswer, 3), q.QAnswer)
+	rand.Shuffle(len(h.options), func(i, j int) {
+		h.options[i], h.options[j] = h.options[j], h.options[i]
+	})
+	for _, player := range h.players {
+		player.answer = -1
+		player.points = 0
+	}
+	h.votes = make(map[string]int)
+	h.open = true
+	h.shownAt = time.Now()
+	update := h.questionUpdate()
+	var out []liveOutgoing
+	for _, player := range h.players {
+		out = h.queue(out, player, update)
+	}
+	options := h.options
+	chatConnected := h.chat != nil
+	h.mu.Unlock()
+	go h.deliver(out)
+
+	questionText := canvas.NewText(q.QHirakata, theme.ForegroundColor())
+	h.qa.japaneseText(questionText, projectorOptionSize)
+	questionText.TextStyle = fyne.TextStyle{Bold: true}
+	questionText.Alignment = fyne.TextAlignCenter
+
+	timerText := canvas.NewText("", theme.PrimaryColor())
+	timerText.TextSize = projectorOptionSize
+	timerText.Alignment = fyne.TextAlignCenter
+
+	optionList := container.NewVBox()
+	optionLabels := make([]*widget.Label, len(options))
+	for i, opt := range options {
+		optionLabels[i] = widget.NewLabel(fmt.Sprintf("%d. %s", i+1, opt))
+		optionList.Add(optionLabels[i])
+	}
+
+	// Chat answers are shown as a bar per option
+	voteBars := make([]*widget.ProgressBar, len(options))
+	voteChart := container.NewVBox()
+	for i := range options {
+		i := i
+		voteBars[i] = widget.NewProgressBar()
+		voteBars[i].TextFormatter = func() string {
+			return fmt.Sprintf("%d. %d", i+1, int(voteBars[i].Value))
+		}
+		voteChart.Add(voteBars[i])
+	}
+	updateVotes := func() {
+		counts, total := h.voteCounts()
+		for i, bar := range voteBars {
+			bar.Max = float64(max(total, 1))
+			bar.SetValue(float64(counts[i]))
+		}
+	}
+	updateVotes()
+	if !chatConnected {
+		voteChart.Hide()
+	}
+
+	answered := widget.NewLabel(h.answeredText())
+	leaderboard := widget.NewLabel(h.leaderboardText())
+	revealButton := widget.NewButton("Reveal Answer", h.qa.serialized(h.reveal))
+	nextButton := widget.NewButton("Next Question", h.qa.serialized(func() {
+		h.mu.Lock()
+		h.index++
+		h.mu.Unlock()
+		h.showQuestion()
+	}))
+	nextButton.Hide()
+
+	h.mu.Lock()
+	h.onChange = func() {
+		answered.SetText(h.answeredText())
+		leaderboard.SetText(h.leaderboardText())
+		updateVotes()
+		h.mu.Lock()
+		open := h.open
+		h.mu.Unlock()
+		if !open {
+			timerText.Text = ""
+			timerText.Refresh()
+			for i, opt := range options {
+				if opt == q.QAnswer {
+					optionLabels[i].SetText(fmt.Sprintf("✅ %d. %s", i+1, opt))
+				}
+			}
+			revealButton.Hide()
+			nextButton.Show()
+		}
+	}
+	h.mu.Unlock()
+
+	h.showScreen(container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle(
+				fmt.Sprintf("Question %d/%d — join at %s", h.index+1, len(h.questions), h.joinURL),
+				fyne.TextAlignCenter,
+				fyne.TextStyle{},
+			),
+			timerText,
+		),
+		container.NewGridWithColumns(3, revealButton, nextButton, widget.NewButton("End Quiz", h.qa.serialized(h.end))),
+		nil,
+		container.NewVBox(
+			widget.NewLabelWithStyle("Leaderboard", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			leaderboard,
+			layoutSpacer(),
+			container.NewCenter(joinCode(h.joinURL, joinCodeSmallSize)),
+		),
+		container.NewVBox(
+			layoutSpacer(),
+			questionText,
+			layoutSpacer(),
+			optionList,
+			answered,
+			voteChart,
+		),
+	))
+
+	// Count down, then reveal the answer if not everyone answered
+	h.mu.Lock()
+	h.stop = make(chan struct{})
+	stop := h.stop
+	deadline := h.shownAt.Add(liveRoundTime)
+	h.mu.Unlock()
+	go func() {
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				h.reveal()
+				return
+			}
+			h.qa.serialized(func() {
+				timerText.Text = fmt.Sprintf("%d", int(remaining.Seconds()+0.999))
+				timerText.Refresh()
+			})()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// reveal closes the current question, adds up the points and tells every participant how they did
+func (h *liveHost) reveal() {
+	h.mu.Lock()
+	if !h.open {
+		h.mu.Unlock()
+		return
+	}
+	h.open = false
+	h.stopCountdown()
+	q := h.questions[h.index]
+	correctOption := -1
+	for i, opt := range h.options {
+		if opt == q.QAnswer {
+			correctOption = i
+		}
+	}
+	for _, player := range h.players {
+		player.score += player.points
+	}
+	var out []liveOutgoing
+	for _, player := range h.players {
+		out = h.queue(out, player, liveUpdate{
+			Type:          "result",
+			Correct:       player.points > 0,
+			CorrectOption: correctOption,
+			Answer:        q.QAnswer,
+			Points:        player.points,
+			Score:         player.score,
+			Rank:          h.rankOf(player),
+			Players:       len(h.players),
+		})
+	}
+	h.mu.Unlock()
+	go h.deliver(out)
+	h.changed()
+}
+
+// showResults shows the final leaderboard and tells every participant their place
+func (h *liveHost) showResults() {
+	h.mu.Lock()
+	h.onChange = nil
+	var out []liveOutgoing
+	for _, player := range h.players {
+		out = h.queue(out, player, liveUpdate{
+			Type:    "end",
+			Score:   player.score,
+			Rank:    h.rankOf(player),
+			Players: len(h.players),
+		})
+	}
+	winner := "Nobody played."
+	if ranking := h.ranking(); len(ranking) > 0 {
+		winner = ranking[0].name + " wins!"
+	}
+	h.mu.Unlock()
+	go h.deliver(out)
+
+	h.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle(winner, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(h.leaderboardText()),
+		widget.NewButton("Return to Chapter Selection", h.qa.serialized(h.end)),
+	)))
+}
+
+// chatText describes the chat connection for the lobby
+func (h *liveHost) chatText() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.chatStatus == "" {
+		return "Stream chat: not connected. Viewers answer with !1 to !4."
+	}
+	return "Stream chat: " + h.chatStatus
+}
+
+// connectChat starts counting answers from a stream chat, replacing any earlier one
+func (h *liveHost) connectChat(chat chatBackend) {
+	h.disconnectChat()
+	stop := make(chan struct{})
+	h.mu.Lock()
+	h.chat = chat
+	h.chatStop = stop
+	h.chatStatus = chat.String() + " connected"
+	h.mu.Unlock()
+	h.changed()
+
+	go func() {
+		err := chat.listen(stop, h.chatAnswer)
+		h.mu.Lock()
+		if h.chatStop == stop && err != nil {
+			log.Printf("Chat disconnected: %v", err)
+			h.chatStatus = chat.String() + " disconnected: " + err.Error()
+			h.chat = nil
+			h.chatStop = nil
+		}
+		h.mu.Unlock()
+		h.changed()
+	}()
+}
+
+// disconnectChat stops counting chat answers
+func (h *liveHost) disconnectChat() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.chatStop != nil {
+		close(h.chatStop)
+		h.chatStop = nil
+	}
+	h.chat = nil
+	h.chatStatus = ""
+}
+
+// chatAnswer counts a chat message if it answers the open question. Only a viewer's first answer counts.
+func (h *liveHost) chatAnswer(user, text string) {
+	h.mu.Lock()
+	option, ok := chatVote(text, len(h.options))
+	if !ok || !h.open {
+		h.mu.Unlock()
+		return
+	}
+	if _, voted := h.votes[user]; !voted {
+		h.votes[user] = option
+	}
+	h.mu.Unlock()
+	h.changed()
+}
+
+// voteCounts returns the chat answers per option and their total
+func (h *liveHost) voteCounts() ([]int, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	counts := make([]int, len(h.options))
+	for _, option := range h.votes {
+		counts[option]++
+	}
+	return counts, len(h.votes)
+}
+
+// showChatSetup asks which stream chat viewers answer in and connects to it
+func (h *liveHost) showChatSetup() {
+	qa := h.qa
+	chatSettings := &qa.settings.Chat
+
+	backendSelect := widget.NewRadioGroup([]string{"Twitch", "Discord"}, nil)
+	backendSelect.Horizontal = true
+	backendSelect.SetSelected("Twitch")
+	if chatSettings.Backend == "discord" {
+		backendSelect.SetSelected("Discord")
+	}
+	twitchEntry := widget.NewEntry()
+	twitchEntry.SetText(chatSettings.TwitchChannel)
+	twitchEntry.SetPlaceHolder("channel name")
+	tokenEntry := widget.NewPasswordEntry()
+	tokenEntry.SetText(discordToken)
+	tokenEntry.SetPlaceHolder("or set " + envDiscordToken)
+	channelEntry := widget.NewEntry()
+	channelEntry.SetText(chatSettings.DiscordChannel)
+	channelEntry.SetPlaceHolder("channel ID")
+
+	dialog.ShowForm("Connect Stream Chat", "Connect", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Chat", backendSelect),
+			widget.NewFormItem("Twitch Channel", twitchEntry),
+			widget.NewFormItem("Discord Bot Token", tokenEntry),
+			widget.NewFormItem("Discord Channel", channelEntry),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			var chat chatBackend
+			if backendSelect.Selected == "Discord" {
+				token, channel := strings.TrimSpace(tokenEntry.Text), strings.TrimSpace(channelEntry.Text)
+				if token == "" || channel == "" {
+					dialog.ShowInformation("Connect Stream Chat", "Please enter the bot token and the channel ID.", qa.window)
+					return
+				}
+				chatSettings.Backend = "discord"
+				discordToken = token
+				chatSettings.DiscordChannel = channel
+				chat = discordChat{token: token, channel: channel}
+			} else {
+				channel := strings.TrimSpace(twitchEntry.Text)
+				if channel == "" {
+					dialog.ShowInformation("Connect Stream Chat", "Please enter the Twitch channel.", qa.window)
+					return
+				}
+				chatSettings.Backend = "twitch"
+				chatSettings.TwitchChannel = channel
+				chat = twitchChat{channel: channel}
+			}
+			if err := qa.settings.save(); err != nil {
+				log.Printf("Failed to save settings: %v", err)
+			}
+			h.connectChat(chat)
+		}, qa.window)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// loanwordSyllables groups the kana of a word into the syllables it is said in: ン, ッ and
+// ー join the kana before them, as in コン・ピュー・ター
+func loanwordSyllables(kana string) []string {
+	var syllables []string
+	for _, mora := range splitMora(kana) {
+		if len(syllables) > 0 && (mora == "ン" || mora == "ん" || mora == "ッ" || mora == "っ" || mora == "ー") {
+			syllables[len(syllables)-1] += mora
+			continue
+		}
+		syllables = append(syllables, mora)
+	}
+	return syllables
+}
+
+// loanwordRespelling spells a word syllable by syllable with long vowels in macrons,
+// e.g. kon·pyū·tā, to show how a loanword is said in Japanese
+func loanwordRespelling(kana string) string {
+	syllables := loanwordSyllables(kana)
+	parts := make([]string, len(syllables))
+	for i, syllable := range syllables {
+		stem := strings.TrimRight(syllable, "ッっ")
+		parts[i] = romanize(stem, romajiMacron)
+		if stem != syllable && i+1 < len(syllables) {
+			// ッ holds the consonant of the next syllable, as in bed·do
+			next := romanize(syllables[i+1], romajiMacron)
+			if strings.HasPrefix(next, "ch") {
+				parts[i] += "t"
+			} else if next != "" {
+				parts[i] += next[:1]
+			}
+		}
+	}
+	return strings.Join(parts, "·")
+}
+
+// loanwordHint tells where a word comes from, from QOrigin, and how a katakana word is
+// said, to show after answering. It is "" for words without an origin in the deck.
+func loanwordHint(q Question) string {
+	if q.QOrigin == "" {
+		return ""
+	}
+	hint := fmt.Sprintf("%s ← %s", q.QHirakata, q.QOrigin)
+	if isKatakana(q.QHirakata) {
+		hint += fmt.Sprintf("\nSaid %s, in %d beats: every kana, ン, ッ and ー takes one.", loanwordRespelling(q.QHirakata), len(splitMora(q.QHirakata)))
+	}
+	return hint
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package main
+
+import "os"
+
+// lockFile does nothing here: there is no file locking, and only one app runs at a time
+func lockFile(f *os.File) error {
+	return nil
+}
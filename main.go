@@ -1,15 +1,24 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"github.com/xuri/excelize/v2"
@@ -23,15 +32,95 @@ type Question struct {
 	QHirakata string // Question text in Japanese characters
 	QRomaji   string // Question text in romanized form
 	QType     string // Category or type of question
+	QExample  string // Example sentence (optional column)
+	QKanji    string // Kanji spelling (optional column)
+	QRequires string // QIDs of items to master before this one is asked, comma-separated (optional column)
+	QOrigin   string // Word a loanword comes from, e.g. "computer (English)" (optional column)
+
+	KanaFromRomaji bool   // QHirakata was made from QRomaji when loading, the deck having no kana
+	KanaCheck      string // What about that kana may be wrong, see kanaFromRomaji
 }
 
 // gameState tracks the current state of the quiz
 type gameState struct {
-	score            int        // Current score
-	questionsAsked   int        // Number of questions completed
-	totalQuestions   int        // Total questions in current quiz
-	currentChapter   string     // Selected chapter
-	chapterQuestions []Question // Questions filtered for current chapter
+	score            int              // Current score
+	questionsAsked   int              // Number of questions completed
+	totalQuestions   int              // Total questions in current quiz
+	currentChapter   string           // Selected chapter
+	quizName         string           // Name used when exporting results
+	chapterQuestions []Question       // Questions filtered for current chapter
+	quizQuestions    []Question       // Fixed question order (practice tests), nil for random draws
+	current          Question         // Question being shown
+	onFinish         func(quizResult) // Called once when the quiz is completed
+
+	nextQuestion func() (Question, bool) // Chooses questions as the quiz goes (adaptive tests); false ends the quiz early
+	onAnswer     func(Question, bool)    // Told about every answer and whether it was correct
+
+	pickDistractors func(Question) []string // Overrides how wrong options are chosen (pair drills)
+
+	advance func() // Skips the question being shown, or moves on at once if it was answered
+
+	mixDrawn map[string]int // Questions drawn so far by category, to keep to the question mix
+
+	answers *answerPool // Answers of chapterQuestions, see answerPool
+}
+
+// reset clears the score and progress of the finished quiz
+func (s *gameState) reset() {
+	s.score = 0
+	s.questionsAsked = 0
+	s.quizQuestions = nil
+	s.onFinish = nil
+	s.nextQuestion = nil
+	s.onAnswer = nil
+	s.pickDistractors = nil
+	s.advance = nil
+	s.mixDrawn = nil
+}
+
+// questionTextSize is the size of the question in the quiz screen
+const questionTextSize = 24
+
+// quizApp holds the window, loaded questions and the widgets shared between screens
+type quizApp struct {
+	app         fyne.App
+	window      fyne.Window
+	questions   []Question
+	index       *questionIndex // Lookups into questions, see setQuestions
+	deckName    string         // File name of the loaded deck, which keys its review schedule
+	state       *gameState
+	settings    *Settings
+	progress    *Progress
+	kiosk       bool        // Locked-down classroom mode
+	mini        *miniWidget // Compact quiz window, nil when closed
+	statsWindow fyne.Window // Detached statistics window, nil when closed
+
+	romajiMenuItem   *fyne.MenuItem // View ▸ Show Romaji, nil without a main menu
+	verticalMenuItem *fyne.MenuItem // View ▸ Vertical Question Text, nil without a main menu
+	furiganaMenuItem *fyne.MenuItem // View ▸ Furigana on Options, nil without a main menu
+	reverseMenuItem  *fyne.MenuItem // Quiz ▸ Reverse, nil without a main menu
+	timerMenuItem    *fyne.MenuItem // View ▸ Show Question Timer, nil without a main menu
+
+	tabs        *container.AppTabs // Quiz, Browse, Stats and Settings
+	browseTab   *container.TabItem
+	statsTab    *container.TabItem
+	settingsTab *container.TabItem // nil in kiosk mode
+
+	stopHotkey func()      // Unregisters the global hotkey, nil if none is registered
+	idleTimer  *time.Timer // Reminds about a quiz left unanswered
+
+	router               *screenRouter // Shows the current screen
+	questionLabel        *canvas.Text
+	verticalQuestion     *verticalText // questionLabel written vertically, shown instead of it when set
+	romajiLabel          *widget.Label
+	optionsContainer     *fyne.Container
+	scoreLabel           *widget.Label
+	clickableRomajiLabel *widget.Button
+	answerInfo           *widget.Label // Extra information shown after answering
+	timer                *questionTimer
+	nextButton           *widget.Button // Moves on after an answer when auto-advance is off
+
+	ui sync.Mutex // Held by timer callbacks and the input handlers they race with, see serialized
 }
 
 // loadQuestionsFromExcel reads and parses questions from an Excel file
@@ -40,30 +129,59 @@ func loadQuestionsFromExcel(filepath string) ([]Question, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
+	return readQuestions(f)
+}
+
+// eachRow streams the rows of a sheet to visit with their 1-based numbers, without holding
+// the whole sheet in memory as GetRows does. Trailing empty cells are left out of each row.
+func eachRow(f *excelize.File, sheet string, visit func(number int, row []string)) error {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for number := 1; rows.Next(); number++ {
+		row, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		visit(number, row)
+	}
+	return rows.Error()
+}
 
-	var questions []Question
-	rows, err := f.GetRows("Sheet1")
+// readQuestions parses the questions on the first sheet of a workbook, below the header
+// detectLayout finds. Columns a header leaves open are taken at their best guess.
+func readQuestions(f *excelize.File) ([]Question, error) {
+	layout, err := sheetLayout(f, questionSheet, nil)
 	if err != nil {
 		return nil, err
 	}
+	return readQuestionsWith(f, layout)
+}
 
-	// Skip header row and process each data row
-	for _, row := range rows[1:] {
-		if len(row) < 6 {
-			continue // Skip incomplete rows
+// deckColumns are the header of a question sheet, required columns first
+var deckColumns = []string{"QID", "QChapter", "QAnswer", "QHirakata", "QRomaji", "QType", "QExample", "QKanji", "QRequires", "QOrigin"}
+
+// writeQuestions saves questions as a workbook that readQuestions can load
+func writeQuestions(w io.Writer, questions []Question) error {
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetRow(questionSheet, "A1", &deckColumns); err != nil {
+		return err
+	}
+	for i, q := range questions {
+		row := []string{q.QID, q.QChapter, q.QAnswer, q.QHirakata, q.QRomaji, q.QType, q.QExample, q.QKanji, q.QRequires, q.QOrigin}
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
 		}
-		question := Question{
-			QID:       row[0],
-			QChapter:  row[1],
-			QAnswer:   row[2],
-			QHirakata: row[3],
-			QRomaji:   row[4],
-			QType:     row[5],
+		if err := f.SetSheetRow(questionSheet, cell, &row); err != nil {
+			return err
 		}
-		questions = append(questions, question)
 	}
-
-	return questions, nil
+	return f.Write(w)
 }
 
 // getQuestionsByChapter filters questions for a specific chapter
@@ -77,251 +195,715 @@ func getQuestionsByChapter(questions []Question, chapter string) []Question {
 	return filtered
 }
 
-// getRandomAnswers generates wrong answer options, avoiding duplicates
+// deckChapters returns the chapters of a deck, in numeric order where chapters are numbers
+func deckChapters(questions []Question) []string {
+	var chapters []string
+	seen := make(map[string]bool)
+	for _, q := range questions {
+		if !seen[q.QChapter] {
+			seen[q.QChapter] = true
+			chapters = append(chapters, q.QChapter)
+		}
+	}
+	sort.Slice(chapters, func(i, j int) bool {
+		a, errA := strconv.Atoi(chapters[i])
+		b, errB := strconv.Atoi(chapters[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return chapters[i] < chapters[j]
+	})
+	return chapters
+}
+
+// getRandomAnswers generates wrong answer options, avoiding duplicates and answers
+// that share a meaning with the correct one or with each other. Options drawn again and
+// again from the same questions come from an answerPool instead.
 func getRandomAnswers(questions []Question, correctAnswer string, count int) []string {
-	var answers []string
-	usedAnswers := make(map[string]bool)
-	usedAnswers[correctAnswer] = true
+	return newAnswerPool(questions).random(correctAnswer, count)
+}
 
-	// Collect unique wrong answers
-	for _, q := range questions {
-		if !usedAnswers[q.QAnswer] {
-			answers = append(answers, q.QAnswer)
-			usedAnswers[q.QAnswer] = true
+// overlapsAny reports whether an answer shares a meaning with any of the others
+func overlapsAny(answer string, others []string) bool {
+	for _, other := range others {
+		if answersOverlap(answer, other) {
+			return true
 		}
 	}
+	return false
+}
 
-	// Randomize answers
-	rand.Shuffle(len(answers), func(i, j int) {
-		answers[i], answers[j] = answers[j], answers[i]
-	})
+// serialized wraps a callback so that it holds the UI lock. Fyne runs input handlers on its
+// event goroutine and time.AfterFunc runs callbacks on their own, so both sides of a race
+// over the quiz state are wrapped. A serialized callback must not call another one directly.
+func (qa *quizApp) serialized(f func()) func() {
+	return func() {
+		qa.ui.Lock()
+		defer qa.ui.Unlock()
+		f()
+	}
+}
+
+// afterFunc calls f after d, holding the UI lock, unless the screen shown now was replaced
+// by then. Timers that outlive screens call time.AfterFunc with a serialized func instead.
+func (qa *quizApp) afterFunc(d time.Duration, f func()) *time.Timer {
+	session := qa.router.currentSession()
+	return session.track(time.AfterFunc(d, qa.serialized(func() {
+		// The timer may have fired while the callback that replaced the screen held the lock
+		if session.active() {
+			f()
+		}
+	})))
+}
+
+// showScreen replaces the window content with a new screen, fading it in.
+// Leaving the quiz screen stops the question timer.
+func (qa *quizApp) showScreen(content fyne.CanvasObject) {
+	qa.timer.stop()
+	qa.router.show(content, transitionFade)
+}
+
+// gameLayout creates the main quiz game layout
+func (qa *quizApp) gameLayout() fyne.CanvasObject {
+	state := qa.state
+
+	// Toggle button for showing/hiding romaji
+	qa.clickableRomajiLabel = widget.NewButton("", qa.serialized(func() {
+		qa.setShowRomaji(!qa.settings.ShowRomaji)
+	}))
+	qa.clickableRomajiLabel.Importance = widget.LowImportance
+	qa.updateRomaji()
+
+	// Auto-advance can be switched right here, for skimming or for studying each answer
+	autoAdvance := widget.NewCheck("Auto-advance", nil)
+	autoAdvance.SetChecked(!qa.settings.ManualAdvance)
+	autoAdvance.OnChanged = func(auto bool) {
+		// The advance timer may move on meanwhile
+		qa.serialized(func() {
+			qa.setAutoAdvance(auto)
+		})()
+	}
+	qa.nextButton = widget.NewButton("Next", nil)
+	qa.nextButton.Importance = widget.HighImportance
+	qa.nextButton.Hide()
+	advanceRow := container.NewHBox(autoAdvance, layout.NewSpacer(), qa.nextButton)
+
+	// Progress and score tracking
+	progressLabel := widget.NewLabel(fmt.Sprintf("Question %d/%d", state.questionsAsked+1, state.totalQuestions))
+	qa.scoreLabel.SetText(fmt.Sprintf("Score: %d/%d", state.score, state.questionsAsked))
+
+	title := container.NewCenter(widget.NewLabelWithStyle(
+		fmt.Sprintf("Genki Quiz! (Chapter: %s)", state.currentChapter),
+		fyne.TextAlignCenter,
+		fyne.TextStyle{Bold: true},
+	))
+	// Right-click or long-press the question to copy, star or edit it
+	question := container.NewCenter(newContextMenuArea(
+		container.NewStack(qa.questionLabel, qa.verticalQuestion),
+		qa.window,
+		qa.questionMenu,
+	))
+
+	header := container.NewCenter(container.NewHBox(progressLabel, qa.timer.label))
+
+	// On phones the answers sit at the bottom, in reach of a thumb, and a swipe to the left skips
+	if isMobile() {
+		return newSwipeArea(container.NewBorder(
+			container.NewVBox(title, header),
+			container.NewVBox(qa.optionsContainer, advanceRow, qa.scoreLabel),
+			nil, nil,
+			container.NewVBox(
+				question,
+				container.NewCenter(qa.romajiLabel),
+				container.NewCenter(qa.clickableRomajiLabel),
+				qa.answerInfo,
+			),
+		), qa.serialized(func() {
+			if state.advance != nil {
+				state.advance()
+			}
+		}))
+	}
+
+	// Arrange UI elements vertically
+	return container.NewVBox(
+		title,
+		header,
+		question,
+		container.NewCenter(qa.romajiLabel),
+		container.NewCenter(qa.clickableRomajiLabel),
+		qa.optionsContainer,
+		advanceRow,
+		qa.answerInfo,
+		qa.scoreLabel,
+	)
+}
 
-	// Return requested number of wrong answers
-	if len(answers) > count {
-		return answers[:count]
+// setAutoAdvance switches between moving on by itself after an answer and waiting for Next,
+// and remembers the choice. Switching it on while Next is waiting moves on at once.
+// It must be called holding the UI lock.
+func (qa *quizApp) setAutoAdvance(auto bool) {
+	if auto == !qa.settings.ManualAdvance {
+		return
+	}
+	qa.settings.ManualAdvance = !auto
+	if err := qa.settings.save(); err != nil {
+		log.Printf("Failed to save settings: %v", err)
+	}
+	if auto && qa.nextButton != nil && qa.nextButton.Visible() && qa.state.advance != nil {
+		qa.state.advance()
 	}
-	return answers
 }
 
-func main() {
-	rand.Seed(time.Now().UnixNano())
+// setShowRomaji shows or hides the romaji under questions and remembers the choice
+func (qa *quizApp) setShowRomaji(show bool) {
+	qa.settings.ShowRomaji = show
+	if err := qa.settings.save(); err != nil {
+		log.Printf("Failed to save settings: %v", err)
+	}
+	qa.updateRomaji()
+}
 
-	// Load questions from Excel file
-	questions, err := loadQuestionsFromExcel("quizsheet.xlsx")
-	if err != nil {
-		log.Fatalf("Failed to load quiz questions: %v", err)
+// updateRomaji applies the romaji setting to the quiz screen
+func (qa *quizApp) updateRomaji() {
+	if qa.settings.ShowRomaji {
+		qa.romajiLabel.Show()
+	} else {
+		qa.romajiLabel.Hide()
+	}
+	if qa.clickableRomajiLabel != nil {
+		if qa.settings.ShowRomaji {
+			qa.clickableRomajiLabel.SetText("Hide Romaji")
+		} else {
+			qa.clickableRomajiLabel.SetText("Show Romaji")
+		}
+	}
+	if qa.romajiMenuItem != nil {
+		qa.romajiMenuItem.Checked = qa.settings.ShowRomaji
+		qa.window.MainMenu().Refresh()
 	}
+}
 
-	// Initialize Fyne application and window
-	a := app.New()
-	w := a.NewWindow("Genki Quiz")
-	w.Resize(fyne.NewSize(500, 400))
+// setReverse switches between picking the English for Japanese and the Japanese for English.
+// It applies from the next question on.
+func (qa *quizApp) setReverse(reverse bool) {
+	qa.settings.Reverse = reverse
+	if err := qa.settings.save(); err != nil {
+		log.Printf("Failed to save settings: %v", err)
+	}
+	if qa.reverseMenuItem != nil {
+		qa.reverseMenuItem.Checked = reverse
+		qa.window.MainMenu().Refresh()
+	}
+}
 
-	// Initialize game state and UI elements
-	state := &gameState{}
-	var questionContainer *fyne.Container
-	questionLabel := canvas.NewText("", theme.TextColor())
-	questionLabel.TextStyle = fyne.TextStyle{Bold: true}
-	questionLabel.TextSize = 24
-	romajiLabel := widget.NewLabel("")
-	optionsContainer := container.NewVBox()
-	scoreLabel := widget.NewLabel("")
-	var clickableRomajiLabel *widget.Button
-
-	romajiLabel.Hide() // Initially hide romaji text
-
-	// Forward declarations for UI navigation functions
-	var loadQuestion func()
-	var showChapterSelection func()
-	var showQuizTypeSelection func()
-	var showQuizSummary func()
-
-	// Creates main quiz game layout
-	gameLayout := func() fyne.CanvasObject {
-		romajiVisible := false
-
-		// Toggle button for showing/hiding romaji
-		clickableRomajiLabel = widget.NewButton("Show Romaji", func() {
-			romajiVisible = !romajiVisible
-			if romajiVisible {
-				romajiLabel.Show()
-				clickableRomajiLabel.SetText("Hide Romaji")
-			} else {
-				romajiLabel.Hide()
-				clickableRomajiLabel.SetText("Show Romaji")
+// startQuiz switches to the game layout and shows the first question. A quiz drawing
+// only from new items is not started once today's new items are used up.
+func (qa *quizApp) startQuiz() {
+	state := qa.state
+	if state.quizQuestions == nil && state.nextQuestion == nil && len(state.chapterQuestions) > 0 &&
+		len(qa.newItemsPool(state.chapterQuestions)) == 0 {
+		qa.showNewItemsLimit()
+		return
+	}
+	qa.state.mixDrawn = nil
+	qa.router.show(qa.gameLayout(), transitionSlide)
+	qa.loadQuestion()
+}
+
+// showQuizSummary shows the quiz completion screen with the final score
+func (qa *quizApp) showQuizSummary() {
+	state := qa.state
+	result := quizResult{
+		Quiz:        state.quizName,
+		Chapter:     state.currentChapter,
+		Score:       state.score,
+		Total:       state.totalQuestions,
+		CompletedAt: time.Now().Truncate(time.Second),
+	}
+	qa.stopIdleTimer()
+	qa.reportResult(result)
+	qa.notifySessionEnd(result)
+	if state.onFinish != nil {
+		state.onFinish(result)
+		state.onFinish = nil
+	}
+	qa.updatePresence("Finished "+result.Quiz, fmt.Sprintf("Score %d/%d", result.Score, result.Total))
+	qa.earnPerfectXP(float64(state.score), state.totalQuestions)
+
+	summary := container.NewVBox(
+		widget.NewLabelWithStyle(
+			"Quiz Complete!",
+			fyne.TextAlignCenter,
+			fyne.TextStyle{Bold: true},
+		),
+		widget.NewLabel(fmt.Sprintf("Final Score: %d/%d (%.1f%%)",
+			state.score,
+			state.totalQuestions,
+			float64(state.score)/float64(state.totalQuestions)*100,
+		)),
+		qa.levelPanel(),
+	)
+	if !qa.kiosk {
+		summary.Add(widget.NewButton("Export Results", qa.serialized(func() {
+			qa.exportResult(result)
+		})))
+		summary.Add(widget.NewButton("Result Card", qa.serialized(func() {
+			qa.showResultCard(result)
+		})))
+	}
+	summary.Add(widget.NewButton("Return to Chapter Selection", qa.serialized(func() {
+		state.reset()
+		qa.showChapterSelection()
+	})))
+	qa.router.show(container.NewCenter(summary), transitionSlide)
+}
+
+// showQuizTypeSelection shows the quiz type selection screen (mini or full chapter)
+func (qa *quizApp) showQuizTypeSelection() {
+	state := qa.state
+	state.chapterQuestions = qa.studyChapters([]string{state.currentChapter})
+
+	// Hosting opens a network server, which kiosk computers should not do
+	liveButton := widget.NewButton("Host a Live Quiz (Phones)", qa.serialized(func() {
+		qa.showLiveHost()
+	}))
+	if qa.kiosk {
+		liveButton.Hide()
+	}
+
+	qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Chapter %s - Available Questions: %d",
+			state.currentChapter, len(state.chapterQuestions))),
+		qa.chapterDashboard(state.currentChapter, state.chapterQuestions),
+		widget.NewButton("Question Mix: "+qa.settings.QuestionMix.String(), qa.serialized(func() {
+			qa.showMixDialog(qa.showQuizTypeSelection)
+		})),
+		widget.NewButton("Mini Quiz (10 questions)", qa.serialized(func() {
+			state.quizName = fmt.Sprintf("Chapter %s Mini Quiz", state.currentChapter)
+			state.totalQuestions = 10
+			if len(state.chapterQuestions) < 10 {
+				state.totalQuestions = len(state.chapterQuestions)
 			}
-			romajiLabel.Refresh()
-			clickableRomajiLabel.Refresh()
-		})
-		clickableRomajiLabel.Importance = widget.LowImportance
-
-		// Progress and score tracking
-		progressLabel := widget.NewLabel(fmt.Sprintf("Question %d/%d", state.questionsAsked+1, state.totalQuestions))
-		scoreLabel.SetText(fmt.Sprintf("Score: %d/%d", state.score, state.questionsAsked))
-
-		// Arrange UI elements vertically
-		return container.NewVBox(
-			container.NewCenter(widget.NewLabelWithStyle(
-				fmt.Sprintf("Genki Quiz! (Chapter: %s)", state.currentChapter),
-				fyne.TextAlignCenter,
-				fyne.TextStyle{Bold: true},
-			)),
-			container.NewCenter(progressLabel),
-			container.NewCenter(questionLabel),
-			container.NewCenter(romajiLabel),
-			container.NewCenter(clickableRomajiLabel),
-			optionsContainer,
-			scoreLabel,
-		)
-	}
-
-	// Shows quiz completion screen with final score
-	showQuizSummary = func() {
-		questionContainer.Objects = []fyne.CanvasObject{
-			container.NewCenter(container.NewVBox(
-				widget.NewLabelWithStyle(
-					"Quiz Complete!",
-					fyne.TextAlignCenter,
-					fyne.TextStyle{Bold: true},
-				),
-				widget.NewLabel(fmt.Sprintf("Final Score: %d/%d (%.1f%%)",
-					state.score,
-					state.totalQuestions,
-					float64(state.score)/float64(state.totalQuestions)*100,
-				)),
-				widget.NewButton("Return to Chapter Selection", func() {
-					state.score = 0
-					state.questionsAsked = 0
-					showChapterSelection()
-				}),
-			)),
+			qa.startQuiz()
+		})),
+		widget.NewButton("Full Chapter Quiz", qa.serialized(func() {
+			state.quizName = fmt.Sprintf("Chapter %s Full Quiz", state.currentChapter)
+			state.totalQuestions = len(state.chapterQuestions)
+			qa.startQuiz()
+		})),
+		widget.NewButton(fmt.Sprintf("Typed Quiz (%d questions)", typedQuizSize), qa.serialized(func() {
+			qa.startTypedQuiz()
+		})),
+		widget.NewButton("Spelling Traps (typed)", qa.serialized(func() {
+			qa.startSpellingDrill()
+		})),
+		widget.NewButton("Meanings (typed)", qa.serialized(func() {
+			qa.startMeaningDrill()
+		})),
+		qa.grammarButton(),
+		qa.translationButton(),
+		qa.shadowingButton(),
+		qa.passageButton(),
+		qa.bossButton(),
+		widget.NewButton("Projector Mode (Class Review)", qa.serialized(func() {
+			qa.showProjectorMode()
+		})),
+		widget.NewButton("Versus Mode (2 Players)", qa.serialized(func() {
+			qa.showVersusMode()
+		})),
+		liveButton,
+		widget.NewButton("Back to Chapter Selection", qa.serialized(func() {
+			qa.showChapterSelection()
+		})),
+	)))
+}
+
+// showChapterSelection shows the chapter selection screen
+func (qa *quizApp) showChapterSelection() {
+	qa.updatePresence("Choosing a chapter", "")
+
+	dailyButton := widget.NewButton(qa.dailyButtonText(), qa.serialized(func() {
+		qa.showDailyChallenge()
+	}))
+	dailyButton.Importance = widget.HighImportance
+
+	// Guests are reminded that their answers are not kept
+	guestLabel := widget.NewLabelWithStyle("Guest mode: nothing is saved", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	if !guestMode {
+		guestLabel.Hide()
+	}
+
+	chooseChapter := func(selected string) {
+		qa.state.currentChapter = selected
+		qa.showQuizTypeSelection()
+	}
+	var chapters fyne.CanvasObject = widget.NewRadioGroup(qa.availableChapters(), func(selected string) {
+		qa.serialized(func() {
+			chooseChapter(selected)
+		})()
+	})
+	if qa.settings.Progression.On {
+		chapters = qa.progressionMap(qa.offeredChapters(), chooseChapter)
+	} else if conquered := qa.conqueredChapters(qa.availableChapters()); len(conquered) > 0 {
+		chapters = container.NewVBox(chapters, widget.NewLabel("👑 Conquered: "+strings.Join(conquered, ", ")))
+	}
+
+	menu := container.NewVBox(
+		widget.NewLabelWithStyle(
+			"Welcome to Genki Quiz!",
+			fyne.TextAlignCenter,
+			fyne.TextStyle{Bold: true},
+		),
+		guestLabel,
+		qa.levelPanel(),
+		qa.duePanel(),
+		dailyButton,
+		qa.presetsPanel(),
+		qa.wordOfTheDayPanel(),
+		widget.NewLabel("Select Chapter:"),
+		chapters,
+		widget.NewButton("Practice Test", qa.serialized(func() {
+			qa.showPracticeTestSelection()
+		})),
+		widget.NewButton("Placement Test", qa.serialized(func() {
+			qa.startAdaptiveTest()
+		})),
+		widget.NewButton("Confusable Pairs", qa.serialized(func() {
+			qa.showConfusablePairs()
+		})),
+		widget.NewButton("Kana Typing Tutor", qa.serialized(func() {
+			qa.showKanaTutor()
+		})),
+		widget.NewButton("Numbers Dictation", qa.serialized(func() {
+			qa.showNumberDictation()
+		})),
+		widget.NewButton("Clock Reading", qa.serialized(func() {
+			qa.showClockDrill()
+		})),
+		widget.NewButton("Calendar Dates", qa.serialized(func() {
+			qa.showCalendarDrill()
+		})),
+		widget.NewButton("Family Terms", qa.serialized(func() {
+			qa.startFamilyDrill()
+		})),
+	)
+
+	// Screens that open files or change settings are not available in kiosk mode
+	if !qa.kiosk {
+		menu.Add(widget.NewButton("Open Assignment", qa.serialized(func() {
+			qa.openAssignment()
+		})))
+		menu.Add(widget.NewButton("Teacher Mode", qa.serialized(func() {
+			qa.requireLockPIN("Teacher Mode", qa.showTeacherMode)
+		})))
+	}
+
+	qa.showScreen(container.NewCenter(menu))
+}
+
+// loadQuestion loads and displays a new question
+func (qa *quizApp) loadQuestion() {
+	state := qa.state
+	if state.questionsAsked >= state.totalQuestions {
+		qa.showQuizSummary()
+		return
+	}
+
+	qa.resetIdleTimer()
+
+	// Create question pool
+	availableQuestions := make([]Question, len(state.chapterQuestions))
+	copy(availableQuestions, state.chapterQuestions)
+
+	// Select the next question (in order for practice tests, random in the chosen mix otherwise) and set up display
+	var q Question
+	if state.quizQuestions == nil && state.nextQuestion == nil {
+		pool := qa.newItemsPool(availableQuestions)
+		if len(pool) == 0 {
+			pool = availableQuestions
+		}
+		q = state.drawMixed(pool, qa.settings.QuestionMix)
+	}
+	if state.quizQuestions != nil {
+		q = state.quizQuestions[state.questionsAsked]
+	}
+	if state.nextQuestion != nil {
+		next, ok := state.nextQuestion()
+		if !ok {
+			state.totalQuestions = state.questionsAsked
+			qa.showQuizSummary()
+			return
+		}
+		q = next
+	}
+	state.current = q
+	qa.quizPresence()
+	qa.questionLabel.Text = q.QHirakata
+	qa.romajiLabel.SetText(q.QRomaji)
+	if qa.settings.Reverse {
+		// Show the English and pick the Japanese; the romaji would give the answer away
+		qa.questionLabel.Text = q.QAnswer
+		qa.romajiLabel.SetText("")
+	}
+	qa.questionLabel.Refresh()
+	qa.verticalQuestion.Refresh()
+	qa.answerInfo.SetText("")
+	qa.timer.start()
+
+	// Generate and shuffle answer options
+	answers := state.answerPool()
+	randomAnswers := getDistractors(answers, q, 3, qa.progress)
+	if state.pickDistractors != nil {
+		randomAnswers = state.pickDistractors(q)
+	}
+	allAnswers := append(randomAnswers, q.QAnswer)
+	rand.Shuffle(len(allAnswers), func(i, j int) {
+		allAnswers[i], allAnswers[j] = allAnswers[j], allAnswers[i]
+	})
+
+	// In reverse mode each option shows the Japanese of a question with that answer
+	byAnswer := func(answer string) (Question, bool) {
+		if answer == q.QAnswer {
+			return q, true
 		}
-		questionContainer.Refresh()
-	}
-
-	// Shows quiz type selection screen (mini or full chapter)
-	showQuizTypeSelection = func() {
-		state.chapterQuestions = getQuestionsByChapter(questions, state.currentChapter)
-
-		questionContainer.Objects = []fyne.CanvasObject{
-			container.NewCenter(container.NewVBox(
-				widget.NewLabel(fmt.Sprintf("Chapter %s - Available Questions: %d",
-					state.currentChapter, len(state.chapterQuestions))),
-				widget.NewButton("Mini Quiz (10 questions)", func() {
-					state.totalQuestions = 10
-					if len(state.chapterQuestions) < 10 {
-						state.totalQuestions = len(state.chapterQuestions)
-					}
-					questionContainer.Objects = []fyne.CanvasObject{gameLayout()}
-					questionContainer.Refresh()
-					loadQuestion()
-				}),
-				widget.NewButton("Full Chapter Quiz", func() {
-					state.totalQuestions = len(state.chapterQuestions)
-					questionContainer.Objects = []fyne.CanvasObject{gameLayout()}
-					questionContainer.Refresh()
-					loadQuestion()
-				}),
-				widget.NewButton("Back to Chapter Selection", func() {
-					showChapterSelection()
-				}),
-			)),
+		return answers.question(answer)
+	}
+
+	// Move on once, whether after the answer delay, at Next or at a swipe
+	answered, advanced := false, false
+	next := func() {
+		if !advanced {
+			advanced = true
+			if qa.nextButton != nil {
+				qa.nextButton.Hide()
+			}
+			qa.loadQuestion()
 		}
-		questionContainer.Refresh()
-	}
-
-	// Shows chapter selection screen
-	showChapterSelection = func() {
-		questionContainer.Objects = []fyne.CanvasObject{
-			container.NewCenter(container.NewVBox(
-				widget.NewLabelWithStyle(
-					"Welcome to Genki Quiz!",
-					fyne.TextAlignCenter,
-					fyne.TextStyle{Bold: true},
-				),
-				widget.NewLabel("Select Chapter:"),
-				widget.NewRadioGroup([]string{"1", "2", "3", "4"}, func(selected string) {
-					state.currentChapter = selected
-					showQuizTypeSelection()
-				}),
-			)),
+	}
+	state.advance = func() {
+		if !answered {
+			// A skipped question counts as asked but not as right
+			answered = true
+			state.questionsAsked++
+			qa.timer.stop()
 		}
-		questionContainer.Refresh()
+		next()
 	}
 
-	// Loads and displays a new question
-	loadQuestion = func() {
-		if state.questionsAsked >= state.totalQuestions {
-			showQuizSummary()
-			return
+	// Create answer buttons
+	qa.optionsContainer.Objects = nil
+	var correctButton *rubyButton
+
+	for _, opt := range allAnswers {
+		opt := opt
+		text, reading := opt, ""
+		if p, ok := byAnswer(opt); ok && qa.settings.Reverse {
+			text = p.QHirakata
+			if kanji := questionKanji(p); kanji != "" && kanji != p.QHirakata {
+				text, reading = kanji, p.QHirakata
+			} else if kana, kanji := splitReading(p.QHirakata); kanji != "" {
+				text, reading = kanji, kana // Written like "学校 (がっこう)"
+			}
 		}
+		var button *rubyButton
+		button = newRubyButton(text, reading, qa.serialized(func() {
+			answered = true
+			hapticFeedback()
+			state.questionsAsked++
+			qa.progress.recordTime(q.QID, qa.timer.stop())
+			qa.recordAnswer(q, opt)
+			if state.onAnswer != nil {
+				state.onAnswer(q, opt == q.QAnswer)
+			}
+			if opt == q.QAnswer {
+				state.score++
+				button.setMark("✅")
+			} else {
+				button.setMark("❌")
+				shake(button)
+			}
+			qa.showAnswerToast(opt == q.QAnswer)
 
-		// Create question pool
-		availableQuestions := make([]Question, len(state.chapterQuestions))
-		copy(availableQuestions, state.chapterQuestions)
-
-		// Select random question and set up display
-		q := availableQuestions[rand.Intn(len(availableQuestions))]
-		questionLabel.Text = q.QHirakata
-		questionLabel.Refresh()
-		romajiLabel.SetText(q.QRomaji)
-
-		// Generate and shuffle answer options
-		randomAnswers := getRandomAnswers(availableQuestions, q.QAnswer, 3)
-		allAnswers := append(randomAnswers, q.QAnswer)
-		rand.Shuffle(len(allAnswers), func(i, j int) {
-			allAnswers[i], allAnswers[j] = allAnswers[j], allAnswers[i]
-		})
-
-		// Create answer buttons
-		optionsContainer.Objects = nil
-		var correctButton *widget.Button
-
-		for _, opt := range allAnswers {
-			opt := opt
-			var button *widget.Button
-			button = widget.NewButton(opt, func() {
-				state.questionsAsked++
-				if opt == q.QAnswer {
-					state.score++
-					button.SetText(fmt.Sprintf("✅ %s", button.Text))
-				} else {
-					button.SetText(fmt.Sprintf("❌ %s", button.Text))
-				}
-				button.Refresh()
+			// Show correct answer if wrong choice selected
+			if correctButton != nil && correctButton != button {
+				correctButton.setMark("✅")
+			}
+			if correctButton != nil {
+				pulse(correctButton.highlight, theme.SuccessColor())
+			}
 
-				// Show correct answer if wrong choice selected
-				if correctButton != nil && correctButton != button {
-					correctButton.SetText(fmt.Sprintf("✅ %s", correctButton.Text))
-					correctButton.Refresh()
+			// Show the kanji components to help build mnemonics
+			if kanji := questionKanji(q); kanji != "" {
+				if lines := kanjiBreakdown(kanji); len(lines) > 0 {
+					qa.answerInfo.SetText(kanji + "\n" + strings.Join(lines, "\n"))
 				}
+			}
+			// and where a loanword comes from
+			if hint := loanwordHint(q); hint != "" {
+				qa.answerInfo.SetText(strings.TrimPrefix(qa.answerInfo.Text+"\n"+hint, "\n"))
+			}
 
-				// Update score display
-				scoreLabel.SetText(fmt.Sprintf("Score: %d/%d", state.score, state.questionsAsked))
+			// Update score display
+			qa.scoreLabel.SetText(fmt.Sprintf("Score: %d/%d", state.score, state.questionsAsked))
 
-				// Disable all buttons after answer
-				for _, obj := range optionsContainer.Objects {
-					if btn, ok := obj.(*widget.Button); ok {
-						btn.OnTapped = nil
-					}
+			// Disable all buttons after answer
+			for _, obj := range qa.optionsContainer.Objects {
+				if btn, ok := obj.(*rubyButton); ok {
+					btn.disable()
 				}
+			}
 
-				// Load next question after delay
-				time.AfterFunc(2*time.Second, loadQuestion)
-			})
-
-			if opt == q.QAnswer {
-				correctButton = button
+			// Load next question after delay, or when Next is pressed
+			if qa.settings.ManualAdvance && qa.nextButton != nil {
+				qa.nextButton.OnTapped = qa.serialized(next)
+				qa.nextButton.Show()
+			} else {
+				qa.afterFunc(2*time.Second, next)
 			}
+		}))
+
+		if isMobile() {
+			button.minHeight = thumbButtonHeight
+		}
+		button.label.ShowReading = qa.settings.OptionFurigana
+		if qa.settings.Reverse {
+			qa.japaneseRuby(button.label)
+		}
+		if opt == q.QAnswer {
+			correctButton = button
+		}
+		p, inDeck := byAnswer(opt)
+		button.menu = func() *fyne.Menu {
+			return qa.optionMenu(text, p, inDeck)
+		}
+
+		qa.optionsContainer.Add(button)
+	}
+	qa.optionsContainer.Refresh()
+}
+
+// deckFile is the question sheet loaded at startup
+const deckFile = "quizsheet.xlsx"
+
+func main() {
+	// Subcommands such as "validate" run without opening a window
+	if code, ok := runCommand(os.Args[1:], os.Stdout, os.Stderr); ok {
+		os.Exit(code)
+	}
+
+	kiosk := flag.Bool("kiosk", false, "run locked down for shared classroom computers")
+	portable := flag.Bool("portable", false, "keep settings, progress and decks beside the executable (also enabled by "+portableMarker+")")
+	profile := flag.String("profile", "", "use the settings and progress of a named learner (or set "+envProfile+")")
+	deckPath := flag.String("deck", "", "question sheet to load (or set "+envDeck+")")
+	flag.BoolVar(&guestMode, "guest", false, "start with fresh settings and progress and save nothing, for trying the app or demonstrating it")
+	flag.Parse()
+
+	if err := setupStorage(*portable, *profile); err != nil {
+		log.Fatalf("Failed to set up data folders: %v", err)
+	}
+	openLog()
+
+	rand.Seed(time.Now().UnixNano())
+	removeOldBinary()
+
+	// Load saved settings; guests start with the defaults
+	var err error
+	settings := &Settings{}
+	if !guestMode {
+		if settings, err = loadSettings(); err != nil {
+			log.Fatalf("Failed to load settings: %v", err)
+		}
+	}
 
-			optionsContainer.Add(button)
+	// A crash of the last run becomes a report, offered once the window is open
+	lastCrash := ""
+	if !guestMode {
+		if lastCrash, err = collectCrash(settings); err != nil {
+			log.Printf("Failed to save crash report: %v", err)
+		}
+		if err := catchCrashes(); err != nil {
+			log.Printf("Failed to set up crash reports: %v", err)
+		}
+	}
+
+	// Load study progress; encrypted progress is unlocked once the window is open
+	progress := &Progress{}
+	locked := false
+	if !guestMode {
+		if err := lockProgress(); err != nil {
+			log.Fatalf("Failed to open progress: %v", err)
+		}
+		progress, err = loadProgress("")
+		locked = errors.Is(err, errProgressLocked)
+		if locked {
+			progress = &Progress{locked: true}
+		} else if err != nil {
+			log.Fatalf("Failed to load progress: %v", err)
+		}
+	}
+
+	// Initialize Fyne application and window
+	a := app.New()
+	title := "Genki Quiz"
+	if guestMode {
+		title += " (Guest)"
+	}
+	w := a.NewWindow(title)
+	w.Resize(fyne.NewSize(500, 400))
+
+	// Initialize game state and UI elements
+	qa := &quizApp{
+		app:              a,
+		window:           w,
+		state:            &gameState{},
+		index:            newQuestionIndex(nil),
+		settings:         settings,
+		progress:         progress,
+		questionLabel:    canvas.NewText("", theme.TextColor()),
+		romajiLabel:      widget.NewLabel(""),
+		optionsContainer: container.NewVBox(),
+		scoreLabel:       widget.NewLabel(""),
+		answerInfo:       widget.NewLabel(""),
+		timer:            newQuestionTimer(),
+	}
+	qa.questionLabel.TextStyle = fyne.TextStyle{Bold: true}
+	qa.questionLabel.TextSize = questionTextSize
+	qa.verticalQuestion = newVerticalText(qa.questionLabel)
+
+	qa.updateRomaji()
+	qa.updateTimer()
+	qa.applyTheme()
+	qa.updateVerticalText()
+
+	if *kiosk {
+		if err := qa.enableKiosk(); err != nil {
+			log.Fatalf("Failed to start kiosk mode: %v", err)
 		}
-		optionsContainer.Refresh()
 	}
 
-	// Initialize and start application
-	questionContainer = container.NewVBox()
-	showChapterSelection()
-	w.SetContent(questionContainer)
+	// Load the deck while the window is up, then start the application
+	qa.router = newScreenRouter()
+	start := func() {
+		if !qa.kiosk {
+			qa.setupMainMenu()
+			qa.registerHotkey()
+		}
+		qa.registerHelpKey()
+		qa.showChapterSelection()
+		w.SetContent(qa.mainTabs())
+		qa.checkDeck()
+		if lastCrash != "" {
+			qa.offerCrashReport(lastCrash)
+		}
+	}
+	qa.loadDeckInBackground(*deckPath, func() {
+		if locked {
+			qa.showUnlock(start)
+		} else {
+			start()
+		}
+	})
+	w.SetMaster()
 	w.ShowAndRun()
 }
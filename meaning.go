@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// meaningDrillSize is the number of words in a typed meaning drill
+const meaningDrillSize = 10
+
+// glossSeparator separates the accepted meanings of a word in QAnswer, e.g. "to eat; to have a meal"
+const glossSeparator = ";"
+
+// leadingWords are left off the start of English answers unless answerRules.StrictArticles is set,
+// so "to eat" and "eat" or "a book" and "book" are the same answer
+var leadingWords = []string{"a", "an", "the", "to"}
+
+// answerRules decide which differences typed English answers may have from the deck
+type answerRules struct {
+	StrictCase        bool `json:"strict_case"`        // Capitals must match
+	StrictArticles    bool `json:"strict_articles"`    // "a", "an", "the" and the "to" of verbs must match
+	StrictPunctuation bool `json:"strict_punctuation"` // Punctuation must match
+}
+
+// glosses splits an answer into its accepted meanings
+func glosses(answer string) []string {
+	var accepted []string
+	for _, gloss := range strings.Split(answer, glossSeparator) {
+		if gloss = strings.TrimSpace(gloss); gloss != "" {
+			accepted = append(accepted, gloss)
+		}
+	}
+	return accepted
+}
+
+// answersOverlap reports whether two answers share a meaning, so that neither may be a
+// wrong option for the other, e.g. "to eat; to have a meal" and "eat"
+func answersOverlap(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var rules answerRules
+	for _, gloss := range glosses(a) {
+		if rules.matchesMeaning(gloss, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize rewrites an English answer so that differences the rules allow are gone
+func (r answerRules) normalize(answer string) string {
+	answer = width.Fold.String(answer) // Full-width letters from a Japanese keyboard are the usual ones
+	if !r.StrictCase {
+		answer = strings.ToLower(answer)
+	}
+	if !r.StrictPunctuation {
+		answer = strings.Map(func(c rune) rune {
+			switch {
+			case c == '.' || c == '\'' || c == '’':
+				return -1 // "U.S." is "US", "don't" is "dont"
+			case unicode.IsPunct(c):
+				return ' '
+			}
+			return c
+		}, answer)
+	}
+	words := strings.Fields(answer)
+	if !r.StrictArticles {
+		for len(words) > 1 && containsFold(leadingWords, words[0]) {
+			words = words[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// containsFold reports whether list holds word, ignoring case
+func containsFold(list []string, word string) bool {
+	for _, w := range list {
+		if strings.EqualFold(w, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMeaning reports whether a typed answer is one of the accepted meanings.
+// Answers written in kana, such as the readings of a kanji, may be typed in romaji.
+func (r answerRules) matchesMeaning(input, answer string) bool {
+	typed := r.normalize(input)
+	if typed == "" {
+		return false
+	}
+	for _, gloss := range glosses(answer) {
+		if r.normalize(gloss) == typed || isKana(gloss) && toHiragana(gloss) == typedKana(input) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMeaning grades a typed meaning under the learner's answer rules
+func (qa *quizApp) checkMeaning(q Question, input string) (bool, string) {
+	answer := fmt.Sprintf("%s (%s) means %s.", q.QHirakata, q.QRomaji, strings.Join(glosses(q.QAnswer), " / "))
+	if qa.settings.AnswerRules.matchesMeaning(input, q.QAnswer) {
+		return true, answer
+	}
+	return false, answer
+}
+
+// startMeaningDrill drills the current chapter by typing the English for the Japanese
+func (qa *quizApp) startMeaningDrill() {
+	words := append([]Question(nil), qa.newItemsPool(qa.state.chapterQuestions)...)
+	if len(words) == 0 && len(qa.state.chapterQuestions) > 0 {
+		qa.showNewItemsLimit()
+		return
+	}
+	rand.Shuffle(len(words), func(i, j int) {
+		words[i], words[j] = words[j], words[i]
+	})
+	if len(words) > meaningDrillSize {
+		words = words[:meaningDrillSize]
+	}
+
+	qa.startTypedDrill(&typedDrill{
+		name:      "Meanings",
+		questions: words,
+		english: func(Question) bool {
+			return true
+		},
+		prompt: func(q Question) string {
+			return q.QHirakata
+		},
+		answer: func(q Question) string {
+			return q.QAnswer
+		},
+		check: qa.checkMeaning,
+		closeness: func(q Question, input string) float64 {
+			return qa.settings.AnswerRules.meaningSimilarity(input, q.QAnswer)
+		},
+	})
+}
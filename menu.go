@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"github.com/xuri/excelize/v2"
+)
+
+// setupMainMenu installs the window's main menu
+func (qa *quizApp) setupMainMenu() {
+	fileMenu := fyne.NewMenu("File",
+		fyne.NewMenuItem("Open Deck…", qa.lockedByPIN("Open Deck", qa.openDeck)),
+		fyne.NewMenuItem("Save Deck As…", qa.lockedByPIN("Save Deck As", qa.saveDeck)),
+		fyne.NewMenuItem("Deck Change Log…", qa.showDeckChanges),
+		fyne.NewMenuItem("Reconcile Progress…", qa.lockedByPIN("Reconcile Progress", qa.showReconcile)),
+		fyne.NewMenuItem("Import Quizlet Set…", qa.lockedByPIN("Import Quizlet Set", qa.importQuizletSet)),
+		fyne.NewMenuItem("Import CSV…", qa.lockedByPIN("Import CSV", qa.importCSV)),
+		fyne.NewMenuItem("Export Quizlet Set…", qa.exportQuizletSet),
+		fyne.NewMenuItem("Import Preset…", qa.lockedByPIN("Import Preset", qa.importPreset)),
+		fyne.NewMenuItem("Export Mistake Notebook…", qa.exportMistakes),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Open Assignment…", qa.lockedByPIN("Open Assignment", qa.openAssignment)),
+	)
+
+	// Quiz actions abandon any quiz in progress and switch to the quiz tab first
+	onQuizTab := func(action func()) func() {
+		return func() {
+			qa.state.reset()
+			qa.tabs.SelectIndex(0)
+			action()
+		}
+	}
+	// Toggles that save settings ask for the settings lock PIN like the settings tab does
+	qa.reverseMenuItem = fyne.NewMenuItem("Reverse (English → Japanese)", qa.lockedByPIN("Reverse", func() {
+		qa.setReverse(!qa.settings.Reverse)
+	}))
+	qa.reverseMenuItem.Checked = qa.settings.Reverse
+	quizMenu := fyne.NewMenu("Quiz",
+		fyne.NewMenuItem("Review Due Items", onQuizTab(qa.startDueReview)),
+		fyne.NewMenuItem("Daily Challenge", onQuizTab(qa.startDailyChallenge)),
+		fyne.NewMenuItem("Quick Quiz", onQuizTab(qa.startQuickQuiz)),
+		fyne.NewMenuItem("Practice Test…", onQuizTab(qa.showPracticeTestSelection)),
+		fyne.NewMenuItem("Placement Test", onQuizTab(qa.startAdaptiveTest)),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Kana Typing Tutor", onQuizTab(qa.showKanaTutor)),
+		fyne.NewMenuItem("Confusable Pairs", onQuizTab(qa.showConfusablePairs)),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Chapter Selection", onQuizTab(qa.showChapterSelection)),
+		fyne.NewMenuItem("Quiz Starred Questions", onQuizTab(qa.startStarredQuiz)),
+		fyne.NewMenuItem("Starred Questions…", qa.showStarred),
+		fyne.NewMenuItem("Suspended Questions…", qa.showSuspended),
+		fyne.NewMenuItem("Mastered Archive…", qa.showArchive),
+		fyne.NewMenuItemSeparator(),
+		qa.reverseMenuItem,
+	)
+
+	qa.romajiMenuItem = fyne.NewMenuItem("Show Romaji", qa.lockedByPIN("Show Romaji", func() {
+		qa.setShowRomaji(!qa.settings.ShowRomaji)
+	}))
+	qa.romajiMenuItem.Checked = qa.settings.ShowRomaji
+	qa.timerMenuItem = fyne.NewMenuItem("Show Question Timer", qa.lockedByPIN("Show Question Timer", func() {
+		qa.setShowTimer(qa.settings.HideTimer)
+	}))
+	qa.timerMenuItem.Checked = !qa.settings.HideTimer
+	themeItems := map[string]*fyne.MenuItem{
+		"":      fyne.NewMenuItem("System", nil),
+		"light": fyne.NewMenuItem("Light", nil),
+		"dark":  fyne.NewMenuItem("Dark", nil),
+	}
+	for name, item := range themeItems {
+		name := name
+		item.Checked = qa.settings.Theme == name
+		item.Action = qa.lockedByPIN("Theme", func() {
+			qa.setTheme(name)
+			for other, otherItem := range themeItems {
+				otherItem.Checked = other == name
+			}
+			qa.window.MainMenu().Refresh()
+		})
+	}
+	qa.verticalMenuItem = fyne.NewMenuItem("Vertical Question Text", qa.lockedByPIN("Vertical Question Text", func() {
+		qa.setVerticalText(!qa.settings.VerticalText)
+	}))
+	qa.verticalMenuItem.Checked = qa.settings.VerticalText
+	qa.furiganaMenuItem = fyne.NewMenuItem("Furigana on Options", qa.lockedByPIN("Furigana on Options", func() {
+		qa.setShowOptionFurigana(!qa.settings.OptionFurigana)
+	}))
+	qa.furiganaMenuItem.Checked = qa.settings.OptionFurigana
+	themeItem := fyne.NewMenuItem("Theme", nil)
+	themeItem.ChildMenu = fyne.NewMenu("", themeItems[""], themeItems["light"], themeItems["dark"])
+
+	miniItem := fyne.NewMenuItem("Mini Widget", nil)
+	viewMenu := fyne.NewMenu("View",
+		qa.romajiMenuItem,
+		qa.timerMenuItem,
+		qa.verticalMenuItem,
+		qa.furiganaMenuItem,
+		themeItem,
+		fyne.NewMenuItemSeparator(),
+		miniItem,
+		fyne.NewMenuItem("Statistics Window", qa.openStatsWindow),
+	)
+	miniItem.Action = func() {
+		qa.toggleMiniWidget()
+		miniItem.Checked = qa.mini != nil
+		viewMenu.Refresh()
+	}
+
+	helpMenu := fyne.NewMenu("Help",
+		fyne.NewMenuItem("Genki Quiz Help (F1)", qa.showHelp),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("About Genki Quiz", qa.showAbout),
+	)
+
+	for _, menu := range []*fyne.Menu{fileMenu, quizMenu, viewMenu, helpMenu} {
+		qa.serializeMenu(menu)
+	}
+	qa.window.SetMainMenu(fyne.NewMainMenu(fileMenu, quizMenu, viewMenu, helpMenu))
+}
+
+// serializeMenu makes the items of a menu and its submenus hold the UI lock, as quiz
+// actions reset the quiz that an advance timer may be moving on meanwhile
+func (qa *quizApp) serializeMenu(menu *fyne.Menu) {
+	for _, item := range menu.Items {
+		if item.Action != nil {
+			item.Action = qa.serialized(item.Action)
+		}
+		if item.ChildMenu != nil {
+			qa.serializeMenu(item.ChildMenu)
+		}
+	}
+}
+
+// startQuickQuiz starts a mini quiz over every selectable chapter
+func (qa *quizApp) startQuickQuiz() {
+	state := qa.state
+	state.chapterQuestions = qa.studyChapters(qa.availableChapters())
+	state.currentChapter = "All"
+	state.quizName = "Quick Quiz"
+	state.totalQuestions = min(10, len(state.chapterQuestions))
+	if state.totalQuestions == 0 {
+		return
+	}
+	qa.startQuiz()
+}
+
+// openDeck replaces the questions with another Excel deck
+func (qa *quizApp) openDeck() {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+		defer reader.Close()
+
+		// Kept to look into the workbook if it gives no questions
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		name := reader.URI().Name()
+		f, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			qa.showDeckTroubleshooting(name, err, diagnoseDeck(bytes.NewReader(data)))
+			return
+		}
+		qa.readDeckConfirmed(f, name, func(questions []Question, err error) {
+			f.Close()
+			if errors.Is(err, errColumnsCancelled) {
+				return
+			}
+			if err != nil || len(questions) == 0 {
+				qa.showDeckTroubleshooting(name, err, diagnoseDeck(bytes.NewReader(data)))
+				return
+			}
+			qa.serialized(func() {
+				qa.setQuestions(questions)
+				qa.deckName = name
+				qa.state.reset()
+				qa.deckChanged()
+			})()
+			qa.checkDeck()
+		})
+	}, qa.window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".xlsx"}))
+	open.Show()
+}
+
+// deckChanged rebuilds the screens that show the deck after a new one was opened
+func (qa *quizApp) deckChanged() {
+	qa.browseTab.Content = qa.browseContent()
+	qa.statsTab.Content = qa.statsTabContent()
+	qa.tabs.Refresh()
+	qa.tabs.SelectIndex(0)
+	qa.showChapterSelection()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"math/rand"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// miniWidgetSize is the size of the compact quiz window
+var miniWidgetSize = fyne.NewSize(280, 170)
+
+// miniWidget is a tiny always-on-top window asking one question at a time
+type miniWidget struct {
+	qa       *quizApp
+	window   fyne.Window
+	pool     []Question
+	answers  *answerPool // Answers of pool
+	question *canvas.Text
+	options  *fyne.Container
+}
+
+// toggleMiniWidget opens the mini widget, or closes it if it is already open
+func (qa *quizApp) toggleMiniWidget() {
+	if qa.mini != nil {
+		qa.mini.window.Close()
+		return
+	}
+
+	pool := qa.state.chapterQuestions
+	if len(pool) == 0 {
+		pool = qa.studyChapters(qa.availableChapters())
+	}
+	if len(pool) == 0 {
+		return
+	}
+
+	m := &miniWidget{
+		qa:       qa,
+		window:   qa.app.NewWindow("Genki Mini"),
+		pool:     pool,
+		answers:  newAnswerPool(pool),
+		question: canvas.NewText("", theme.ForegroundColor()),
+		options:  container.NewGridWithColumns(2),
+	}
+	m.question.TextStyle = fyne.TextStyle{Bold: true}
+	qa.japaneseText(m.question, 18)
+	m.question.Alignment = fyne.TextAlignCenter
+
+	m.window.SetContent(container.NewBorder(m.question, nil, nil, nil, m.options))
+	m.window.Resize(miniWidgetSize)
+	m.window.SetFixedSize(true)
+	m.window.SetOnClosed(func() {
+		qa.mini = nil
+	})
+	qa.mini = m
+
+	m.next()
+	m.window.Show()
+	setAlwaysOnTop(m.window)
+}
+
+// next shows a new random question
+func (m *miniWidget) next() {
+	q := m.pool[rand.Intn(len(m.pool))]
+	m.question.Text = q.QHirakata
+	m.question.Refresh()
+
+	options := append(m.answers.random(q.QAnswer, 3), q.QAnswer)
+	rand.Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
+
+	m.options.Objects = nil
+	buttons := make([]*widget.Button, len(options))
+	for i, opt := range options {
+		opt := opt
+		buttons[i] = widget.NewButton(opt, m.qa.serialized(func() {
+			m.qa.recordAnswer(q, opt)
+			for j, button := range buttons {
+				if options[j] == q.QAnswer {
+					button.Importance = widget.SuccessImportance
+				} else if options[j] == opt {
+					button.Importance = widget.DangerImportance
+				}
+				button.OnTapped = nil
+				button.Refresh()
+			}
+			time.AfterFunc(1500*time.Millisecond, m.qa.serialized(func() {
+				if m.qa.mini == m {
+					m.next()
+				}
+			}))
+		}))
+		m.options.Add(buttons[i])
+	}
+	m.options.Refresh()
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+// maxMistakes is how many misses the mistake notebook keeps, dropping the oldest
+const maxMistakes = 1000
+
+// mistake is one miss in the mistake notebook
+type mistake struct {
+	QID      string    `json:"qid"`
+	Question string    `json:"question"` // As asked, kept in case the deck changes
+	Romaji   string    `json:"romaji,omitempty"`
+	Picked   string    `json:"picked"` // The wrong answer given
+	Answer   string    `json:"answer"` // The right answer
+	At       time.Time `json:"at"`
+}
+
+// recordMistake adds a miss to the mistake notebook
+func (p *Progress) recordMistake(m mistake) {
+	m.At = time.Now().Truncate(time.Second)
+	p.Mistakes = append(p.Mistakes, m)
+	if len(p.Mistakes) > maxMistakes {
+		p.Mistakes = p.Mistakes[len(p.Mistakes)-maxMistakes:]
+	}
+}
+
+// mistakeHeader is the header row of the mistake notebook as CSV
+var mistakeHeader = []string{"date", "qid", "question", "romaji", "my_answer", "correct_answer"}
+
+// writeMistakesCSV writes the mistake notebook as CSV, one miss per row
+func writeMistakesCSV(w io.Writer, mistakes []mistake) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(mistakeHeader); err != nil {
+		return err
+	}
+	for _, m := range mistakes {
+		if err := cw.Write([]string{m.At.Format(time.RFC3339), m.QID, m.Question, m.Romaji, m.Picked, m.Answer}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// markdownCell escapes text for a Markdown table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// writeMistakesMarkdown writes the mistake notebook as Markdown, a table per day like a paper notebook
+func writeMistakesMarkdown(w io.Writer, mistakes []mistake) error {
+	var b strings.Builder
+	b.WriteString("# Mistake Notebook\n")
+	day := ""
+	for _, m := range mistakes {
+		if d := m.At.Format(dateLayout); d != day {
+			day = d
+			fmt.Fprintf(&b, "\n## %s\n\n| Question | My Answer | Correct Answer |\n| --- | --- | --- |\n", day)
+		}
+		question := m.Question
+		if m.Romaji != "" {
+			question += " (" + m.Romaji + ")"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", markdownCell(question), markdownCell(m.Picked), markdownCell(m.Answer))
+	}
+	if len(mistakes) == 0 {
+		b.WriteString("\nNo mistakes yet.\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// exportMistakes saves the mistake notebook as Markdown, or as CSV if the file name ends in .csv
+func (qa *quizApp) exportMistakes() {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		write := writeMistakesMarkdown
+		if strings.EqualFold(path.Ext(writer.URI().Name()), ".csv") {
+			write = writeMistakesCSV
+		}
+		if err := write(writer, qa.progress.Mistakes); err != nil {
+			dialog.ShowError(err, qa.window)
+		}
+	}, qa.window)
+	save.SetFileName("mistake-notebook.md")
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".md", ".csv"}))
+	save.Show()
+}
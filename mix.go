@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// questionMix gives the share of each question category in a session, in percent.
+// An empty mix draws from the pool as it is.
+type questionMix map[string]int
+
+// String describes the mix, e.g. "60% vocab, 40% verb"
+func (m questionMix) String() string {
+	categories := make([]string, 0, len(m))
+	for category, share := range m {
+		if share > 0 {
+			categories = append(categories, category)
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if m[categories[i]] != m[categories[j]] {
+			return m[categories[i]] > m[categories[j]]
+		}
+		return categories[i] < categories[j]
+	})
+	if len(categories) == 0 {
+		return "as in the deck"
+	}
+	parts := make([]string, len(categories))
+	for i, category := range categories {
+		parts[i] = fmt.Sprintf("%d%% %s", m[category], category)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// deckCategories returns the question categories of a deck, sorted
+func deckCategories(questions []Question) []string {
+	seen := make(map[string]bool)
+	var categories []string
+	for _, q := range questions {
+		if category := questionCategory(q); !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// nextMixCategory picks the category of the next question: the one furthest behind its
+// share, given how many questions of each category were drawn so far. Categories the
+// pool has no questions of are left out. ok is false if the mix has none of the pool's categories.
+func nextMixCategory(pool []Question, mix questionMix, drawn map[string]int) (category string, ok bool) {
+	available := make(map[string]bool)
+	for _, q := range pool {
+		available[questionCategory(q)] = true
+	}
+	total, sum := 0, 0
+	for c, share := range mix {
+		if available[c] && share > 0 {
+			sum += share
+			total += drawn[c]
+		}
+	}
+	if sum == 0 {
+		return "", false
+	}
+	best := -1.0
+	for c, share := range mix {
+		if !available[c] || share <= 0 {
+			continue
+		}
+		deficit := float64(share)/float64(sum)*float64(total+1) - float64(drawn[c])
+		if deficit > best || (deficit == best && c < category) {
+			category, best = c, deficit
+		}
+	}
+	return category, true
+}
+
+// drawMixed draws a random question of the pool, keeping to the session's mix
+func (s *gameState) drawMixed(pool []Question, mix questionMix) Question {
+	category, ok := nextMixCategory(pool, mix, s.mixDrawn)
+	if !ok {
+		return pool[rand.Intn(len(pool))]
+	}
+	var candidates []Question
+	for _, q := range pool {
+		if questionCategory(q) == category {
+			candidates = append(candidates, q)
+		}
+	}
+	if s.mixDrawn == nil {
+		s.mixDrawn = make(map[string]int)
+	}
+	s.mixDrawn[category]++
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// showMixDialog lets the learner set the share of each category in a session
+func (qa *quizApp) showMixDialog(onSaved func()) {
+	categories := deckCategories(qa.questions)
+	entries := make(map[string]*widget.Entry)
+	form := widget.NewForm()
+	for _, category := range categories {
+		entry := widget.NewEntry()
+		entry.SetPlaceHolder("0")
+		if share := qa.settings.QuestionMix[category]; share > 0 {
+			entry.SetText(strconv.Itoa(share))
+		}
+		entries[category] = entry
+		form.Append(category+" (%)", entry)
+	}
+	content := widget.NewLabel("Leave every field empty to draw questions as they come in the deck.")
+	content.Wrapping = fyne.TextWrapWord
+
+	d := dialog.NewCustomConfirm("Question Mix", "Save", "Cancel", container.NewVBox(content, form), func(ok bool) {
+		if !ok {
+			return
+		}
+		mix := questionMix{}
+		sum := 0
+		for category, entry := range entries {
+			text := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(entry.Text), "%"))
+			if text == "" {
+				continue
+			}
+			share, err := strconv.Atoi(text)
+			if err != nil || share < 0 || share > 100 {
+				dialog.ShowInformation("Question Mix", "Each share must be a percentage from 0 to 100.", qa.window)
+				return
+			}
+			if share > 0 {
+				mix[category] = share
+				sum += share
+			}
+		}
+		if sum != 0 && sum != 100 {
+			dialog.ShowInformation("Question Mix", fmt.Sprintf("The shares add up to %d%%; they must add up to 100%%.", sum), qa.window)
+			return
+		}
+		if sum == 0 {
+			mix = nil
+		}
+		qa.settings.QuestionMix = mix
+		if err := qa.settings.save(); err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		onSaved()
+	}, qa.window)
+	d.Resize(fyne.NewSize(360, 0))
+	d.Show()
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// defaultNearMiss is the similarity in percent from which a wrong typed answer is a near miss
+const defaultNearMiss = 75
+
+// Near miss similarity limits offered in the settings, in percent
+const (
+	minNearMiss = 50
+	maxNearMiss = 99
+)
+
+// nearMissSettings decide how typed answers that are almost right are graded
+type nearMissSettings struct {
+	Off           bool `json:"off"`            // Grade near misses as wrong
+	Threshold     int  `json:"threshold"`      // Similarity in percent, 0 for defaultNearMiss
+	PartialCredit bool `json:"partial_credit"` // Give half a point instead of asking to check the spelling
+}
+
+// Grading choices for near misses in the settings
+const (
+	nearMissRetry = "Ask to check the spelling"
+	nearMissHalf  = "Give half a point"
+)
+
+// threshold returns the similarity from which an answer is a near miss, from 0 to 1
+func (n nearMissSettings) threshold() float64 {
+	if n.Threshold == 0 {
+		return defaultNearMiss / 100.0
+	}
+	return float64(n.Threshold) / 100
+}
+
+// halfCostKana are typed or left out by mistake so often that they count as half a typo
+const halfCostKana = "っー"
+
+// largeKana folds a small kana to its full size
+var largeKana = strings.NewReplacer(
+	"ぁ", "あ", "ぃ", "い", "ぅ", "う", "ぇ", "え", "ぉ", "お",
+	"っ", "つ", "ゃ", "や", "ゅ", "ゆ", "ょ", "よ", "ゎ", "わ",
+)
+
+// kanaBase folds a kana to its plain form, without dakuten, handakuten or small size
+func kanaBase(kana string) string {
+	decomposed := norm.NFD.String(toHiragana(kana))
+	decomposed = strings.NewReplacer("\u3099", "", "\u309a", "").Replace(decomposed)
+	return largeKana.Replace(decomposed)
+}
+
+// kanaCost is the cost of typing one kana for another: half a typo for the same kana
+// with other marks or size, like は/ば or よ/ょ
+func kanaCost(a, b string) float64 {
+	switch {
+	case a == b:
+		return 0
+	case kanaBase(a) == kanaBase(b):
+		return 0.5
+	}
+	return 1
+}
+
+// kanaGapCost is the cost of leaving out or adding a kana: half a typo for っ and ー
+func kanaGapCost(kana string) float64 {
+	if strings.Contains(halfCostKana, kana) {
+		return 0.5
+	}
+	return 1
+}
+
+// weightedDistance is the edit distance between two sequences with the given costs.
+// Swapping two neighbours, as in "eta" for "eat", is one typo.
+func weightedDistance(a, b []string, sub func(a, b string) float64, gap func(string) float64) float64 {
+	d := make([][]float64, len(a)+1)
+	for i := range d {
+		d[i] = make([]float64, len(b)+1)
+		if i > 0 {
+			d[i][0] = d[i-1][0] + gap(a[i-1])
+		}
+	}
+	for j := 1; j <= len(b); j++ {
+		d[0][j] = d[0][j-1] + gap(b[j-1])
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			d[i][j] = min(d[i-1][j]+gap(a[i-1]), d[i][j-1]+gap(b[j-1]), d[i-1][j-1]+sub(a[i-1], b[j-1]))
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && a[i-1] != a[i-2] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// similarity turns a distance over sequences of the given lengths into a share from 0 to 1
+func similarity(distance float64, a, b int) float64 {
+	if a == 0 && b == 0 {
+		return 1
+	}
+	return max(0, 1-distance/float64(max(a, b)))
+}
+
+// kanaSimilarity compares two kana spellings kana by kana, see kanaCost and kanaGapCost
+func kanaSimilarity(expected, typed string) float64 {
+	want, got := strings.Split(toHiragana(expected), ""), strings.Split(toHiragana(typed), "")
+	return similarity(weightedDistance(want, got, kanaCost, kanaGapCost), len(want), len(got))
+}
+
+// latinSimilarity compares two English answers letter by letter
+func latinSimilarity(expected, typed string) float64 {
+	want, got := strings.Split(expected, ""), strings.Split(typed, "")
+	letter := func(a, b string) float64 {
+		if a == b {
+			return 0
+		}
+		return 1
+	}
+	gap := func(string) float64 { return 1 }
+	return similarity(weightedDistance(want, got, letter, gap), len(want), len(got))
+}
+
+// meaningSimilarity compares a typed meaning with the closest accepted meaning
+func (r answerRules) meaningSimilarity(input, answer string) float64 {
+	best := 0.0
+	typed := r.normalize(input)
+	for _, gloss := range glosses(answer) {
+		if isKana(gloss) {
+			best = max(best, kanaSimilarity(gloss, typedKana(input)))
+			continue
+		}
+		best = max(best, latinSimilarity(r.normalize(gloss), typed))
+	}
+	return best
+}
+
+// nearMiss reports whether a wrong typed answer is close enough to be a near miss
+func (d *typedDrill) nearMiss(q Question, input string) bool {
+	settings := d.qa.settings.NearMiss
+	return d.closeness != nil && !settings.Off && d.closeness(q, input) >= settings.threshold()
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKanaSimilarity(t *testing.T) {
+	tests := []struct {
+		expected, typed string
+		want            float64
+	}{
+		{"がっこう", "がっこう", 1},
+		{"がっこう", "ガッコウ", 1},
+		{"がっこう", "がこう", 0.875},  // Left out っ, half a typo
+		{"がっこう", "かっこう", 0.875}, // Missing dakuten, half a typo
+		{"がっこう", "がっこお", 0.75},  // Other kana
+		{"がっこう", "がっうこ", 0.75},  // Swapped neighbours
+		{"がっこう", "", 0.125},     // Everything left out, っ at half cost
+	}
+	for _, tt := range tests {
+		if got := kanaSimilarity(tt.expected, tt.typed); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("kanaSimilarity(%q, %q) = %v, want %v", tt.expected, tt.typed, got, tt.want)
+		}
+	}
+}
+
+func TestMeaningSimilarity(t *testing.T) {
+	tests := []struct {
+		input, answer string
+		want          float64
+	}{
+		{"Teacher", "teacher", 1},
+		{"teahcer", "teacher", 1 - 1.0/7},
+		{"studnet", "teacher; student", 1 - 1.0/7},
+		{"xyz", "teacher", 0},
+	}
+	for _, tt := range tests {
+		if got := (answerRules{}).meaningSimilarity(tt.input, tt.answer); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("meaningSimilarity(%q, %q) = %v, want %v", tt.input, tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestNearMissThreshold(t *testing.T) {
+	if got := (nearMissSettings{}).threshold(); got != 0.75 {
+		t.Errorf("default threshold %v, want 0.75", got)
+	}
+	if got := (nearMissSettings{Threshold: 90}).threshold(); got != 0.9 {
+		t.Errorf("threshold %v, want 0.9", got)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+)
+
+// newItemsOn counts the items first answered on the day of t
+func (p *Progress) newItemsOn(t time.Time) int {
+	y, m, d := t.Date()
+	count := 0
+	for _, s := range p.Items {
+		if fy, fm, fd := s.FirstSeen.Date(); !s.FirstSeen.IsZero() && fy == y && fm == m && fd == d {
+			count++
+		}
+	}
+	return count
+}
+
+// limitNewItems keeps the items of a pool that were answered before, and the never-seen ones,
+// in pool order, that can still be introduced today without going over their limit (0 for none)
+func limitNewItems(pool []Question, progress *Progress, limit func(Question) int, now time.Time) []Question {
+	introduced := progress.newItemsOn(now)
+	var limited []Question
+	for _, q := range pool {
+		if progress.stats(q.QID) != nil {
+			limited = append(limited, q)
+		} else if l := limit(q); l <= 0 || introduced < l {
+			limited = append(limited, q)
+			introduced++
+		}
+	}
+	return limited
+}
+
+// newItemsPool applies the new items per day of each question's schedule to a pool questions
+// are drawn from as the quiz goes. Quizzes with a fixed set of questions, such as tests and
+// challenges, are not limited.
+func (qa *quizApp) newItemsPool(pool []Question) []Question {
+	return limitNewItems(pool, qa.progress, func(q Question) int {
+		return qa.schedule(q.QChapter).NewPerDay
+	}, time.Now())
+}
+
+// showNewItemsLimit explains that a quiz has only new items left when no more may be started today
+func (qa *quizApp) showNewItemsLimit() {
+	dialog.ShowInformation("New Items",
+		fmt.Sprintf("You have started %d new items today, your daily limit.\nReview the items you have started, or change the limit in Settings.", qa.progress.newItemsOn(time.Now())),
+		qa.window)
+}
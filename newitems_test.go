@@ -0,0 +1,54 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLimitNewItems(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 0, 0, 0, time.Local)
+	progress := &Progress{Items: map[string]*itemStats{
+		"seen-today":     {Seen: 1, FirstSeen: now.Add(-time.Hour)},
+		"seen-yesterday": {Seen: 1, FirstSeen: now.AddDate(0, 0, -1)},
+	}}
+	pool := []Question{
+		{QID: "new-1", QChapter: "1"},
+		{QID: "seen-today", QChapter: "1"},
+		{QID: "new-2", QChapter: "1"},
+		{QID: "new-3", QChapter: "2"},
+		{QID: "seen-yesterday", QChapter: "1"},
+		{QID: "new-4", QChapter: "1"},
+	}
+	qids := func(questions []Question) []string {
+		var ids []string
+		for _, q := range questions {
+			ids = append(ids, q.QID)
+		}
+		return ids
+	}
+
+	if got := progress.newItemsOn(now); got != 1 {
+		t.Errorf("newItemsOn = %d, want 1", got)
+	}
+	tests := []struct {
+		name  string
+		limit func(Question) int
+		want  []string
+	}{
+		{"no limit", func(Question) int { return 0 }, qids(pool)},
+		{"reached", func(Question) int { return 1 }, []string{"seen-today", "seen-yesterday"}},
+		{"one left", func(Question) int { return 2 }, []string{"new-1", "seen-today", "seen-yesterday"}},
+		{"per chapter", func(q Question) int {
+			if q.QChapter == "2" {
+				return 0
+			}
+			return 2
+		}, []string{"new-1", "seen-today", "new-3", "seen-yesterday"}},
+	}
+	for _, tt := range tests {
+		if got := qids(limitNewItems(pool, progress, tt.limit, now)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: limitNewItems = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
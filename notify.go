@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// idleTimeout is how long a quiz can sit unanswered before the learner is reminded
+const idleTimeout = 5 * time.Minute
+
+// reviewInterval is the time until an item should be reviewed again, doubling with
+// every correct answer in a row (1, 2, 4, 8… days) at the default ease; missed items
+// are due right away
+func reviewInterval(streak int, ease float64) time.Duration {
+	return defaultSchedule.interval(streak, ease)
+}
+
+// dueAt returns when the item should be reviewed next, by the schedule of its chapter
+// when it was last answered
+func (s *itemStats) dueAt() time.Time {
+	if !s.Due.IsZero() {
+		return s.Due
+	}
+	return s.LastSeen.Add(reviewInterval(s.Streak, s.ease()))
+}
+
+// countDue counts studied questions that are due for review by the given time
+func countDue(questions []Question, progress *Progress, by time.Time) int {
+	due := 0
+	for _, q := range questions {
+		if stats := progress.stats(q.QID); stats != nil && !stats.dueAt().After(by) {
+			due++
+		}
+	}
+	return due
+}
+
+// endOfDay returns midnight at the end of the given day
+func endOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+}
+
+// notify sends a desktop notification unless notifications are muted
+func (qa *quizApp) notify(title, content string) {
+	if qa.settings.MuteNotifications {
+		return
+	}
+	qa.app.SendNotification(fyne.NewNotification(title, content))
+}
+
+// dueTomorrowText describes tomorrow's review load
+func (qa *quizApp) dueTomorrowText() string {
+	tomorrow := endOfDay(time.Now().AddDate(0, 0, 1))
+	due := countDue(qa.studyQuestions(), qa.progress, tomorrow)
+	if due == 0 {
+		return "Nothing is due for review tomorrow."
+	}
+	return fmt.Sprintf("%d item(s) due for review tomorrow.", due)
+}
+
+// notifySessionEnd summarizes a finished quiz
+func (qa *quizApp) notifySessionEnd(result quizResult) {
+	qa.notify("Genki Quiz — session complete",
+		fmt.Sprintf("%s: %d/%d (%.0f%%). %s", result.Quiz, result.Score, result.Total, result.percent(), qa.dueTomorrowText()))
+}
+
+// resetIdleTimer restarts the reminder for a quiz left unanswered
+func (qa *quizApp) resetIdleTimer() {
+	qa.stopIdleTimer()
+	state := qa.state
+	qa.idleTimer = time.AfterFunc(idleTimeout, qa.serialized(func() {
+		qa.notify("Genki Quiz — quiz paused",
+			fmt.Sprintf("You left %s at %d/%d answered (score %d). %s",
+				state.quizName, state.questionsAsked, state.totalQuestions, state.score, qa.dueTomorrowText()))
+	}))
+}
+
+// stopIdleTimer cancels the idle reminder
+func (qa *quizApp) stopIdleTimer() {
+	if qa.idleTimer != nil {
+		qa.idleTimer.Stop()
+		qa.idleTimer = nil
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package main
+
+import "fyne.io/fyne/v2"
+
+// setAlwaysOnTop keeps a window above all others. Fyne has no portable way to do this,
+// so on these platforms the window manager's own "always on top" option has to be used.
+func setAlwaysOnTop(w fyne.Window) {}
@@ -0,0 +1,35 @@
+//go:build windows
+
+package main
+
+import (
+	"syscall"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+)
+
+var procSetWindowPos = syscall.NewLazyDLL("user32.dll").NewProc("SetWindowPos")
+
+// Flags for SetWindowPos, see the Win32 documentation
+const (
+	hwndTopmost   = ^uintptr(0) // HWND_TOPMOST (-1)
+	swpNoSize     = 0x0001
+	swpNoMove     = 0x0002
+	swpNoActive   = 0x0010
+	swpShowWindow = 0x0040
+)
+
+// setAlwaysOnTop keeps a window above all others
+func setAlwaysOnTop(w fyne.Window) {
+	native, ok := w.(driver.NativeWindow)
+	if !ok {
+		return
+	}
+	native.RunNative(func(context any) {
+		if win, ok := context.(driver.WindowsWindowContext); ok {
+			procSetWindowPos.Call(win.HWND, hwndTopmost, 0, 0, 0, 0,
+				swpNoSize|swpNoMove|swpNoActive|swpShowWindow)
+		}
+	})
+}
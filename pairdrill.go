@@ -0,0 +1,182 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"log"
+	"math/rand"
+	"sort"
+)
+
+//go:embed data/confusable_pairs.json
+var curatedPairsJSON []byte
+
+// pairDrillTarget is how many correct answers in a row each side of a pair needs
+const pairDrillTarget = 3
+
+// pairDrillMaxPairs limits the number of pairs in one drill session
+const pairDrillMaxPairs = 5
+
+// pairDrillMaxQuestions stops a drill that is not converging
+const pairDrillMaxQuestions = 60
+
+// curatedPairs returns the bundled look-alike/sound-alike kana pairs
+func curatedPairs() [][2]string {
+	var pairs [][2]string
+	if err := json.Unmarshal(curatedPairsJSON, &pairs); err != nil {
+		log.Printf("Invalid bundled confusable pairs: %v", err)
+	}
+	return pairs
+}
+
+// editDistance is the Levenshtein distance between two strings, counted in runes
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// lookAlikePairs finds curated pairs present in the deck, plus words whose kana differ by a
+// single character (a small っ, a long vowel, a dakuten…)
+func lookAlikePairs(questions []Question) [][2]Question {
+	byKana := make(map[string]Question)
+	for _, q := range questions {
+		byKana[q.QHirakata] = q
+	}
+
+	var pairs [][2]Question
+	seen := make(map[[2]string]bool)
+	add := func(a, b Question) {
+		key := [2]string{a.QID, b.QID}
+		if a.QID > b.QID {
+			key = [2]string{b.QID, a.QID}
+		}
+		if a.QID == b.QID || a.QAnswer == b.QAnswer || seen[key] {
+			return
+		}
+		seen[key] = true
+		pairs = append(pairs, [2]Question{a, b})
+	}
+
+	for _, pair := range curatedPairs() {
+		a, okA := byKana[pair[0]]
+		b, okB := byKana[pair[1]]
+		if okA && okB {
+			add(a, b)
+		}
+	}
+	for i, a := range questions {
+		for _, b := range questions[i+1:] {
+			if len([]rune(a.QHirakata)) >= 2 && editDistance(a.QHirakata, b.QHirakata) == 1 {
+				add(a, b)
+			}
+		}
+	}
+	return pairs
+}
+
+// pairDrill alternates between the two words of each pair until both sides
+// have been answered correctly pairDrillTarget times in a row
+type pairDrill struct {
+	pairs   [][2]Question
+	current int            // Index of the pair being drilled
+	side    int            // Which word of the pair comes next
+	streak  map[string]int // Correct answers in a row by QID
+}
+
+// next returns the next question, moving on to the next pair once both sides are learned
+func (d *pairDrill) next() (Question, bool) {
+	for d.current < len(d.pairs) {
+		pair := d.pairs[d.current]
+		if d.streak[pair[0].QID] >= pairDrillTarget && d.streak[pair[1].QID] >= pairDrillTarget {
+			d.current++
+			d.side = 0
+			continue
+		}
+		q := pair[d.side]
+		d.side = 1 - d.side
+		return q, true
+	}
+	return Question{}, false
+}
+
+// answer updates the streak of an answered word
+func (d *pairDrill) answer(q Question, correct bool) {
+	if correct {
+		d.streak[q.QID]++
+	} else {
+		d.streak[q.QID] = 0
+	}
+}
+
+// distractors always includes the other word of the pair, so the learner must tell them apart
+func (d *pairDrill) distractors(pool *answerPool, progress *Progress) func(Question) []string {
+	return func(q Question) []string {
+		pair := d.pairs[min(d.current, len(d.pairs)-1)]
+		partner := pair[0]
+		if partner.QID == q.QID {
+			partner = pair[1]
+		}
+		var options []string
+		if !answersOverlap(partner.QAnswer, q.QAnswer) {
+			options = append(options, partner.QAnswer)
+		}
+		for _, answer := range getDistractors(pool, q, 3, progress) {
+			if len(options) < 3 && !overlapsAny(answer, options) {
+				options = append(options, answer)
+			}
+		}
+		return options
+	}
+}
+
+// startPairDrill drills the given pairs of easily confused words
+func (qa *quizApp) startPairDrill(name string, pairs [][2]Question) {
+	if len(pairs) == 0 {
+		return
+	}
+	pairs = append([][2]Question(nil), pairs...)
+	rand.Shuffle(len(pairs), func(i, j int) {
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	})
+	if len(pairs) > pairDrillMaxPairs {
+		pairs = pairs[:pairDrillMaxPairs]
+	}
+
+	chapters := make(map[string]bool)
+	for _, pair := range pairs {
+		chapters[pair[0].QChapter] = true
+		chapters[pair[1].QChapter] = true
+	}
+	var chapterList []string
+	for chapter := range chapters {
+		chapterList = append(chapterList, chapter)
+	}
+	sort.Strings(chapterList)
+
+	state := qa.state
+	drill := &pairDrill{pairs: pairs, streak: make(map[string]int)}
+	state.chapterQuestions = qa.studyChapters(chapterList)
+	state.totalQuestions = pairDrillMaxQuestions
+	state.currentChapter = name
+	state.quizName = name
+	state.nextQuestion = drill.next
+	state.onAnswer = drill.answer
+	state.pickDistractors = drill.distractors(state.answerPool(), qa.progress)
+	qa.startQuiz()
+}
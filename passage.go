@@ -0,0 +1,269 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+//go:embed data/listening_passages.json
+var bundledPassagesJSON []byte
+
+// passageFile holds custom listening passages, loaded from the data folder if present
+const passageFile = "passages.json"
+
+// ListeningPassage is a short dialogue or announcement heard once or a few times and then
+// asked about, like the listening sections of the workbook
+type ListeningPassage struct {
+	ID          string                  `json:"id"` // Names its recording in an audio pack, e.g. passage-3-1.mp3
+	Chapter     string                  `json:"chapter"`
+	Title       string                  `json:"title"`
+	Lines       []PassageLine           `json:"lines"`
+	Translation string                  `json:"translation,omitempty"` // English of the whole passage, shown after answering
+	Questions   []ComprehensionQuestion `json:"questions"`
+}
+
+// PassageLine is one line of a passage and who says it
+type PassageLine struct {
+	Speaker string `json:"speaker,omitempty"` // Not read aloud
+	Text    string `json:"text"`
+}
+
+// ComprehensionQuestion asks about a passage, answered from choices
+type ComprehensionQuestion struct {
+	Question string   `json:"question"`
+	Answer   string   `json:"answer"`
+	Options  []string `json:"options"` // Wrong choices offered with the answer
+}
+
+// valid reports whether a passage has lines and each question has choices
+func (p ListeningPassage) valid() bool {
+	if len(p.Lines) == 0 || len(p.Questions) == 0 {
+		return false
+	}
+	for _, q := range p.Questions {
+		if q.Question == "" || q.Answer == "" || len(q.Options) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// spoken returns the text of a passage to read aloud, without its speakers
+func (p ListeningPassage) spoken() string {
+	lines := make([]string, len(p.Lines))
+	for i, line := range p.Lines {
+		lines[i] = line.Text
+	}
+	return strings.Join(lines, " ")
+}
+
+// script writes out a passage line by line with its speakers
+func (p ListeningPassage) script() string {
+	lines := make([]string, len(p.Lines))
+	for i, line := range p.Lines {
+		lines[i] = line.Text
+		if line.Speaker != "" {
+			lines[i] = line.Speaker + "：" + line.Text
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// loadPassages returns the bundled listening passages followed by any custom ones from
+// passageFile. Passages without lines or with questions lacking choices are left out.
+func loadPassages() []ListeningPassage {
+	var passages []ListeningPassage
+	if err := json.Unmarshal(bundledPassagesJSON, &passages); err != nil {
+		log.Printf("Invalid bundled listening passages: %v", err)
+	}
+	if data, err := os.ReadFile(dataPath(passageFile)); err == nil {
+		var custom []ListeningPassage
+		if err := json.Unmarshal(data, &custom); err != nil {
+			log.Printf("Ignoring %s: %v", passageFile, err)
+		}
+		passages = append(passages, custom...)
+	}
+
+	var valid []ListeningPassage
+	for _, p := range passages {
+		if !p.valid() {
+			log.Printf("Skipping listening passage %q: it needs lines and questions with choices", p.Title)
+			continue
+		}
+		valid = append(valid, p)
+	}
+	return valid
+}
+
+// chapterPassages returns the listening passages of a chapter
+func chapterPassages(chapter string) []ListeningPassage {
+	var passages []ListeningPassage
+	for _, p := range loadPassages() {
+		if p.Chapter == chapter {
+			passages = append(passages, p)
+		}
+	}
+	return passages
+}
+
+// passageQuiz plays passages and asks their questions together, one passage at a time
+type passageQuiz struct {
+	qa       *quizApp
+	passages []ListeningPassage
+	index    int
+	right    int // Questions answered right
+	asked    int // Questions graded
+}
+
+// passageButton starts the listening passages of the current chapter, disabled if it has none
+func (qa *quizApp) passageButton() *widget.Button {
+	passages := chapterPassages(qa.state.currentChapter)
+	button := widget.NewButton(fmt.Sprintf("Listening Passages (%d)", len(passages)), qa.serialized(func() {
+		q := &passageQuiz{qa: qa, passages: passages}
+		q.showPassage()
+	}))
+	if len(passages) == 0 {
+		button.Disable()
+	}
+	return button
+}
+
+// showPassage plays the current passage and shows all its questions. Without speech or
+// a recording the script is shown from the start, for reading comprehension instead.
+func (q *passageQuiz) showPassage() {
+	if q.index >= len(q.passages) {
+		q.showResults()
+		return
+	}
+	p := q.passages[q.index]
+	recording := recordingPath(p.ID)
+	audible := recording != "" || speechAvailable()
+
+	script := wrappedLabel(p.script())
+	translation := wrappedLabel(p.Translation)
+	translation.TextStyle = fyne.TextStyle{Italic: true}
+	translation.Hide()
+	if audible {
+		script.Hide()
+	}
+
+	replays := 0
+	limit := q.qa.settings.Audio.MaxReplays
+	playButton := widget.NewButton("🔊 Play", nil)
+	playButton.OnTapped = func() {
+		if limit > 0 && replays > limit {
+			return
+		}
+		q.qa.sayRecorded(p.spoken(), recording)
+		replays++
+		if limit > 0 {
+			playButton.SetText(fmt.Sprintf("🔊 Play Again (%d left)", limit-replays+1))
+			if replays > limit {
+				playButton.Disable()
+			}
+		} else {
+			playButton.SetText("🔊 Play Again")
+		}
+	}
+	if !audible {
+		playButton.Disable()
+	}
+	scriptButton := widget.NewButton("Show Script", q.qa.serialized(func() {
+		script.Show()
+	}))
+
+	questions := container.NewVBox()
+	choices := make([]*widget.RadioGroup, len(p.Questions))
+	marks := make([]*widget.Label, len(p.Questions))
+	for i, cq := range p.Questions {
+		options := append([]string{cq.Answer}, cq.Options...)
+		rand.Shuffle(len(options), func(a, b int) { options[a], options[b] = options[b], options[a] })
+		choices[i] = widget.NewRadioGroup(options, nil)
+		marks[i] = widget.NewLabel("")
+		questions.Add(widget.NewLabelWithStyle(fmt.Sprintf("%d. %s", i+1, cq.Question), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		questions.Add(choices[i])
+		questions.Add(marks[i])
+	}
+
+	var checkButton *widget.Button
+	answered := false
+	checkButton = widget.NewButton("Check Answers", q.qa.serialized(func() {
+		if answered {
+			q.index++
+			q.showPassage()
+			return
+		}
+		for _, choice := range choices {
+			if choice.Selected == "" {
+				dialog.ShowInformation("Listening Passages", "Please answer every question.", q.qa.window)
+				return
+			}
+		}
+		answered = true
+		right := 0
+		for i, cq := range p.Questions {
+			choices[i].Disable()
+			if choices[i].Selected == cq.Answer {
+				right++
+				marks[i].SetText("✅ Correct")
+			} else {
+				marks[i].SetText("❌ " + cq.Answer)
+			}
+		}
+		q.right += right
+		q.asked += len(p.Questions)
+		script.Show()
+		if p.Translation != "" {
+			translation.Show()
+		}
+		scriptButton.Hide()
+		checkButton.SetText("Next Passage")
+	}))
+	checkButton.Importance = widget.HighImportance
+
+	q.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Listening Passages — %d/%d — %s", q.index+1, len(q.passages), p.Title),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		container.NewGridWithColumns(2,
+			checkButton,
+			widget.NewButton("End Passages", q.qa.serialized(q.showResults)),
+		),
+		nil, nil,
+		container.NewVScroll(container.NewVBox(
+			container.NewHBox(playButton, scriptButton, widget.NewLabel("Speed"), q.qa.speedSelect()),
+			script,
+			translation,
+			questions,
+		)),
+	))
+	if audible {
+		playButton.OnTapped()
+	}
+}
+
+// showResults shows the questions answered right over all passages
+func (q *passageQuiz) showResults() {
+	if q.index >= len(q.passages) {
+		q.qa.earnPerfectXP(float64(q.right), q.asked)
+	}
+	q.qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Listening Passages Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("Questions right: %d/%d", q.right, q.asked)),
+		widget.NewButton("Return to Chapter Selection", q.qa.serialized(func() {
+			q.qa.showChapterSelection()
+		})),
+	)))
+}
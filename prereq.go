@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// requiredQIDs returns the prerequisites of a question, from its QRequires column
+func requiredQIDs(q Question) []string {
+	return strings.FieldsFunc(q.QRequires, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+}
+
+// prerequisiteMet reports whether an item no longer holds back the items that require it:
+// it was mastered at some point, or taken out of study
+func (p *Progress) prerequisiteMet(qid string) bool {
+	if s := p.stats(qid); s != nil && !s.MasteredAt.IsZero() {
+		return true
+	}
+	return p.retired(qid) || p.suspended(qid)
+}
+
+// missingPrerequisites returns the prerequisites of a question that are still to be mastered.
+// Prerequisites that are not in the deck are ignored.
+func missingPrerequisites(q Question, deck map[string]Question, progress *Progress) []Question {
+	var missing []Question
+	for _, qid := range requiredQIDs(q) {
+		if p, ok := deck[qid]; ok && qid != q.QID && !progress.prerequisiteMet(qid) {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
+
+// deckByQID indexes a deck by QID
+func deckByQID(questions []Question) map[string]Question {
+	deck := make(map[string]Question, len(questions))
+	for _, q := range questions {
+		deck[q.QID] = q
+	}
+	return deck
+}
+
+// unlockedQuestions leaves out the questions of a deck whose prerequisites are still to be mastered
+func unlockedQuestions(questions []Question, progress *Progress) []Question {
+	var deck map[string]Question
+	var unlocked []Question
+	for _, q := range questions {
+		if q.QRequires != "" {
+			if deck == nil {
+				deck = deckByQID(questions)
+			}
+			if len(missingPrerequisites(q, deck, progress)) > 0 {
+				continue
+			}
+		}
+		unlocked = append(unlocked, q)
+	}
+	return unlocked
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRequiredQIDs(t *testing.T) {
+	got := requiredQIDs(Question{QRequires: "12, 13;14  15"})
+	if want := []string{"12", "13", "14", "15"}; !slices.Equal(got, want) {
+		t.Errorf("requiredQIDs = %q, want %q", got, want)
+	}
+}
+
+func TestUnlockedQuestions(t *testing.T) {
+	deck := []Question{
+		{QID: "1"},
+		{QID: "2", QRequires: "1"},
+		{QID: "3", QRequires: "2"},
+		{QID: "4", QRequires: "99"}, // Not in the deck
+		{QID: "5", QRequires: "5"},  // Requires itself
+	}
+	qids := func(questions []Question) []string {
+		var ids []string
+		for _, q := range questions {
+			ids = append(ids, q.QID)
+		}
+		return ids
+	}
+
+	progress := &Progress{}
+	if got, want := qids(unlockedQuestions(deck, progress)), []string{"1", "4", "5"}; !slices.Equal(got, want) {
+		t.Errorf("nothing mastered: unlocked %q, want %q", got, want)
+	}
+
+	progress.Items = map[string]*itemStats{"1": {Streak: masteryStreak, MasteredAt: time.Now()}}
+	if got, want := qids(unlockedQuestions(deck, progress)), []string{"1", "2", "4", "5"}; !slices.Equal(got, want) {
+		t.Errorf("1 mastered: unlocked %q, want %q", got, want)
+	}
+
+	// Mastered once stays met after a miss, and retired items count as met
+	progress.Items["1"].Streak = 0
+	progress.Retired = map[string]time.Time{"2": time.Now()}
+	if got, want := qids(unlockedQuestions(deck, progress)), qids(deck); !slices.Equal(got, want) {
+		t.Errorf("1 missed after mastery, 2 retired: unlocked %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Quiz modes a preset can start
+const (
+	modeChoice   = "choice"   // Multiple choice
+	modeSpelling = "spelling" // Typed spelling traps
+	modeMeaning  = "meaning"  // Typed English meanings
+)
+
+// modeNames are the quiz modes by the name shown
+var modeNames = map[string]string{
+	modeChoice:   "Multiple Choice",
+	modeSpelling: "Spelling Traps (typed)",
+	modeMeaning:  "Meanings (typed)",
+}
+
+// QuizPreset is a saved quiz setup, started with one click from the home screen
+type QuizPreset struct {
+	Name          string      `json:"name"`
+	Chapters      []string    `json:"chapters"`      // Empty for every chapter
+	Mix           questionMix `json:"mix,omitempty"` // Share of each question category
+	Mode          string      `json:"mode"`          // modeChoice, modeSpelling or modeMeaning
+	Length        int         `json:"length"`        // Questions per session, 0 for the whole pool
+	Reverse       bool        `json:"reverse"`       // English → Japanese
+	HideTimer     bool        `json:"hide_timer"`
+	ManualAdvance bool        `json:"manual_advance"`
+}
+
+// String summarizes the preset, e.g. "Ch. 1, 2 · Multiple Choice · 30 questions"
+func (p QuizPreset) String() string {
+	chapters := "All chapters"
+	if len(p.Chapters) > 0 {
+		chapters = "Ch. " + strings.Join(p.Chapters, ", ")
+	}
+	length := "all questions"
+	if p.Length > 0 {
+		length = fmt.Sprintf("%d questions", p.Length)
+	}
+	parts := []string{chapters, modeNames[p.Mode], length}
+	if p.Reverse {
+		parts = append(parts, "reverse")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// savePreset adds a preset to the settings, replacing one with the same name
+func (qa *quizApp) savePreset(preset QuizPreset) error {
+	presets := qa.settings.Presets
+	replaced := false
+	for i := range presets {
+		if presets[i].Name == preset.Name {
+			presets[i] = preset
+			replaced = true
+		}
+	}
+	if !replaced {
+		presets = append(presets, preset)
+	}
+	qa.settings.Presets = presets
+	return qa.settings.save()
+}
+
+// deletePreset removes a preset from the settings
+func (qa *quizApp) deletePreset(name string) error {
+	var presets []QuizPreset
+	for _, p := range qa.settings.Presets {
+		if p.Name != name {
+			presets = append(presets, p)
+		}
+	}
+	qa.settings.Presets = presets
+	return qa.settings.save()
+}
+
+// readPreset reads a preset shared as a JSON file, checking it can be started
+func readPreset(r io.Reader) (QuizPreset, error) {
+	var preset QuizPreset
+	if err := json.NewDecoder(r).Decode(&preset); err != nil {
+		return preset, fmt.Errorf("not a preset file: %w", err)
+	}
+	preset.Name = strings.TrimSpace(preset.Name)
+	if preset.Name == "" {
+		return preset, errors.New("the preset has no name")
+	}
+	if preset.Mode == "" {
+		preset.Mode = modeChoice
+	}
+	if _, ok := modeNames[preset.Mode]; !ok {
+		return preset, fmt.Errorf("the preset uses an unknown mode %q", preset.Mode)
+	}
+	if preset.Length < 0 {
+		return preset, errors.New("the preset has a negative number of questions")
+	}
+	return preset, nil
+}
+
+// writePreset writes a preset as a JSON file to share
+func writePreset(w io.Writer, preset QuizPreset) error {
+	data, err := json.MarshalIndent(preset, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// exportPreset saves a preset to a file, e.g. for a teacher to hand out
+func (qa *quizApp) exportPreset(preset QuizPreset) {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+		if err := writePreset(writer, preset); err != nil {
+			dialog.ShowError(err, qa.window)
+		}
+	}, qa.window)
+	save.SetFileName(preset.Name + ".json")
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	save.Show()
+}
+
+// importPreset adds a preset from a shared file, asking before replacing one of the same name
+func (qa *quizApp) importPreset() {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+		defer reader.Close()
+		preset, err := readPreset(reader)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		add := func() {
+			if err := qa.savePreset(preset); err != nil {
+				dialog.ShowError(err, qa.window)
+				return
+			}
+			qa.showChapterSelection()
+			dialog.ShowInformation("Import Preset", "Added the preset "+preset.Name+" ("+preset.String()+").", qa.window)
+		}
+		for _, p := range qa.settings.Presets {
+			if p.Name == preset.Name {
+				dialog.ShowConfirm("Import Preset", "Replace your preset "+preset.Name+"?", func(ok bool) {
+					if ok {
+						add()
+					}
+				}, qa.window)
+				return
+			}
+		}
+		add()
+	}, qa.window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	open.Show()
+}
+
+// startPreset switches to a preset's direction, timer and mix and starts its quiz
+func (qa *quizApp) startPreset(preset QuizPreset) {
+	qa.settings.QuestionMix = preset.Mix
+	qa.settings.ManualAdvance = preset.ManualAdvance
+	qa.setReverse(preset.Reverse)
+	qa.setShowTimer(!preset.HideTimer)
+
+	// Chapters outside the kiosk's selection are left out
+	available := make(map[string]bool)
+	for _, chapter := range qa.availableChapters() {
+		available[chapter] = true
+	}
+	var chapters []string
+	for _, chapter := range preset.Chapters {
+		if available[chapter] {
+			chapters = append(chapters, chapter)
+		}
+	}
+	if len(preset.Chapters) == 0 {
+		chapters = qa.availableChapters()
+	}
+
+	state := qa.state
+	state.reset()
+	state.chapterQuestions = qa.studyChapters(chapters)
+	state.currentChapter = strings.Join(chapters, ", ")
+	state.quizName = preset.Name
+	if len(state.chapterQuestions) == 0 {
+		dialog.ShowInformation(preset.Name, "None of the preset's chapters are in this deck.", qa.window)
+		return
+	}
+	switch preset.Mode {
+	case modeSpelling:
+		qa.startSpellingDrill()
+		return
+	case modeMeaning:
+		qa.startMeaningDrill()
+		return
+	}
+	state.totalQuestions = len(state.chapterQuestions)
+	if preset.Length > 0 {
+		state.totalQuestions = min(preset.Length, state.totalQuestions)
+	}
+	qa.startQuiz()
+}
+
+// presetsPanel offers the saved presets on the home screen. Right-click or long-press a preset to export or delete it.
+func (qa *quizApp) presetsPanel() fyne.CanvasObject {
+	if qa.kiosk && len(qa.settings.Presets) == 0 {
+		return container.NewVBox()
+	}
+	panel := container.NewVBox(widget.NewLabelWithStyle("Presets", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for _, preset := range qa.settings.Presets {
+		preset := preset
+		button := widget.NewButton(preset.Name, qa.serialized(func() {
+			qa.startPreset(preset)
+		}))
+		panel.Add(newContextMenuArea(button, qa.window, func() *fyne.Menu {
+			if qa.kiosk {
+				return fyne.NewMenu("")
+			}
+			return fyne.NewMenu("",
+				fyne.NewMenuItem("Export Preset…", func() {
+					qa.exportPreset(preset)
+				}),
+				fyne.NewMenuItem("Delete Preset", func() {
+					if err := qa.deletePreset(preset.Name); err != nil {
+						dialog.ShowError(err, qa.window)
+					}
+					qa.showChapterSelection()
+				}),
+			)
+		}))
+		panel.Add(widget.NewLabel(preset.String()))
+	}
+	if !qa.kiosk {
+		panel.Add(container.NewGridWithColumns(2,
+			widget.NewButton("New Preset…", qa.serialized(qa.showPresetDialog)),
+			widget.NewButton("Import Preset…", qa.serialized(qa.importPreset)),
+		))
+	}
+	return panel
+}
+
+// showPresetDialog asks for the setup of a new preset, starting from the current settings
+func (qa *quizApp) showPresetDialog() {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("e.g. Ch. 1–5 mixed, 30 questions")
+	chapterCheck := widget.NewCheckGroup(qa.availableChapters(), nil)
+	chapterCheck.Horizontal = true
+	var modes []string
+	for _, mode := range []string{modeChoice, modeSpelling, modeMeaning} {
+		modes = append(modes, modeNames[mode])
+	}
+	modeSelect := widget.NewSelect(modes, nil)
+	modeSelect.SetSelected(modeNames[modeChoice])
+	lengthEntry := widget.NewEntry()
+	lengthEntry.SetPlaceHolder("All")
+	reverseCheck := widget.NewCheck("Reverse (English → Japanese)", nil)
+	reverseCheck.SetChecked(qa.settings.Reverse)
+	timerCheck := widget.NewCheck("Show the question timer", nil)
+	timerCheck.SetChecked(!qa.settings.HideTimer)
+	autoCheck := widget.NewCheck("Auto-advance", nil)
+	autoCheck.SetChecked(!qa.settings.ManualAdvance)
+	mix := qa.settings.QuestionMix
+
+	form := widget.NewForm(
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Chapters", chapterCheck),
+		widget.NewFormItem("Mode", modeSelect),
+		widget.NewFormItem("Questions", lengthEntry),
+		widget.NewFormItem("Question Mix", widget.NewLabel(mix.String())),
+		widget.NewFormItem("", reverseCheck),
+		widget.NewFormItem("", timerCheck),
+		widget.NewFormItem("", autoCheck),
+	)
+	d := dialog.NewCustomConfirm("New Preset", "Save", "Cancel", container.NewVScroll(form), func(ok bool) {
+		if !ok {
+			return
+		}
+		preset := QuizPreset{
+			Name:          strings.TrimSpace(nameEntry.Text),
+			Chapters:      chapterCheck.Selected,
+			Mix:           mix,
+			Mode:          modeChoice,
+			Reverse:       reverseCheck.Checked,
+			HideTimer:     !timerCheck.Checked,
+			ManualAdvance: !autoCheck.Checked,
+		}
+		if preset.Name == "" {
+			dialog.ShowInformation("New Preset", "Please enter a name for the preset.", qa.window)
+			return
+		}
+		for mode, name := range modeNames {
+			if name == modeSelect.Selected {
+				preset.Mode = mode
+			}
+		}
+		if text := strings.TrimSpace(lengthEntry.Text); text != "" {
+			length, err := strconv.Atoi(text)
+			if err != nil || length <= 0 {
+				dialog.ShowInformation("New Preset", "The number of questions must be a positive number.", qa.window)
+				return
+			}
+			preset.Length = length
+		}
+		if err := qa.savePreset(preset); err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		qa.showChapterSelection()
+	}, qa.window)
+	d.Resize(fyne.NewSize(480, 480))
+	d.Show()
+}
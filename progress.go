@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// Progress is the learner's saved study history
+type Progress struct {
+	SchemaVersion int `json:"schema_version"` // See progressMigrations
+
+	Daily []dailyRecord         `json:"daily"` // Completed daily challenges, oldest first
+	Items map[string]*itemStats `json:"items"` // Answer statistics by QID
+
+	Confusions map[string]map[string]int `json:"confusions"` // Wrong options picked: QID → picked answer → count
+
+	KanaTyping map[string]*kanaTypingStats `json:"kana_typing,omitempty"` // Typing tutor statistics by hiragana
+
+	Listening *listeningStats `json:"listening,omitempty"` // Listening drill totals
+
+	Shadowing *shadowingStats `json:"shadowing,omitempty"` // Shadowing totals, kept apart from answers as they are self-graded
+
+	Starred   map[string]time.Time `json:"starred,omitempty"`   // Questions starred during study: QID → when
+	Suspended map[string]time.Time `json:"suspended,omitempty"` // Questions taken out of study: QID → when
+	Retired   map[string]time.Time `json:"retired,omitempty"`   // Mastered archive, see updateRetirement: QID → when
+
+	Mistakes []mistake `json:"mistakes,omitempty"` // Mistake notebook, oldest first, up to maxMistakes
+
+	Conquered map[string]time.Time `json:"conquered,omitempty"` // Chapters whose boss quiz was passed: chapter → when
+
+	Decks       map[string]*deckRecord `json:"decks,omitempty"`        // Contents of each deck when it was last loaded, by file name
+	DeckChanges []deckChange           `json:"deck_changes,omitempty"` // Changes noticed in decks, oldest first, up to maxDeckChanges
+
+	XP      int            `json:"xp,omitempty"`        // Experience earned in all activities, see earnXP
+	XPByDay map[string]int `json:"xp_by_day,omitempty"` // Experience earned by day (dateLayout)
+
+	passphrase string // Encrypts the saved file when set
+	locked     bool   // The saved file is encrypted and was not unlocked yet, so it must not be overwritten
+}
+
+// masteryStreak is the number of correct answers in a row after which an item counts as mastered
+const masteryStreak = 3
+
+// itemStats records how a learner has done on one question
+type itemStats struct {
+	Seen     int       `json:"seen"`
+	Correct  int       `json:"correct"`
+	Streak   int       `json:"streak"` // Correct answers in a row
+	LastSeen time.Time `json:"last_seen"`
+
+	FirstSeen time.Time `json:"first_seen,omitempty"` // When the item was first answered, for the new items per day limit
+
+	MasteredAt time.Time `json:"mastered_at,omitempty"` // When the item was first mastered
+
+	ReviewStreak int `json:"review_streak,omitempty"` // Correct answers in a row given when the item was due after a day or more
+
+	Due time.Time `json:"due,omitempty"` // When the item should be reviewed next, see quizApp.reschedule
+
+	Ease float64 `json:"ease,omitempty"` // How quickly the review interval grows for this item, 0 for defaultEase; see adjustEase
+
+	TotalTime int64 `json:"total_ms,omitempty"` // Summed time of the timed answers, in milliseconds
+	Timed     int   `json:"timed,omitempty"`    // Answers with a measured time
+}
+
+// mastered reports whether the item has been answered correctly enough times in a row
+func (s *itemStats) mastered() bool {
+	return s != nil && s.Streak >= masteryStreak
+}
+
+// progressFile stores the progress between sessions
+const progressFile = "progress.json"
+
+// errLockHeld is returned by lockFile when another process holds the lock
+var errLockHeld = errors.New("in use by another process")
+
+// progressLock keeps the lock taken by lockProgress for as long as the app runs
+var progressLock *os.File
+
+// progressLockWait is how long lockProgress waits for another Genki Quiz to let go of the
+// lock, such as the old version restarting into an update
+const progressLockWait = 5 * time.Second
+
+// lockProgress makes sure no other Genki Quiz, such as the window and the serve command,
+// saves the same progress at the same time, as one would overwrite the other's answers.
+// The lock is held until the process exits.
+func lockProgress() error {
+	f, err := os.OpenFile(dataPath(progressFile)+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	err = lockFile(f)
+	for deadline := time.Now().Add(progressLockWait); errors.Is(err, errLockHeld) && time.Now().Before(deadline); {
+		time.Sleep(100 * time.Millisecond)
+		err = lockFile(f)
+	}
+	if err != nil {
+		f.Close()
+		if errors.Is(err, errLockHeld) {
+			return fmt.Errorf("%s is %w, close the other Genki Quiz first", progressFile, err)
+		}
+		return err
+	}
+	progressLock = f
+	return nil
+}
+
+// loadProgress reads the saved progress, starting empty if there is none yet.
+// An encrypted file needs its passphrase; without one errProgressLocked is returned.
+func loadProgress(passphrase string) (*Progress, error) {
+	return openProgress(passphrase, true)
+}
+
+// readProgress reads the saved progress like loadProgress but never writes: an older
+// file is upgraded in memory only, with no backup, for commands that just report it
+func readProgress(passphrase string) (*Progress, error) {
+	return openProgress(passphrase, false)
+}
+
+// openProgress reads the saved progress, saving it upgraded to the latest schema if
+// upgrade is set
+func openProgress(passphrase string, upgrade bool) (*Progress, error) {
+	progress := &Progress{passphrase: passphrase}
+	raw, err := os.ReadFile(dataPath(progressFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	data := raw
+	if isEncrypted(raw) {
+		if data, err = decrypt(raw, passphrase); err != nil {
+			return nil, err
+		}
+	} else {
+		progress.passphrase = ""
+	}
+	if !upgrade {
+		if _, _, err := decodeLatest(dataPath(progressFile), data, progressMigrations, progress); err != nil {
+			return nil, err
+		}
+		return progress, nil
+	}
+	upgraded, err := decodeVersioned(dataPath(progressFile), raw, data, progressMigrations, progress)
+	if err != nil {
+		return nil, err
+	}
+	if upgraded {
+		return progress, progress.save()
+	}
+	return progress, nil
+}
+
+// stats returns the statistics of a question, or nil if it was never answered
+func (p *Progress) stats(qid string) *itemStats {
+	return p.Items[qid]
+}
+
+// record updates the statistics of a question after an answer
+func (p *Progress) record(qid string, correct bool) {
+	if p.Items == nil {
+		p.Items = make(map[string]*itemStats)
+	}
+	s := p.Items[qid]
+	if s == nil {
+		s = &itemStats{}
+		p.Items[qid] = s
+	}
+	now := time.Now()
+	onSchedule := s.Streak > 0 && !now.Before(s.dueAt())
+	if s.Seen == 0 {
+		s.FirstSeen = now
+	}
+	s.Seen++
+	s.LastSeen = now
+	xp := answerXP(correct)
+	if correct {
+		s.Correct++
+		s.Streak++
+		if onSchedule {
+			s.ReviewStreak++
+		}
+		if s.mastered() && s.MasteredAt.IsZero() {
+			s.MasteredAt = s.LastSeen
+			xp += xpMastered
+		}
+	} else {
+		s.Streak = 0
+		s.ReviewStreak = 0
+	}
+	s.adjustEase(correct, onSchedule)
+	s.Due = now.Add(reviewInterval(s.Streak, s.ease()))
+	p.earnXP(xp, now)
+}
+
+// recordTime adds the time taken to answer a question. It is saved with the answer.
+func (p *Progress) recordTime(qid string, elapsed time.Duration) {
+	if elapsed <= 0 {
+		return
+	}
+	if p.Items == nil {
+		p.Items = make(map[string]*itemStats)
+	}
+	s := p.Items[qid]
+	if s == nil {
+		s = &itemStats{}
+		p.Items[qid] = s
+	}
+	s.TotalTime += elapsed.Milliseconds()
+	s.Timed++
+}
+
+// recordPick applies an option picked for a question, including which wrong option it was,
+// under the schedule of its chapter. It is shared by the window and the quiz engine.
+func (p *Progress) recordPick(q Question, picked string, schedule srsSettings, retireAfter int) bool {
+	correct := picked == q.QAnswer
+	p.answered(q.QID, correct, schedule, retireAfter)
+	if !correct {
+		p.recordConfusion(q.QID, picked)
+		p.recordMistake(mistake{QID: q.QID, Question: q.QHirakata, Romaji: q.QRomaji, Picked: picked, Answer: q.QAnswer})
+	}
+	return correct
+}
+
+// recordAnswer saves the outcome of an answered question, including which wrong option was picked
+func (qa *quizApp) recordAnswer(q Question, picked string) {
+	qa.progress.recordPick(q, picked, qa.schedule(q.QChapter), qa.settings.RetireAfter)
+	if err := qa.progress.save(); err != nil {
+		log.Printf("Failed to save progress: %v", err)
+	}
+	qa.progressChanged()
+}
+
+// save writes the progress to progressFile, or nothing in guest mode
+func (p *Progress) save() error {
+	return p.saveWith(p.passphrase)
+}
+
+// saveWith writes the progress encrypted with passphrase, or in the clear if it is empty
+func (p *Progress) saveWith(passphrase string) error {
+	if p.locked {
+		return errProgressLocked
+	}
+	if guestMode {
+		return nil
+	}
+	p.SchemaVersion = len(progressMigrations)
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if passphrase != "" {
+		if data, err = encrypt(data, passphrase); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(dataPath(progressFile), data, 0o644)
+}
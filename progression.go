@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// defaultUnlockAt is the share of a chapter in percent to master before the next chapter unlocks
+const defaultUnlockAt = 80
+
+// Unlock shares offered in the settings, in percent
+const (
+	minUnlockAt = 10
+	maxUnlockAt = 100
+)
+
+// progressionSettings configure the game mode where chapters unlock one after another
+type progressionSettings struct {
+	On       bool `json:"on"`        // Only the first chapter is open at the start
+	UnlockAt int  `json:"unlock_at"` // Percent of a chapter to master to unlock the next, 0 for defaultUnlockAt
+}
+
+// unlockShare returns the share of a chapter to master to unlock the next, from 0 to 1
+func (p progressionSettings) unlockShare() float64 {
+	if p.UnlockAt == 0 {
+		return defaultUnlockAt / 100.0
+	}
+	return float64(p.UnlockAt) / 100
+}
+
+// chapterMastery returns the share of a chapter's items that were mastered, or taken out of study
+func chapterMastery(questions []Question, chapter string, progress *Progress) float64 {
+	total, met := 0, 0
+	for _, q := range questions {
+		if q.QChapter != chapter {
+			continue
+		}
+		total++
+		if progress.prerequisiteMet(q.QID) {
+			met++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(met) / float64(total)
+}
+
+// chapterUnlocked reports whether a chapter may be studied: always when progression is off,
+// otherwise when it is the deck's first chapter or enough of the chapter before it is mastered
+func (qa *quizApp) chapterUnlocked(chapter string) bool {
+	if !qa.settings.Progression.On {
+		return true
+	}
+	previous := ""
+	for _, c := range qa.index.chapters {
+		if c == chapter {
+			break
+		}
+		previous = c
+	}
+	return previous == "" || chapterMastery(qa.questions, previous, qa.progress) >= qa.settings.Progression.unlockShare()
+}
+
+// progressionMap shows the chapters as a path of tiles: conquered, finished, open with the mastery
+// still needed, or locked. Choosing an open chapter starts it like the chapter list does.
+func (qa *quizApp) progressionMap(chapters []string, choose func(chapter string)) fyne.CanvasObject {
+	need := qa.settings.Progression.unlockShare()
+	tiles := container.NewGridWithColumns(4)
+	for _, chapter := range chapters {
+		mastery := chapterMastery(qa.questions, chapter, qa.progress)
+		unlocked := qa.chapterUnlocked(chapter)
+		label := "🔒 Chapter " + chapter
+		if qa.progress.conquered(chapter) {
+			label = "👑 Chapter " + chapter
+		} else if unlocked && mastery >= need {
+			label = "⭐ Chapter " + chapter
+		} else if unlocked {
+			label = "▶ Chapter " + chapter
+		}
+		button := widget.NewButton(label, qa.serialized(func() {
+			choose(chapter)
+		}))
+		if !unlocked {
+			button.Disable()
+		}
+		bar := widget.NewProgressBar()
+		bar.Max = need
+		bar.SetValue(min(mastery, need))
+		bar.TextFormatter = func() string {
+			return fmt.Sprintf("%.0f%% mastered", mastery*100)
+		}
+		tiles.Add(container.NewVBox(button, bar))
+	}
+	return container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Master %.0f%% of a chapter to unlock the next one.", need*100)),
+		tiles,
+	)
+}
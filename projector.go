@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// projectorCountdown is how long the class gets to discuss each question
+const projectorCountdown = 20 * time.Second
+
+// Text sizes used in projector mode, readable from the back of a classroom
+const (
+	projectorQuestionSize = 72
+	projectorOptionSize   = 36
+	projectorTimerSize    = 48
+)
+
+// hostGame is a whole-class review game shown on a projector.
+// The host answers on behalf of the class, so no score is kept.
+type hostGame struct {
+	qa         *quizApp
+	questions  []Question
+	index      int
+	stop       chan struct{} // Closed to stop the countdown of the current question
+	screen     int           // Counts the screens shown, so leaving the game can be told from the next question
+	fullScreen bool          // The window was full screen before the game, as in kiosk mode
+}
+
+// showProjectorMode starts a projector review game for the selected chapter
+func (qa *quizApp) showProjectorMode() {
+	questions := make([]Question, len(qa.state.chapterQuestions))
+	copy(questions, qa.state.chapterQuestions)
+	rand.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+
+	game := &hostGame{qa: qa, questions: questions, fullScreen: qa.window.FullScreen()}
+	qa.window.SetFullScreen(true)
+	game.showQuestion()
+}
+
+// stopCountdown cancels the running countdown, if any
+func (g *hostGame) stopCountdown() {
+	if g.stop != nil {
+		close(g.stop)
+		g.stop = nil
+	}
+}
+
+// end leaves projector mode
+func (g *hostGame) end() {
+	g.qa.showChapterSelection()
+}
+
+// leave stops the countdown and restores the window once another screen replaces the game,
+// whether by end, another tab or a menu item
+func (g *hostGame) leave() {
+	g.stopCountdown()
+	g.qa.window.SetFullScreen(g.fullScreen)
+}
+
+// showQuestion displays the current question with its options and starts the countdown
+func (g *hostGame) showQuestion() {
+	if g.index >= len(g.questions) {
+		g.end()
+		return
+	}
+	q := g.questions[g.index]
+
+	questionText := canvas.NewText(q.QHirakata, theme.ForegroundColor())
+	g.qa.japaneseText(questionText, projectorQuestionSize)
+	questionText.TextStyle = fyne.TextStyle{Bold: true}
+	questionText.Alignment = fyne.TextAlignCenter
+
+	timerText := canvas.NewText("", theme.PrimaryColor())
+	timerText.TextSize = projectorTimerSize
+	timerText.Alignment = fyne.TextAlignCenter
+
+	options := append(g.qa.state.answerPool().random(q.QAnswer, 3), q.QAnswer)
+	rand.Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
+
+	nextButton := widget.NewButton("Next Question", g.qa.serialized(func() {
+		g.stopCountdown()
+		g.index++
+		g.showQuestion()
+	}))
+	nextButton.Hide()
+
+	// Reveal marks the correct option, and the host's pick if it was wrong
+	var revealOnce sync.Once
+	labels := make([]*canvas.Text, len(options))
+	reveal := func(picked int) {
+		revealOnce.Do(func() {
+			timerText.Text = ""
+			timerText.Refresh()
+			for i, opt := range options {
+				if opt == q.QAnswer {
+					labels[i].Text = "✅ " + opt
+				} else if i == picked {
+					labels[i].Text = "❌ " + opt
+				}
+				labels[i].Refresh()
+			}
+			nextButton.Show()
+		})
+	}
+
+	// Large answer buttons: a plain button with big text on top
+	optionGrid := container.NewGridWithColumns(2)
+	for i, opt := range options {
+		i := i
+		labels[i] = canvas.NewText(opt, theme.ForegroundColor())
+		labels[i].TextSize = projectorOptionSize
+		labels[i].Alignment = fyne.TextAlignCenter
+		button := widget.NewButton("", g.qa.serialized(func() {
+			g.stopCountdown()
+			reveal(i)
+		}))
+		optionGrid.Add(container.NewStack(button, container.NewPadded(labels[i])))
+	}
+
+	g.screen++
+	shown := g.screen
+	g.qa.showScreen(container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle(
+				fmt.Sprintf("Question %d/%d", g.index+1, len(g.questions)),
+				fyne.TextAlignCenter,
+				fyne.TextStyle{},
+			),
+			timerText,
+		),
+		container.NewGridWithColumns(2,
+			nextButton,
+			widget.NewButton("End Review", g.qa.serialized(g.end)),
+		),
+		nil, nil,
+		container.NewVBox(
+			layoutSpacer(),
+			questionText,
+			layoutSpacer(),
+			optionGrid,
+		),
+	))
+
+	// Count down, then reveal the answer if the host has not picked one
+	g.stop = make(chan struct{})
+	stop := g.stop
+	g.qa.router.currentSession().onEnd(func() {
+		if g.screen == shown {
+			g.leave()
+		}
+	})
+	deadline := time.Now().Add(projectorCountdown)
+	go func() {
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			remaining := time.Until(deadline)
+			g.qa.serialized(func() {
+				select {
+				case <-stop:
+					return // Stopped while waiting for the lock
+				default:
+				}
+				if remaining <= 0 {
+					reveal(-1)
+					return
+				}
+				timerText.Text = fmt.Sprintf("%d", int(remaining.Seconds()+0.999))
+				timerText.Refresh()
+			})()
+			if remaining <= 0 {
+				return
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// layoutSpacer adds vertical breathing room between large elements
+func layoutSpacer() fyne.CanvasObject {
+	spacer := canvas.NewRectangle(color.Transparent)
+	spacer.SetMinSize(fyne.NewSize(0, 24))
+	return spacer
+}
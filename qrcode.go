@@ -0,0 +1,31 @@
+package main
+
+import (
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+	"github.com/skip2/go-qrcode"
+)
+
+// joinCodeSize is the side length of the join code in the lobby, big enough to scan
+// from the back of a classroom when projected
+const joinCodeSize = 240
+
+// joinCodeSmallSize is the side length of the join code shown during a game for latecomers
+const joinCodeSmallSize = 96
+
+// joinCode renders a QR code for a join address so participants can scan it instead of typing it
+func joinCode(url string, size float32) fyne.CanvasObject {
+	code, err := qrcode.New(url, qrcode.Medium)
+	if err != nil {
+		log.Printf("Failed to make QR code: %v", err)
+		return widget.NewLabel(url)
+	}
+	image := canvas.NewImageFromImage(code.Image(512))
+	image.FillMode = canvas.ImageFillContain
+	image.ScaleMode = canvas.ImageScalePixels // Keep the modules sharp
+	image.SetMinSize(fyne.NewSize(size, size))
+	return image
+}
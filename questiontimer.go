@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// questionTimer shows how long the question being shown has been open
+type questionTimer struct {
+	label   *widget.Label
+	started time.Time
+	done    chan struct{} // Closed to stop the ticking, nil when stopped
+}
+
+// newQuestionTimer creates a stopped timer
+func newQuestionTimer() *questionTimer {
+	return &questionTimer{label: widget.NewLabel("")}
+}
+
+// formatElapsed formats a time like "⏱ 1:05"
+func formatElapsed(d time.Duration) string {
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("⏱ %d:%02d", seconds/60, seconds%60)
+}
+
+// start restarts the timer from zero for a new question
+func (t *questionTimer) start() {
+	if t == nil {
+		return
+	}
+	t.stop()
+	started := time.Now()
+	done := make(chan struct{})
+	t.started, t.done = started, done
+	t.label.SetText(formatElapsed(0))
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				t.label.SetText(formatElapsed(now.Sub(started)))
+			}
+		}
+	}()
+}
+
+// stop halts the timer, leaving the time shown, and returns how long the question was open
+func (t *questionTimer) stop() time.Duration {
+	if t == nil || t.done == nil {
+		return 0
+	}
+	close(t.done)
+	t.done = nil
+	elapsed := time.Since(t.started)
+	t.label.SetText(formatElapsed(elapsed))
+	return elapsed
+}
+
+// setShowTimer shows or hides the question timer and remembers the choice.
+// Answers are timed for the statistics either way.
+func (qa *quizApp) setShowTimer(show bool) {
+	qa.settings.HideTimer = !show
+	if err := qa.settings.save(); err != nil {
+		log.Printf("Failed to save settings: %v", err)
+	}
+	qa.updateTimer()
+}
+
+// updateTimer applies the timer setting to the quiz screen
+func (qa *quizApp) updateTimer() {
+	if qa.settings.HideTimer {
+		qa.timer.label.Hide()
+	} else {
+		qa.timer.label.Show()
+	}
+	if qa.timerMenuItem != nil {
+		qa.timerMenuItem.Checked = !qa.settings.HideTimer
+		qa.window.MainMenu().Refresh()
+	}
+}
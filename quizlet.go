@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// quizletCard is one term and definition of a Quizlet set
+type quizletCard struct {
+	Term       string
+	Definition string
+}
+
+// parseQuizletSet reads a set exported from Quizlet with the default settings:
+// one card per line, a tab between term and definition. Sets exported with a
+// comma between them, and sets written by writeQuizletSet with tags, are read as well.
+func parseQuizletSet(r io.Reader) ([]quizletCard, error) {
+	var cards []quizletCard
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\uFEFF"))
+		if line == "" {
+			continue
+		}
+		separator := "\t"
+		if !strings.Contains(line, separator) {
+			separator = ","
+		}
+		term, definition, ok := strings.Cut(line, separator)
+		if !ok {
+			return nil, fmt.Errorf("line %q has no tab or comma between term and definition", line)
+		}
+		definition, _, _ = strings.Cut(definition, "\t") // Drop the tags column written by writeQuizletSet
+		cards = append(cards, quizletCard{
+			Term:       cleanText(term),
+			Definition: cleanText(definition),
+		})
+	}
+	return cards, scanner.Err()
+}
+
+// hasJapanese reports whether text contains kana or kanji
+func hasJapanese(text string) bool {
+	for _, r := range text {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) {
+			return true
+		}
+	}
+	return false
+}
+
+// readingPattern matches a term written with its reading, like "学校 (がっこう)"
+var readingPattern = regexp.MustCompile(`^(.+?)\s*[(（]\s*(.+?)\s*[)）]$`)
+
+// splitReading separates a Japanese term into its kana and, if written with kanji, the kanji.
+// Terms without a kana reading are returned as they are.
+func splitReading(term string) (kana, kanji string) {
+	if m := readingPattern.FindStringSubmatch(term); m != nil {
+		switch {
+		case isKana(m[2]) && !isKana(m[1]):
+			return m[2], m[1]
+		case isKana(m[1]) && !isKana(m[2]):
+			return m[1], m[2]
+		}
+	}
+	return term, ""
+}
+
+// nextQID returns the QID after the highest numeric one in a deck
+func nextQID(questions []Question) int {
+	next := 1
+	for _, q := range questions {
+		if id, err := strconv.Atoi(q.QID); err == nil && id >= next {
+			next = id + 1
+		}
+	}
+	return next
+}
+
+// quizletQuestions turns cards into vocabulary questions of a chapter, numbered from firstID.
+// Either side of a card may be the Japanese one.
+func quizletQuestions(cards []quizletCard, chapter string, firstID int) []Question {
+	var questions []Question
+	for _, card := range cards {
+		japanese, english := card.Term, card.Definition
+		if !hasJapanese(japanese) && hasJapanese(english) {
+			japanese, english = english, japanese
+		}
+		if japanese == "" || english == "" {
+			continue
+		}
+		kana, kanji := splitReading(japanese)
+		romaji := ""
+		if isKana(kana) {
+			romaji = capitalizeRomaji(toRomaji(kana))
+		}
+		questions = append(questions, Question{
+			QID:       strconv.Itoa(firstID + len(questions)),
+			QChapter:  chapter,
+			QAnswer:   english,
+			QHirakata: kana,
+			QRomaji:   romaji,
+			QType:     "nil",
+			QKanji:    kanji,
+		})
+	}
+	return questions
+}
+
+// quizletField flattens text so it cannot break the tab-separated layout
+func quizletField(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// quizletTerm writes the Japanese side of a card the way splitReading reads it back
+func quizletTerm(q Question) string {
+	if q.QKanji != "" && q.QKanji != q.QHirakata {
+		return q.QKanji + " (" + q.QHirakata + ")"
+	}
+	return q.QHirakata
+}
+
+// quizletTags describes a question for the optional tags column
+func quizletTags(q Question) string {
+	return quizletField(strings.Join(questionTags(q), " "))
+}
+
+// writeQuizletSet writes questions in the layout Quizlet imports: the term, a tab
+// and the definition on each line, optionally followed by a tab and space-separated tags
+func writeQuizletSet(w io.Writer, questions []Question, tags bool) error {
+	bw := bufio.NewWriter(w)
+	for _, q := range questions {
+		fields := []string{quizletField(quizletTerm(q)), quizletField(q.QAnswer)}
+		if tags {
+			fields = append(fields, quizletTags(q))
+		}
+		if _, err := bw.WriteString(strings.Join(fields, "\t") + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// exportQuizletSet saves a chapter or the whole deck as a set to import into Quizlet
+func (qa *quizApp) exportQuizletSet() {
+	const allChapters = "All chapters"
+	chapters := qa.index.chapters
+	chapterSelect := widget.NewSelect(append([]string{allChapters}, chapters...), nil)
+	chapterSelect.SetSelected(allChapters)
+	if qa.state.currentChapter != "" {
+		chapterSelect.SetSelected(qa.state.currentChapter)
+	}
+	tagsCheck := widget.NewCheck("Add a tags column (not read by Quizlet's importer)", nil)
+
+	dialog.ShowForm("Export Quizlet Set", "Export", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Chapter", chapterSelect),
+			widget.NewFormItem("", tagsCheck),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			questions := qa.questions
+			name := "Genki Quiz"
+			if chapter := chapterSelect.Selected; chapter != allChapters {
+				questions = qa.index.byChapter[chapter]
+				name = "Genki Quiz Chapter " + chapter
+			}
+
+			save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+				if err != nil {
+					dialog.ShowError(err, qa.window)
+					return
+				}
+				if writer == nil {
+					return // Cancelled
+				}
+				defer writer.Close()
+
+				if err := writeQuizletSet(writer, questions, tagsCheck.Checked); err != nil {
+					dialog.ShowError(err, qa.window)
+				}
+			}, qa.window)
+			save.SetFileName(name + ".txt")
+			save.Show()
+		}, qa.window)
+}
+
+// importQuizletSet adds the cards of an exported Quizlet set to the deck as a chapter
+func (qa *quizApp) importQuizletSet() {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+		defer reader.Close()
+
+		cards, err := parseQuizletSet(reader)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if len(cards) == 0 {
+			dialog.ShowInformation("Import Quizlet Set", "The file has no cards.", qa.window)
+			return
+		}
+
+		// New cards go into a chapter of their own unless another one is chosen
+		chapter := strings.TrimSuffix(reader.URI().Name(), reader.URI().Extension())
+		chapterEntry := widget.NewEntry()
+		chapterEntry.SetText(chapter)
+		dialog.ShowForm("Import Quizlet Set", "Import", "Cancel",
+			[]*widget.FormItem{
+				widget.NewFormItem("Cards", widget.NewLabel(strconv.Itoa(len(cards)))),
+				widget.NewFormItem("Chapter", chapterEntry),
+			},
+			func(ok bool) {
+				if !ok {
+					return
+				}
+				chapter := strings.TrimSpace(chapterEntry.Text)
+				if chapter == "" {
+					dialog.ShowInformation("Import Quizlet Set", "Please enter a chapter.", qa.window)
+					return
+				}
+				imported := quizletQuestions(cards, chapter, nextQID(qa.questions))
+				qa.serialized(func() {
+					qa.setQuestions(append(qa.questions, imported...))
+					qa.state.reset()
+					qa.deckChanged()
+				})()
+				dialog.ShowInformation("Import Quizlet Set",
+					fmt.Sprintf("Imported %d cards into chapter %s. Use File › Save Deck As… to keep them.", len(imported), chapter),
+					qa.window)
+			}, qa.window)
+	}, qa.window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".txt", ".tsv", ".csv"}))
+	open.Show()
+}
+
+// saveDeck saves the current deck, including imported questions, as a question sheet
+func (qa *quizApp) saveDeck() {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		if err := writeQuestions(writer, qa.questions); err != nil {
+			dialog.ShowError(err, qa.window)
+		}
+	}, qa.window)
+	save.SetFileName(deckFile)
+	if dir, err := storage.ListerForURI(storage.NewFileURI(store.decks)); err == nil {
+		save.SetLocation(dir)
+	}
+	save.Show()
+}
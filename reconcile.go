@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// minReconcileScore is the similarity from which an orphaned record is offered as a match
+const minReconcileScore = 0.7
+
+// reconcileMatch proposes to move the progress of a QID no longer in the deck to a question that has none
+type reconcileMatch struct {
+	From  string    // QID the progress was recorded under
+	Label deckLabel // What that question said
+	To    Question
+	Score float64 // Similarity of kana and answer, from 0 to 1
+}
+
+// labelSimilarity compares what a question said with a question of the deck:
+// its kana counts more than its answer, which may have been reworded
+func labelSimilarity(label deckLabel, q Question) float64 {
+	kana := kanaSimilarity(label.Kana, q.QHirakata)
+	answer := max(answerRules{}.meaningSimilarity(label.Answer, q.QAnswer), answerRules{}.meaningSimilarity(q.QAnswer, label.Answer))
+	return 0.6*kana + 0.4*answer
+}
+
+// orphanLabels returns the text of the questions that have progress but are not in the deck,
+// as far as the deck records know it
+func orphanLabels(progress *Progress, questions []Question) map[string]deckLabel {
+	inDeck := deckByQID(questions)
+	orphans := make(map[string]deckLabel)
+	for qid := range progress.Items {
+		if _, ok := inDeck[qid]; ok {
+			continue
+		}
+		for _, record := range progress.Decks {
+			if label, ok := record.Labels[qid]; ok {
+				orphans[qid] = label
+				break
+			}
+		}
+	}
+	return orphans
+}
+
+// reconcileMatches pairs orphaned progress with the most similar questions without progress,
+// best matches first, each question at most once
+func reconcileMatches(progress *Progress, questions []Question) []reconcileMatch {
+	orphans := orphanLabels(progress, questions)
+	if len(orphans) == 0 {
+		return nil
+	}
+	var candidates []reconcileMatch
+	for _, q := range questions {
+		if progress.stats(q.QID) != nil {
+			continue
+		}
+		for qid, label := range orphans {
+			if score := labelSimilarity(label, q); score >= minReconcileScore {
+				candidates = append(candidates, reconcileMatch{From: qid, Label: label, To: q, Score: score})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].From < candidates[j].From
+	})
+	usedFrom, usedTo := make(map[string]bool), make(map[string]bool)
+	var matches []reconcileMatch
+	for _, m := range candidates {
+		if !usedFrom[m.From] && !usedTo[m.To.QID] {
+			usedFrom[m.From], usedTo[m.To.QID] = true, true
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// moveQIDKey moves the entry of a map from one key to another
+func moveQIDKey[V any](m map[string]V, from, to string) {
+	if v, ok := m[from]; ok {
+		m[to] = v
+		delete(m, from)
+	}
+}
+
+// moveProgress moves everything recorded under one QID to another, keeping the review history
+func (p *Progress) moveProgress(from, to string) {
+	moveQIDKey(p.Items, from, to)
+	moveQIDKey(p.Confusions, from, to)
+	moveQIDKey(p.Starred, from, to)
+	moveQIDKey(p.Suspended, from, to)
+	moveQIDKey(p.Retired, from, to)
+	for i := range p.Mistakes {
+		if p.Mistakes[i].QID == from {
+			p.Mistakes[i].QID = to
+		}
+	}
+}
+
+// showReconcile offers to move orphaned progress to the questions it most likely belongs to now
+func (qa *quizApp) showReconcile() {
+	matches := reconcileMatches(qa.progress, qa.questions)
+	if len(matches) == 0 {
+		orphans := len(orphanLabels(qa.progress, qa.questions))
+		dialog.ShowInformation("Reconcile Progress",
+			fmt.Sprintf("No question of the deck looks like one of the %d questions that have progress but are no longer in the deck.", orphans),
+			qa.window)
+		return
+	}
+
+	chosen := make([]bool, len(matches))
+	list := container.NewVBox(widget.NewLabel("Progress recorded under these QIDs can move to the questions they most likely became:"))
+	for i, m := range matches {
+		i := i
+		check := widget.NewCheck(fmt.Sprintf("%s — %s (%s)  →  %s — %s (%s)   %.0f%% alike",
+			m.Label.Kana, m.Label.Answer, m.From, m.To.QHirakata, m.To.QAnswer, m.To.QID, m.Score*100), func(on bool) {
+			chosen[i] = on
+		})
+		check.SetChecked(m.Score >= 0.9)
+		list.Add(check)
+	}
+
+	var d dialog.Dialog
+	apply := widget.NewButton("Move Checked Progress", func() {
+		moved := 0
+		for i, m := range matches {
+			if chosen[i] {
+				qa.progress.moveProgress(m.From, m.To.QID)
+				moved++
+			}
+		}
+		if err := qa.progress.save(); err != nil {
+			log.Printf("Failed to save progress: %v", err)
+		}
+		qa.progressChanged()
+		d.Hide()
+		dialog.ShowInformation("Reconcile Progress", fmt.Sprintf("Moved the progress of %d questions.", moved), qa.window)
+	})
+	apply.Importance = widget.HighImportance
+	d = dialog.NewCustom("Reconcile Progress", "Cancel", container.NewBorder(nil, apply, nil, nil, container.NewVScroll(list)), qa.window)
+	d.Resize(fyne.NewSize(680, 460))
+	d.Show()
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// reportsFile collects the problems learners reported in questions, for the deck's maintainers
+const reportsFile = "reports.csv"
+
+// reportHeader are the columns of reportsFile
+var reportHeader = []string{"Reported", "Deck", "QID", "Chapter", "Kana", "Romaji", "Answer", "Problem", "Note"}
+
+// reportProblems are the kinds of problems offered when reporting a question
+var reportProblems = []string{
+	"Wrong answer",
+	"Missing accepted answer",
+	"Wrong kana or kanji",
+	"Wrong romaji",
+	"Typo",
+	"Other",
+}
+
+// questionReport is a problem reported in a question
+type questionReport struct {
+	At       time.Time
+	Deck     string
+	Question Question
+	Problem  string
+	Note     string
+}
+
+// title summarizes a report in one line, e.g. for an issue title
+func (r questionReport) title() string {
+	return fmt.Sprintf("%s: %s (%s)", r.Problem, r.Question.QHirakata, r.Question.QID)
+}
+
+// body describes a report for a maintainer
+func (r questionReport) body() string {
+	q := r.Question
+	return fmt.Sprintf("Deck: %s\nQuestion: %s (chapter %s)\nKana: %s\nRomaji: %s\nAnswer: %s\nProblem: %s\n\n%s",
+		r.Deck, q.QID, q.QChapter, q.QHirakata, q.QRomaji, q.QAnswer, r.Problem, r.Note)
+}
+
+// appendReport adds a report to a reports file, writing the header first if the file is new
+func appendReport(path string, r questionReport) error {
+	_, err := os.Stat(path)
+	isNew := errors.Is(err, os.ErrNotExist)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if isNew {
+		w.Write(reportHeader)
+	}
+	q := r.Question
+	w.Write([]string{r.At.Format(time.RFC3339), r.Deck, q.QID, q.QChapter, q.QHirakata, q.QRomaji, q.QAnswer, r.Problem, r.Note})
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// reportURL fills the placeholders of an issue URL template, such as
+// "https://github.com/owner/deck/issues/new?title={title}&body={body}"
+func reportURL(template string, r questionReport) (*url.URL, error) {
+	filled := strings.NewReplacer(
+		"{title}", url.QueryEscape(r.title()),
+		"{body}", url.QueryEscape(r.body()),
+		"{qid}", url.QueryEscape(r.Question.QID),
+		"{deck}", url.QueryEscape(r.Deck),
+	).Replace(template)
+	u, err := url.Parse(filled)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("the report address must start with https://")
+	}
+	return u, nil
+}
+
+// reportQuestion asks what is wrong with a question and saves the report in reportsFile.
+// With a report address set, the report also opens there, e.g. as a new GitHub issue.
+func (qa *quizApp) reportQuestion(q Question) {
+	problemSelect := widget.NewSelect(reportProblems, nil)
+	problemSelect.SetSelected(reportProblems[0])
+	noteEntry := widget.NewMultiLineEntry()
+	noteEntry.SetPlaceHolder("What should it be?")
+	noteEntry.Wrapping = fyne.TextWrapWord
+
+	dialog.ShowForm(fmt.Sprintf("Report a Problem with %s", q.QHirakata), "Report", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Problem", problemSelect),
+			widget.NewFormItem("Note", noteEntry),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			report := questionReport{
+				At:       time.Now().Truncate(time.Second),
+				Deck:     qa.deckName,
+				Question: q,
+				Problem:  problemSelect.Selected,
+				Note:     strings.TrimSpace(noteEntry.Text),
+			}
+			saved := "Thank you! The report was saved in " + dataPath(reportsFile) + "."
+			if guestMode {
+				saved = "Thank you! Reports are not saved in guest mode."
+			} else if err := appendReport(dataPath(reportsFile), report); err != nil {
+				log.Printf("Failed to save report: %v", err)
+				dialog.ShowError(err, qa.window)
+				return
+			}
+			// Kiosk computers do not open a browser
+			if template := qa.settings.ReportURL; template != "" && !qa.kiosk {
+				u, err := reportURL(template, report)
+				if err == nil {
+					err = qa.app.OpenURL(u)
+				}
+				if err != nil {
+					log.Printf("Failed to open report address: %v", err)
+				}
+			}
+			dialog.ShowInformation("Report a Problem", saved, qa.window)
+		}, qa.window)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/software"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+)
+
+// resultCardSize is the size of a result card, rendered at resultCardScale
+var resultCardSize = fyne.NewSize(480, 270)
+
+// resultCardScale renders the card at twice its size, so it stays sharp when shared
+const resultCardScale = 2
+
+// Colours of the result card, fixed so the card looks the same in every theme
+var (
+	resultCardBackground = color.NRGBA{R: 0x1e, G: 0x2a, B: 0x44, A: 0xff}
+	resultCardAccent     = color.NRGBA{R: 0xff, G: 0x8a, B: 0x65, A: 0xff}
+	resultCardText       = color.White
+	resultCardMuted      = color.NRGBA{R: 0xb0, G: 0xbc, B: 0xd4, A: 0xff}
+)
+
+// cardText creates a centred line of text for the result card
+func cardText(text string, size float32, c color.Color, bold bool) *canvas.Text {
+	t := canvas.NewText(text, c)
+	t.TextSize = size
+	t.TextStyle = fyne.TextStyle{Bold: bold}
+	t.Alignment = fyne.TextAlignCenter
+	return t
+}
+
+// renderResultCard draws a shareable card with the score, quiz, streak and date
+func renderResultCard(result quizResult, streak int) image.Image {
+	streakText := "Start a daily streak tomorrow!"
+	if streak > 0 {
+		streakText = fmt.Sprintf("%d-day streak", streak)
+	}
+
+	card := container.NewStack(
+		canvas.NewRectangle(resultCardBackground),
+		container.NewVBox(
+			layout.NewSpacer(),
+			cardText("Genki Quiz", 18, resultCardAccent, true),
+			cardText(result.Quiz, 16, resultCardText, false),
+			cardText(fmt.Sprintf("%d/%d", result.Score, result.Total), 64, resultCardText, true),
+			cardText(fmt.Sprintf("%.0f%% correct", result.percent()), 18, resultCardText, false),
+			cardText(streakText, 14, resultCardAccent, false),
+			cardText(result.CompletedAt.Format("January 2, 2006"), 12, resultCardMuted, false),
+			layout.NewSpacer(),
+		),
+	)
+
+	c := software.NewCanvas()
+	c.SetPadded(false)
+	c.SetScale(resultCardScale)
+	c.SetContent(card)
+	c.Resize(resultCardSize)
+	return c.Capture()
+}
+
+// showResultCard previews the result card and offers to save it as a PNG
+func (qa *quizApp) showResultCard(result quizResult) {
+	img := renderResultCard(result, qa.progress.dailyStreak(time.Now()))
+	preview := canvas.NewImageFromImage(img)
+	preview.FillMode = canvas.ImageFillContain
+	preview.SetMinSize(resultCardSize)
+
+	dialog.ShowCustomConfirm("Result Card", "Save Image", "Close", preview, func(save bool) {
+		if !save {
+			return
+		}
+		fileSave := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, qa.window)
+				return
+			}
+			if writer == nil {
+				return // Cancelled
+			}
+			defer writer.Close()
+
+			if err := png.Encode(writer, img); err != nil {
+				dialog.ShowError(err, qa.window)
+			}
+		}, qa.window)
+		fileSave.SetFilter(storage.NewExtensionFileFilter([]string{".png"}))
+		fileSave.SetFileName(fmt.Sprintf("%s %s.png", result.Quiz, result.CompletedAt.Format(dateLayout)))
+		fileSave.Show()
+	}, qa.window)
+}
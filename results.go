@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// quizResult is one completed quiz, as exported by students and imported in teacher mode
+type quizResult struct {
+	Student     string    `json:"student"`
+	Quiz        string    `json:"quiz"`    // Assignment or quiz name
+	Chapter     string    `json:"chapter"` // Chapter(s) covered
+	Score       int       `json:"score"`
+	Total       int       `json:"total"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// resultHeader is the header row of exported result CSVs
+var resultHeader = []string{"student", "quiz", "chapter", "score", "total", "percent", "completed_at"}
+
+// percent returns the score as a percentage of the total
+func (r quizResult) percent() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Score) / float64(r.Total) * 100
+}
+
+// sameAttempt reports whether two results describe the same quiz attempt
+func (r quizResult) sameAttempt(other quizResult) bool {
+	return strings.EqualFold(r.Student, other.Student) &&
+		r.Quiz == other.Quiz &&
+		r.CompletedAt.Equal(other.CompletedAt)
+}
+
+// writeResultsCSV writes results in the student export format
+func writeResultsCSV(w io.Writer, results []quizResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(resultHeader); err != nil {
+		return err
+	}
+	for _, r := range results {
+		record := []string{
+			r.Student,
+			r.Quiz,
+			r.Chapter,
+			strconv.Itoa(r.Score),
+			strconv.Itoa(r.Total),
+			fmt.Sprintf("%.1f", r.percent()),
+			r.CompletedAt.Format(time.RFC3339),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readResultsCSV parses a result CSV produced by writeResultsCSV
+func readResultsCSV(r io.Reader) ([]quizResult, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || !strings.EqualFold(strings.Join(records[0], ","), strings.Join(resultHeader, ",")) {
+		return nil, fmt.Errorf("not a Genki Quiz result file (expected header %q)", strings.Join(resultHeader, ","))
+	}
+
+	var results []quizResult
+	for i, record := range records[1:] {
+		line := i + 2
+		if len(record) < len(resultHeader) {
+			return nil, fmt.Errorf("line %d: expected %d columns, got %d", line, len(resultHeader), len(record))
+		}
+		score, err := strconv.Atoi(record[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid score %q", line, record[3])
+		}
+		total, err := strconv.Atoi(record[4])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid total %q", line, record[4])
+		}
+		completedAt, err := time.Parse(time.RFC3339, record[6])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid timestamp %q", line, record[6])
+		}
+		results = append(results, quizResult{
+			Student:     record[0],
+			Quiz:        record[1],
+			Chapter:     record[2],
+			Score:       score,
+			Total:       total,
+			CompletedAt: completedAt,
+		})
+	}
+	return results, nil
+}
+
+// exportResult asks for the student's name and saves the result as a CSV for their teacher
+func (qa *quizApp) exportResult(result quizResult) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Your name")
+	dialog.ShowForm("Export Results", "Save", "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("Name", nameEntry)},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			result.Student = strings.TrimSpace(nameEntry.Text)
+			if result.Student == "" {
+				dialog.ShowInformation("Export Results", "Please enter your name.", qa.window)
+				return
+			}
+
+			save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+				if err != nil {
+					dialog.ShowError(err, qa.window)
+					return
+				}
+				if writer == nil {
+					return // Cancelled
+				}
+				defer writer.Close()
+
+				if err := writeResultsCSV(writer, []quizResult{result}); err != nil {
+					dialog.ShowError(err, qa.window)
+				}
+			}, qa.window)
+			save.SetFileName(fmt.Sprintf("%s - %s.csv", result.Student, result.Quiz))
+			save.Show()
+		}, qa.window)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// archiveReviewShare mixes one archived item into due reviews per this many due items, and at least one
+const archiveReviewShare = 10
+
+// retired reports whether a question was moved into the mastered archive
+func (p *Progress) retired(qid string) bool {
+	_, ok := p.Retired[qid]
+	return ok
+}
+
+// unretire brings an archived item back into study, to be retired again after as many reviews
+func (p *Progress) unretire(qid string) {
+	delete(p.Retired, qid)
+	if s := p.stats(qid); s != nil {
+		s.ReviewStreak = 0
+	}
+}
+
+// updateRetirement moves an item into the mastered archive once it was answered right
+// on schedule retireAfter times in a row, and takes an archived item back out when it is missed.
+// A retireAfter of 0 never retires items.
+func (p *Progress) updateRetirement(qid string, retireAfter int) {
+	s := p.stats(qid)
+	if s == nil {
+		return
+	}
+	switch {
+	case s.Streak == 0:
+		p.unretire(qid)
+	case retireAfter > 0 && s.ReviewStreak >= retireAfter && !p.retired(qid):
+		if p.Retired == nil {
+			p.Retired = make(map[string]time.Time)
+		}
+		p.Retired[qid] = s.LastSeen.Truncate(time.Second)
+	}
+}
+
+// archivedForReview picks archived questions to keep an eye on in a review of due items,
+// those not seen for the longest first
+func archivedForReview(questions []Question, progress *Progress, due int) []Question {
+	var archived []Question
+	for _, q := range questions {
+		if progress.retired(q.QID) && !progress.suspended(q.QID) {
+			archived = append(archived, q)
+		}
+	}
+	sort.SliceStable(archived, func(i, j int) bool {
+		return progress.stats(archived[i].QID).LastSeen.Before(progress.stats(archived[j].QID).LastSeen)
+	})
+	return archived[:min(len(archived), max(1, due/archiveReviewShare))]
+}
+
+// showArchive lists the items in the mastered archive, each with a button to bring it back into study
+func (qa *quizApp) showArchive() {
+	qa.showSetAside("Mastered Archive",
+		"The archive is empty. Turn on retirement under Settings ▸ Mastered Archive to move well-known items here.",
+		"retired", "Restore",
+		qa.progress.Retired, qa.progress.unretire)
+}
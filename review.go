@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// dueQuestions returns the studied questions due for review by the given time, most overdue first
+func dueQuestions(questions []Question, progress *Progress, by time.Time) []Question {
+	var due []Question
+	for _, q := range questions {
+		if stats := progress.stats(q.QID); stats != nil && !stats.dueAt().After(by) {
+			due = append(due, q)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return progress.stats(due[i].QID).dueAt().Before(progress.stats(due[j].QID).dueAt())
+	})
+	return due
+}
+
+// duePanel lists the items due today by chapter, with a button to review them all
+func (qa *quizApp) duePanel() fyne.CanvasObject {
+	today := endOfDay(time.Now())
+	panel := container.NewVBox(widget.NewLabelWithStyle("Due Today", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+
+	total := 0
+	chapters := container.NewGridWithColumns(2)
+	for _, chapter := range qa.availableChapters() {
+		due := countDue(qa.studyChapters([]string{chapter}), qa.progress, today)
+		if due == 0 {
+			continue
+		}
+		total += due
+		chapters.Add(widget.NewLabel("Chapter " + chapter))
+		chapters.Add(widget.NewLabel(fmt.Sprintf("%d due", due)))
+	}
+	if total == 0 {
+		panel.Add(widget.NewLabel("Nothing is due for review today."))
+		return panel
+	}
+
+	review := widget.NewButton(fmt.Sprintf("Review All Due (%d)", total), qa.serialized(qa.startDueReview))
+	review.Importance = widget.HighImportance
+	panel.Add(chapters)
+	panel.Add(review)
+	return panel
+}
+
+// startDueReview quizzes every item due for review today, most overdue first, followed by
+// a few items from the mastered archive to check they are still known
+func (qa *quizApp) startDueReview() {
+	state := qa.state
+	now := time.Now()
+	state.chapterQuestions = qa.studyChapters(qa.availableChapters())
+	state.quizQuestions = dueQuestions(state.chapterQuestions, qa.progress, endOfDay(now))
+	if len(state.quizQuestions) > 0 {
+		archived := archivedForReview(qa.index.inChapters(qa.availableChapters()), qa.progress, len(state.quizQuestions))
+		state.quizQuestions = append(state.quizQuestions, archived...)
+	}
+	state.totalQuestions = len(state.quizQuestions)
+	state.currentChapter = "Due Review"
+	state.quizName = "Due Review " + now.Format(dateLayout)
+	if state.totalQuestions == 0 {
+		state.reset()
+		return
+	}
+	qa.startQuiz()
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// romajiStyle is how generated Hepburn romaji writes long vowels
+type romajiStyle string
+
+// Long vowel styles of generated romaji
+const (
+	romajiDoubled romajiStyle = ""       // Doubled the way they are typed: koohii, toukyou
+	romajiMacron  romajiStyle = "macron" // Macrons: kōhī, tōkyō
+	romajiPlain   romajiStyle = "plain"  // Not marked: kohi, tokyo
+)
+
+// romajiStyleNames are the long vowel styles offered in the settings
+var romajiStyleNames = map[romajiStyle]string{
+	romajiDoubled: "Doubled (koohii, toukyou)",
+	romajiMacron:  "Macrons (kōhī, tōkyō)",
+	romajiPlain:   "Unmarked (kohi, tokyo)",
+}
+
+// macrons lengthen a vowel
+var macrons = map[rune]rune{'a': 'ā', 'i': 'ī', 'u': 'ū', 'e': 'ē', 'o': 'ō'}
+
+// isHepburnLong reports whether mora lengthens the vowel of prev the way Hepburn marks it:
+// ああ, うう, おう, おお, ええ and the long vowel mark. いい and えい are written out.
+func isHepburnLong(prev, mora string) bool {
+	switch moraVowel(prev) {
+	case 'a':
+		return mora == "あ" || mora == "ー"
+	case 'u':
+		return mora == "う" || mora == "ー"
+	case 'o':
+		return mora == "う" || mora == "お" || mora == "ー"
+	case 'e':
+		return mora == "え" || mora == "ー"
+	case 'i':
+		return mora == "ー"
+	}
+	return false
+}
+
+// romajiSymbols are written in romaji as these
+var romajiSymbols = map[string]string{"〜": "~", "～": "~", "／": " / ", "　": " "}
+
+// isLatinLetter reports whether a mora is a letter of the Latin alphabet, as the T of Tシャツ
+func isLatinLetter(mora string) bool {
+	return len(mora) == 1 && unicode.IsLetter(rune(mora[0]))
+}
+
+// romanize spells kana in Hepburn romaji with long vowels in a style. ん is written n',
+// before a vowel or y, so that きんえん and きねん differ, and っ doubles the next consonant.
+// The particle を is written apart as wo, a Latin letter is joined to the kana after it
+// with a hyphen (T-shatsu), and 〜, ／ and the spaces between words are kept.
+func romanize(kana string, style romajiStyle) string {
+	var out []rune
+	double := false // A small っ doubles the next consonant
+	latin := false  // The previous mora was a Latin letter
+	morae := splitMora(toHiragana(kana))
+	for i, mora := range morae {
+		if latin && isKana(mora) {
+			out = append(out, '-')
+		}
+		latin = isLatinLetter(mora)
+		if style != romajiDoubled && i > 0 && morae[i-1] != "を" && isHepburnLong(morae[i-1], mora) {
+			if last := len(out) - 1; style == romajiMacron && last >= 0 && macrons[out[last]] != 0 {
+				out[last] = macrons[out[last]]
+			}
+			continue
+		}
+		romaji, ok := kanaRomaji[mora]
+		switch {
+		case mora == "っ":
+			double = true
+			continue
+		case mora == "ん":
+			romaji = "n"
+			if i+1 < len(morae) {
+				if next := kanaRomaji[morae[i+1]]; next != "" && isRomajiVowel(next[0]) {
+					romaji = "n'"
+				}
+			}
+		case mora == "ー":
+			// The long-vowel mark repeats the previous vowel
+			romaji = ""
+			if len(out) > 0 {
+				romaji = string(out[len(out)-1])
+			}
+		case mora == "を":
+			romaji = " wo "
+		case !ok:
+			romaji = mora
+			if symbol, ok := romajiSymbols[mora]; ok {
+				romaji = symbol
+			}
+		}
+		if double && romaji != "" {
+			if strings.HasPrefix(romaji, "ch") {
+				out = append(out, 't')
+			} else {
+				out = append(out, rune(romaji[0]))
+			}
+		}
+		double = false
+		out = append(out, []rune(romaji)...)
+	}
+	return strings.Join(strings.Fields(strings.ReplaceAll(string(out), "~ ", "~")), " ")
+}
+
+// isRomanizable reports whether romanize can spell text: kana, with the symbols and
+// spaces it keeps and Latin letters such as the T of Tシャツ
+func isRomanizable(text string) bool {
+	hasKana := false
+	for _, r := range text {
+		switch {
+		case isKana(string(r)):
+			hasKana = true
+		case r == ' ' || isLatinLetter(string(r)):
+		case romajiSymbols[string(r)] == "":
+			return false
+		}
+	}
+	return hasKana
+}
+
+// questionRomaji spells the kana of a question for a deck without romaji. The う that
+// ends a verb is never a long vowel, as in おもう. Alternatives such as なん／なに are
+// each capitalized.
+func questionRomaji(q Question, style romajiStyle) string {
+	kana := q.QHirakata
+	if !isRomanizable(kana) {
+		return ""
+	}
+	romaji := romanize(kana, style)
+	if questionCategory(q) == "verb" && style != romajiDoubled && strings.HasSuffix(kana, "う") {
+		romaji = romanize(strings.TrimSuffix(kana, "う"), style) + "u"
+	}
+	alternatives := strings.Split(romaji, " / ")
+	for i, alternative := range alternatives {
+		alternatives[i] = capitalizeRomaji(alternative)
+	}
+	return strings.Join(alternatives, " / ")
+}
+
+// capitalizeRomaji capitalizes romaji the way the bundled deck writes it, e.g. "Gakkou"
+func capitalizeRomaji(romaji string) string {
+	first, size := utf8.DecodeRuneInString(romaji)
+	if size == 0 {
+		return ""
+	}
+	return string(unicode.ToUpper(first)) + romaji[size:]
+}
+
+// macronVowels spell out the vowels romaji lengthens with a macron or a circumflex.
+// ō is taken as おう, the more common spelling, though it may be おお.
+var macronVowels = strings.NewReplacer(
+	"ā", "aa", "ī", "ii", "ū", "uu", "ē", "ee", "ō", "ou",
+	"â", "aa", "î", "ii", "û", "uu", "ê", "ee", "ô", "ou",
+)
+
+// romajiParticles are the particles written as they sound in romaji but with other kana
+var romajiParticles = map[string]string{"wa": "は", "e": "へ", "o": "を"}
+
+// kanaFromRomaji makes the kana of a deck that only has romaji, and tells what about
+// it may need checking, or "" if nothing does. Words that stand alone as wa, e and o
+// are taken as particles, and a word with a long vowel mark as a loanword in katakana.
+func kanaFromRomaji(romaji string) (kana, check string) {
+	text := strings.ToLower(romaji)
+	var checks []string
+	if spelled := macronVowels.Replace(text); spelled != text {
+		checks = append(checks, "vowels with a macron are spelled out, though ō may be おお, or ー in a loanword")
+		text = spelled
+	}
+
+	words := strings.Fields(text)
+	var b strings.Builder
+	for _, word := range words {
+		if particle, ok := romajiParticles[word]; ok && len(words) > 1 {
+			checks = append(checks, word+" is taken as the particle "+particle)
+			b.WriteString(particle)
+			continue
+		}
+		b.WriteString(romajiToKana(strings.Trim(word, ".,!?")))
+	}
+	kana = b.String()
+
+	if strings.Contains(kana, "ー") {
+		kana = toKatakana(kana)
+		checks = append(checks, "written in katakana for its long vowel mark")
+	}
+	if !isKana(kana) {
+		checks = append(checks, "some letters are not romaji")
+	}
+	return kana, strings.Join(checks, "; ")
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestRomanize(t *testing.T) {
+	tests := []struct {
+		kana  string
+		style romajiStyle
+		want  string
+	}{
+		{"こーひー", romajiDoubled, "koohii"},
+		{"こーひー", romajiMacron, "kōhī"},
+		{"こーひー", romajiPlain, "kohi"},
+		{"とうきょう", romajiMacron, "tōkyō"},
+		{"きんえん", romajiDoubled, "kin'en"},
+		{"きねん", romajiDoubled, "kinen"},
+		{"がっこう", romajiDoubled, "gakkou"},
+		{"Tシャツ", romajiDoubled, "T-shatsu"},
+		{"ほんをよむ", romajiDoubled, "hon wo yomu"},
+		{"これをおくる", romajiMacron, "kore wo okuru"}, // を and お are not a long vowel
+	}
+	for _, tt := range tests {
+		if got := romanize(tt.kana, tt.style); got != tt.want {
+			t.Errorf("romanize(%q, %q) = %q, want %q", tt.kana, tt.style, got, tt.want)
+		}
+	}
+}
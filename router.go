@@ -0,0 +1,115 @@
+package main
+
+import (
+	"image/color"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+)
+
+// transition is how a new screen replaces the one being shown
+type transition int
+
+const (
+	transitionNone  transition = iota
+	transitionFade             // The new screen fades in
+	transitionSlide            // The new screen slides in from the right, pushing the old one out
+)
+
+// screenTransitionTime is how long a transition between screens takes
+const screenTransitionTime = 250 * time.Millisecond
+
+// screenRouter shows one screen at a time in the main window and animates the changes
+type screenRouter struct {
+	mu        sync.Mutex        // Guards the fields below against timer and animation goroutines
+	container *fyne.Container   // Stack holding the screen, plus the old one during a transition
+	current   fyne.CanvasObject // Screen being shown
+	animation *fyne.Animation   // Running transition, nil when there is none
+	session   *screenSession    // Timers of the current screen, ended when it is replaced
+}
+
+// newScreenRouter creates an empty router
+func newScreenRouter() *screenRouter {
+	return &screenRouter{container: container.NewStack(), session: &screenSession{}}
+}
+
+// currentSession returns the session of the screen being shown
+func (r *screenRouter) currentSession() *screenSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.session
+}
+
+// show replaces the current screen with content, ending the session of the old one.
+// The old session ends outside r.mu, as its stop funcs may look at the new one.
+func (r *screenRouter) show(content fyne.CanvasObject, t transition) {
+	r.mu.Lock()
+	ended := r.session
+	r.session = &screenSession{}
+	r.mu.Unlock()
+	ended.end()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.animation != nil {
+		r.animation.Stop()
+		r.animation = nil
+	}
+	old := r.current
+	r.current = content
+	if old == nil || t == transitionNone || !fyne.CurrentApp().Settings().ShowAnimations() {
+		r.finish()
+		return
+	}
+
+	var tick func(float32)
+	switch t {
+	case transitionFade:
+		// A cover in the background colour is put over the new screen and faded out
+		background := color.NRGBAModel.Convert(theme.BackgroundColor()).(color.NRGBA)
+		cover := canvas.NewRectangle(background)
+		r.container.Objects = []fyne.CanvasObject{content, cover}
+		tick = func(done float32) {
+			faded := background
+			faded.A = uint8(float32(background.A) * (1 - done))
+			cover.FillColor = faded
+			cover.Refresh()
+		}
+	case transitionSlide:
+		width := r.container.Size().Width
+		r.container.Objects = []fyne.CanvasObject{old, content}
+		tick = func(done float32) {
+			old.Move(fyne.NewPos(-width*done, 0))
+			content.Move(fyne.NewPos(width*(1-done), 0))
+		}
+	}
+	r.container.Refresh()
+	tick(0)
+
+	var animation *fyne.Animation
+	animation = fyne.NewAnimation(screenTransitionTime, func(done float32) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.animation != animation {
+			return // Replaced by a newer screen
+		}
+		tick(done)
+		if done >= 1 {
+			r.animation = nil
+			r.finish()
+		}
+	})
+	animation.Curve = fyne.AnimationEaseOut
+	r.animation = animation
+	animation.Start()
+}
+
+// finish leaves only the current screen in place. It is called holding r.mu.
+func (r *screenRouter) finish() {
+	r.container.Objects = []fyne.CanvasObject{r.current}
+	r.container.Refresh()
+}
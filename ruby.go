@@ -0,0 +1,262 @@
+package main
+
+import (
+	"image/color"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// rubyScale is the size of furigana relative to the text they annotate
+const rubyScale = 0.5
+
+// rubySegment is a run of text with the reading shown above it, if any
+type rubySegment struct {
+	Text    string
+	Reading string
+}
+
+// isKanjiRune reports whether r is written with a kanji reading, including the repeat mark 々
+func isKanjiRune(r rune) bool {
+	return unicode.Is(unicode.Han, r) || r == '々'
+}
+
+// rubySegments splits a word into kanji and kana runs and gives each kanji run its part
+// of the reading, e.g. 食べる/たべる → 食(た) べる. If the kana of the word cannot be
+// found in the reading, the whole reading goes over the whole word.
+func rubySegments(text, reading string) []rubySegment {
+	if reading == "" || !containsKanji(text) {
+		return []rubySegment{{Text: text}}
+	}
+	var runs []string
+	var kanji []bool
+	for _, r := range text {
+		if len(runs) > 0 && kanji[len(kanji)-1] == isKanjiRune(r) {
+			runs[len(runs)-1] += string(r)
+			continue
+		}
+		runs = append(runs, string(r))
+		kanji = append(kanji, isKanjiRune(r))
+	}
+
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for i, run := range runs {
+		if kanji[i] {
+			pattern.WriteString("(.+?)")
+		} else {
+			pattern.WriteString("(" + regexp.QuoteMeta(toHiragana(run)) + ")")
+		}
+	}
+	pattern.WriteString("$")
+	reading = toHiragana(reading)
+	match := regexp.MustCompile(pattern.String()).FindStringSubmatch(reading)
+	if match == nil {
+		return []rubySegment{{Text: text, Reading: reading}}
+	}
+	segments := make([]rubySegment, len(runs))
+	for i, run := range runs {
+		segments[i].Text = run
+		if kanji[i] {
+			segments[i].Reading = match[i+1]
+		}
+	}
+	return segments
+}
+
+// rubyText draws text with furigana above its kanji
+type rubyText struct {
+	widget.BaseWidget
+	Segments    []rubySegment
+	TextSize    float32
+	FontSource  fyne.Resource // Font for both the text and the readings, nil for the theme font
+	ShowReading bool
+}
+
+// newRubyText shows text with its reading above the kanji
+func newRubyText(text, reading string) *rubyText {
+	r := &rubyText{Segments: rubySegments(text, reading), TextSize: theme.TextSize(), ShowReading: true}
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// CreateRenderer builds a text and a reading object per segment
+func (r *rubyText) CreateRenderer() fyne.WidgetRenderer {
+	renderer := &rubyTextRenderer{r: r}
+	renderer.Refresh()
+	return renderer
+}
+
+// rubyTextRenderer lays out the segments of a rubyText side by side, readings on top
+type rubyTextRenderer struct {
+	r        *rubyText
+	texts    []*canvas.Text
+	readings []*canvas.Text
+}
+
+// segmentWidth is the width of a segment, which is wider than its text if the reading is
+func (r *rubyTextRenderer) segmentWidth(i int) float32 {
+	width := r.texts[i].MinSize().Width
+	if r.readings[i].Visible() {
+		width = max(width, r.readings[i].MinSize().Width)
+	}
+	return width
+}
+
+// readingHeight is the height of the reading row, 0 if no reading is shown
+func (r *rubyTextRenderer) readingHeight() float32 {
+	var height float32
+	for _, reading := range r.readings {
+		if reading.Visible() {
+			height = max(height, reading.MinSize().Height)
+		}
+	}
+	return height
+}
+
+// Layout centers the segments, each reading centered over its text
+func (r *rubyTextRenderer) Layout(size fyne.Size) {
+	minSize := r.MinSize()
+	x := (size.Width - minSize.Width) / 2
+	top := (size.Height - minSize.Height) / 2
+	readingHeight := r.readingHeight()
+	for i, text := range r.texts {
+		width := r.segmentWidth(i)
+		textSize := text.MinSize()
+		text.Move(fyne.NewPos(x+(width-textSize.Width)/2, top+readingHeight))
+		text.Resize(textSize)
+		readingSize := r.readings[i].MinSize()
+		r.readings[i].Move(fyne.NewPos(x+(width-readingSize.Width)/2, top))
+		r.readings[i].Resize(readingSize)
+		x += width
+	}
+}
+
+// MinSize fits all segments in a row, with room for the readings
+func (r *rubyTextRenderer) MinSize() fyne.Size {
+	var width, height float32
+	for i, text := range r.texts {
+		width += r.segmentWidth(i)
+		height = max(height, text.MinSize().Height)
+	}
+	return fyne.NewSize(width, height+r.readingHeight())
+}
+
+// Refresh rebuilds the text objects from the segments
+func (r *rubyTextRenderer) Refresh() {
+	r.texts, r.readings = nil, nil
+	for _, segment := range r.r.Segments {
+		text := canvas.NewText(segment.Text, theme.ForegroundColor())
+		text.TextSize = r.r.TextSize
+		text.FontSource = r.r.FontSource
+		reading := canvas.NewText(segment.Reading, theme.ForegroundColor())
+		reading.TextSize = r.r.TextSize * rubyScale
+		reading.FontSource = r.r.FontSource
+		if segment.Reading == "" || !r.r.ShowReading {
+			reading.Hide()
+		}
+		r.texts = append(r.texts, text)
+		r.readings = append(r.readings, reading)
+	}
+	r.Layout(r.r.Size())
+	canvas.Refresh(r.r)
+}
+
+// Objects returns the texts and readings
+func (r *rubyTextRenderer) Objects() []fyne.CanvasObject {
+	objects := make([]fyne.CanvasObject, 0, len(r.texts)*2)
+	for i := range r.texts {
+		objects = append(objects, r.texts[i], r.readings[i])
+	}
+	return objects
+}
+
+// Destroy has nothing to release
+func (r *rubyTextRenderer) Destroy() {}
+
+// rubyButton is a button labelled with a rubyText, for answer options written in kanji
+type rubyButton struct {
+	widget.BaseWidget
+	button    *widget.Button
+	highlight *canvas.Rectangle // Flashed to give feedback, see pulse
+	label     *rubyText
+	mark      string  // Shown before the label once answered, e.g. "✅"
+	minHeight float32 // Makes the button taller than its label, e.g. thumbButtonHeight
+
+	menu func() *fyne.Menu // Opened on a right-click or long-press, nil for none
+}
+
+// newRubyButton creates a button for text with the given reading
+func newRubyButton(text, reading string, tapped func()) *rubyButton {
+	b := &rubyButton{
+		button:    widget.NewButton("", tapped),
+		highlight: canvas.NewRectangle(color.Transparent),
+		label:     newRubyText(text, reading),
+	}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+// CreateRenderer places the label over the button. The label does not take taps, so they reach the button.
+func (b *rubyButton) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewStack(b.button, b.highlight, container.NewPadded(b.label)))
+}
+
+// MinSize is the size of the label, but at least minHeight tall
+func (b *rubyButton) MinSize() fyne.Size {
+	return b.BaseWidget.MinSize().Max(fyne.NewSize(0, b.minHeight))
+}
+
+// setMark shows a mark such as "✅" before the label
+func (b *rubyButton) setMark(mark string) {
+	segments := b.label.Segments
+	if b.mark != "" {
+		segments = segments[1:]
+	}
+	b.mark = mark
+	b.label.Segments = append([]rubySegment{{Text: mark + " "}}, segments...)
+	b.label.Refresh()
+	b.Refresh()
+}
+
+// disable stops the button from reacting to taps. Its menu stays available.
+func (b *rubyButton) disable() {
+	b.button.OnTapped = nil
+}
+
+// TappedSecondary opens the button's menu where it was right-clicked or long-pressed
+func (b *rubyButton) TappedSecondary(e *fyne.PointEvent) {
+	if b.menu == nil {
+		return
+	}
+	if c := fyne.CurrentApp().Driver().CanvasForObject(b); c != nil {
+		widget.ShowPopUpMenuAtPosition(b.menu(), c, e.AbsolutePosition)
+	}
+}
+
+// setShowOptionFurigana shows or hides the readings over answer options and remembers the choice
+func (qa *quizApp) setShowOptionFurigana(show bool) {
+	qa.settings.OptionFurigana = show
+	if err := qa.settings.save(); err != nil {
+		log.Printf("Failed to save settings: %v", err)
+	}
+	for _, obj := range qa.optionsContainer.Objects {
+		if b, ok := obj.(*rubyButton); ok {
+			b.label.ShowReading = show
+			b.label.Refresh()
+			b.Refresh()
+		}
+	}
+	qa.optionsContainer.Refresh()
+	if qa.furiganaMenuItem != nil {
+		qa.furiganaMenuItem.Checked = show
+		qa.window.MainMenu().Refresh()
+	}
+}
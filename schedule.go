@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// srsSettings are the parameters of the review schedule. In overrides, zero fields keep
+// the value of the schedule they override.
+type srsSettings struct {
+	FirstInterval  int     `json:"first_interval,omitempty"`  // Days until the review after the first right answer
+	IntervalFactor float64 `json:"interval_factor,omitempty"` // Growth of the interval with each right answer in a row
+	MaxInterval    int     `json:"max_interval,omitempty"`    // Longest interval in days
+	NewPerDay      int     `json:"new_per_day,omitempty"`     // Never-seen items introduced per day at most, 0 for no limit
+	LeechThreshold int     `json:"leech_threshold,omitempty"` // Misses after which an item counts as a leech
+}
+
+// defaultSchedule doubles the interval with every right answer in a row (1, 2, 4, 8… days)
+var defaultSchedule = srsSettings{
+	FirstInterval:  1,
+	IntervalFactor: 2,
+	MaxInterval:    128,
+	LeechThreshold: 8,
+}
+
+// Schedule limits accepted in the settings
+const (
+	maxFirstInterval  = 30
+	maxIntervalFactor = 5.0
+	maxMaxInterval    = 3650
+)
+
+// override returns the schedule with the fields set in o replacing its own
+func (s srsSettings) override(o srsSettings) srsSettings {
+	if o.FirstInterval > 0 {
+		s.FirstInterval = o.FirstInterval
+	}
+	if o.IntervalFactor > 0 {
+		s.IntervalFactor = o.IntervalFactor
+	}
+	if o.MaxInterval > 0 {
+		s.MaxInterval = o.MaxInterval
+	}
+	if o.NewPerDay > 0 {
+		s.NewPerDay = o.NewPerDay
+	}
+	if o.LeechThreshold > 0 {
+		s.LeechThreshold = o.LeechThreshold
+	}
+	return s
+}
+
+// Item ease, in the manner of SM-2: each item's interval grows by the schedule's factor
+// scaled by its ease, so items that are often missed come back sooner
+const (
+	defaultEase   = 2.5
+	minEase       = 1.3
+	maxEase       = 3.5
+	easeBonus     = 0.1 // Added for a right answer when the item was due
+	easePenalty   = 0.2 // Taken off for a wrong answer
+	easeMinFactor = 1.2 // Intervals still grow for the hardest items
+)
+
+// ease returns the ease of an item
+func (s *itemStats) ease() float64 {
+	if s == nil || s.Ease == 0 {
+		return defaultEase
+	}
+	return s.Ease
+}
+
+// adjustEase updates the ease of an item after an answer. Right answers given before the
+// item was due say little about how well it is known, so they leave the ease as it is.
+func (s *itemStats) adjustEase(correct, onSchedule bool) {
+	ease := s.ease()
+	switch {
+	case !correct:
+		ease -= easePenalty
+	case onSchedule:
+		ease += easeBonus
+	}
+	s.Ease = math.Round(min(max(ease, minEase), maxEase)*100) / 100
+}
+
+// interval returns the time until an item should be reviewed again after a number of
+// right answers in a row at an ease; missed items are due right away
+func (s srsSettings) interval(streak int, ease float64) time.Duration {
+	if streak <= 0 {
+		return 0
+	}
+	factor := max(s.IntervalFactor*ease/defaultEase, min(s.IntervalFactor, easeMinFactor))
+	days := float64(s.FirstInterval) * math.Pow(factor, float64(streak-1))
+	days = min(days, float64(s.MaxInterval))
+	return time.Duration(days * float64(24*time.Hour))
+}
+
+// deckSchedule overrides the review schedule for one deck, and for some of its chapters
+type deckSchedule struct {
+	srsSettings
+	Chapters map[string]srsSettings `json:"chapters,omitempty"`
+}
+
+// scheduleFor returns the review schedule of a chapter of a deck: the defaults, the
+// learner's new items limit, then the deck's and the chapter's overrides
+func scheduleFor(settings *Settings, deckName, chapter string) srsSettings {
+	s := defaultSchedule.override(srsSettings{NewPerDay: settings.NewPerDay})
+	deck := settings.Schedules[deckName]
+	return s.override(deck.srsSettings).override(deck.Chapters[chapter])
+}
+
+// schedule returns the review schedule of a chapter of the loaded deck, see scheduleFor
+func (qa *quizApp) schedule(chapter string) srsSettings {
+	return scheduleFor(qa.settings, qa.deckName, chapter)
+}
+
+// answered updates an item after an answer: its statistics, when it is due under the
+// schedule of its chapter, and its place in the mastered archive (retireAfter, 0 for never).
+// Every way of answering goes through here, so items are scheduled alike.
+func (p *Progress) answered(qid string, correct bool, schedule srsSettings, retireAfter int) {
+	p.record(qid, correct)
+	if s := p.stats(qid); s != nil {
+		s.Due = s.LastSeen.Add(schedule.interval(s.Streak, s.ease()))
+	}
+	p.updateRetirement(qid, retireAfter)
+}
+
+// answered applies an answer to a question of the loaded deck, see Progress.answered
+func (qa *quizApp) answered(q Question, correct bool) {
+	qa.progress.answered(q.QID, correct, qa.schedule(q.QChapter), qa.settings.RetireAfter)
+}
+
+// leech reports whether an item was missed so often that it needs another way of learning it
+func (s *itemStats) leech(threshold int) bool {
+	return s != nil && threshold > 0 && s.Seen-s.Correct >= threshold
+}
+
+// leeches returns the questions of a deck that are leeches under their chapter's schedule, most missed first
+func (qa *quizApp) leeches(questions []Question) []Question {
+	var found []Question
+	for _, q := range questions {
+		if qa.progress.stats(q.QID).leech(qa.schedule(q.QChapter).LeechThreshold) {
+			found = append(found, q)
+		}
+	}
+	misses := func(q Question) int {
+		s := qa.progress.stats(q.QID)
+		return s.Seen - s.Correct
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return misses(found[i]) > misses(found[j])
+	})
+	return found
+}
+
+// showScheduleSettings edits the review schedule of the loaded deck and its chapters
+func (qa *quizApp) showScheduleSettings() {
+	const wholeDeck = "Whole deck"
+	scopes := []string{wholeDeck}
+	for _, chapter := range qa.index.chapters {
+		scopes = append(scopes, "Chapter "+chapter)
+	}
+
+	firstEntry := widget.NewEntry()
+	factorEntry := widget.NewEntry()
+	maxEntry := widget.NewEntry()
+	newEntry := widget.NewEntry()
+	leechEntry := widget.NewEntry()
+	chapterOf := func(scope string) string {
+		return strings.TrimPrefix(scope, "Chapter ")
+	}
+	// The entries show the overrides of a scope, with the schedule it overrides as placeholders
+	load := func(scope string) {
+		deck := qa.settings.Schedules[qa.deckName]
+		inherited := defaultSchedule.override(srsSettings{NewPerDay: qa.settings.NewPerDay})
+		own := deck.srsSettings
+		if scope != wholeDeck {
+			inherited = inherited.override(deck.srsSettings)
+			own = deck.Chapters[chapterOf(scope)]
+		}
+		set := func(entry *widget.Entry, value, placeholder string) {
+			entry.SetText(value)
+			entry.SetPlaceHolder(placeholder)
+		}
+		number := func(n int) string {
+			if n == 0 {
+				return ""
+			}
+			return strconv.Itoa(n)
+		}
+		factor := ""
+		if own.IntervalFactor > 0 {
+			factor = strconv.FormatFloat(own.IntervalFactor, 'f', -1, 64)
+		}
+		newPlaceholder := "No limit"
+		if inherited.NewPerDay > 0 {
+			newPlaceholder = strconv.Itoa(inherited.NewPerDay)
+		}
+		set(firstEntry, number(own.FirstInterval), strconv.Itoa(inherited.FirstInterval))
+		set(factorEntry, factor, strconv.FormatFloat(inherited.IntervalFactor, 'f', -1, 64))
+		set(maxEntry, number(own.MaxInterval), strconv.Itoa(inherited.MaxInterval))
+		set(newEntry, number(own.NewPerDay), newPlaceholder)
+		set(leechEntry, number(own.LeechThreshold), strconv.Itoa(inherited.LeechThreshold))
+	}
+	scopeSelect := widget.NewSelect(scopes, load)
+	scopeSelect.SetSelected(wholeDeck)
+
+	// parse reads an entry, empty for no override
+	parse := func(entry *widget.Entry, name string, limit float64, integer bool) (float64, error) {
+		text := strings.TrimSpace(entry.Text)
+		if text == "" {
+			return 0, nil
+		}
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil || value <= 0 || value > limit || integer && value != math.Trunc(value) {
+			return 0, fmt.Errorf("%s must be a number up to %g", name, limit)
+		}
+		return value, nil
+	}
+	save := func() {
+		first, err := parse(firstEntry, "The first interval", maxFirstInterval, true)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		factor, err := parse(factorEntry, "The interval growth", maxIntervalFactor, false)
+		if err == nil && factor > 0 && factor < 1 {
+			err = fmt.Errorf("the interval growth must be at least 1")
+		}
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		longest, err := parse(maxEntry, "The longest interval", maxMaxInterval, true)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		newPerDay, err := parse(newEntry, "The new items per day", math.MaxInt32, true)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		leech, err := parse(leechEntry, "The leech threshold", math.MaxInt32, true)
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		own := srsSettings{
+			FirstInterval:  int(first),
+			IntervalFactor: factor,
+			MaxInterval:    int(longest),
+			NewPerDay:      int(newPerDay),
+			LeechThreshold: int(leech),
+		}
+
+		if qa.settings.Schedules == nil {
+			qa.settings.Schedules = make(map[string]deckSchedule)
+		}
+		deck := qa.settings.Schedules[qa.deckName]
+		if scopeSelect.Selected == wholeDeck {
+			deck.srsSettings = own
+		} else {
+			chapters := make(map[string]srsSettings)
+			for chapter, s := range deck.Chapters {
+				chapters[chapter] = s
+			}
+			chapters[chapterOf(scopeSelect.Selected)] = own
+			if own == (srsSettings{}) {
+				delete(chapters, chapterOf(scopeSelect.Selected))
+			}
+			deck.Chapters = chapters
+		}
+		qa.settings.Schedules[qa.deckName] = deck
+		if err := qa.settings.save(); err != nil {
+			log.Printf("Failed to save settings: %v", err)
+		}
+		dialog.ShowInformation("Review Schedule", "The schedule applies from each item's next answer.", qa.window)
+	}
+
+	form := widget.NewForm(
+		widget.NewFormItem("Applies to", scopeSelect),
+		widget.NewFormItem("First Interval (days)", firstEntry),
+		widget.NewFormItem("Interval Growth (×)", factorEntry),
+		widget.NewFormItem("Longest Interval (days)", maxEntry),
+		widget.NewFormItem("New Items per Day", newEntry),
+		widget.NewFormItem("Leech After (misses)", leechEntry),
+	)
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Overrides for %s. Empty fields keep the schedule shown in grey.", qa.deckName)),
+		form,
+		widget.NewButton("Save", save),
+	)
+	d := dialog.NewCustom("Review Schedule", "Close", content, qa.window)
+	d.Resize(fyne.NewSize(480, 0))
+	d.Show()
+}
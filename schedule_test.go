@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdjustEase(t *testing.T) {
+	tests := []struct {
+		ease                float64
+		correct, onSchedule bool
+		want                float64
+	}{
+		{0, true, true, defaultEase + easeBonus},
+		{0, true, false, defaultEase},
+		{0, false, true, defaultEase - easePenalty},
+		{minEase, false, false, minEase},
+		{maxEase, true, true, maxEase},
+		{1.4, false, true, minEase},
+	}
+	for _, tt := range tests {
+		s := &itemStats{Ease: tt.ease}
+		s.adjustEase(tt.correct, tt.onSchedule)
+		if s.Ease != tt.want {
+			t.Errorf("ease %v, correct %v, on schedule %v: got %v, want %v", tt.ease, tt.correct, tt.onSchedule, s.Ease, tt.want)
+		}
+	}
+}
+
+func TestInterval(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		streak int
+		ease   float64
+		want   time.Duration
+	}{
+		{0, defaultEase, 0},
+		{1, defaultEase, day},
+		{2, defaultEase, 2 * day},
+		{4, defaultEase, 8 * day},
+		{20, defaultEase, 128 * day},                          // MaxInterval
+		{3, minEase, time.Duration(1.2 * 1.2 * float64(day))}, // easeMinFactor
+	}
+	for _, tt := range tests {
+		if got := defaultSchedule.interval(tt.streak, tt.ease); got.Round(time.Minute) != tt.want.Round(time.Minute) {
+			t.Errorf("interval(%d, %v) = %v, want %v", tt.streak, tt.ease, got, tt.want)
+		}
+	}
+}
+
+func TestScheduleFor(t *testing.T) {
+	settings := &Settings{
+		NewPerDay: 20,
+		Schedules: map[string]deckSchedule{
+			"genki.xlsx": {
+				srsSettings: srsSettings{FirstInterval: 2, NewPerDay: 10},
+				Chapters:    map[string]srsSettings{"3": {MaxInterval: 30}},
+			},
+		},
+	}
+	got := scheduleFor(settings, "genki.xlsx", "3")
+	want := srsSettings{FirstInterval: 2, IntervalFactor: 2, MaxInterval: 30, NewPerDay: 10, LeechThreshold: 8}
+	if got != want {
+		t.Errorf("scheduleFor deck chapter = %+v, want %+v", got, want)
+	}
+	got = scheduleFor(settings, "other.xlsx", "3")
+	want = defaultSchedule
+	want.NewPerDay = 20
+	if got != want {
+		t.Errorf("scheduleFor other deck = %+v, want %+v", got, want)
+	}
+}
+
+func TestRetirement(t *testing.T) {
+	p := &Progress{Items: map[string]*itemStats{"1": {Streak: 5, ReviewStreak: 2, LastSeen: time.Now()}}}
+	p.updateRetirement("1", 3)
+	if p.retired("1") {
+		t.Fatal("retired before retireAfter reviews")
+	}
+	p.Items["1"].ReviewStreak = 3
+	p.updateRetirement("1", 0)
+	if p.retired("1") {
+		t.Fatal("retired with retireAfter 0")
+	}
+	p.updateRetirement("1", 3)
+	if !p.retired("1") {
+		t.Fatal("not retired after retireAfter reviews")
+	}
+
+	// A miss takes the item back out of the archive, to be retired after as many reviews again
+	p.answered("1", false, defaultSchedule, 3)
+	if p.retired("1") {
+		t.Error("still retired after a miss")
+	}
+	if s := p.stats("1"); s.ReviewStreak != 0 || s.Due != s.LastSeen {
+		t.Errorf("after a miss: review streak %d, due %v after last seen", s.ReviewStreak, s.Due.Sub(s.LastSeen))
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// migration upgrades a saved JSON document by one schema version, in place
+type migration func(doc map[string]any) error
+
+// progressMigrations upgrade progressFile; migration i goes from version i to i+1
+var progressMigrations = []migration{
+	// 1: items mastered before MasteredAt was recorded get their last answer as the date
+	func(doc map[string]any) error {
+		items, _ := doc["items"].(map[string]any)
+		for _, item := range items {
+			stats, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			streak, _ := stats["streak"].(float64)
+			if _, ok := stats["mastered_at"]; !ok && streak >= masteryStreak {
+				stats["mastered_at"] = stats["last_seen"]
+			}
+		}
+		return nil
+	},
+}
+
+// settingsMigrations upgrade settingsFile; migration i goes from version i to i+1
+var settingsMigrations = []migration{
+	// 1: first versioned format, no changes
+	func(doc map[string]any) error { return nil },
+	// 2: the settings lock and kiosk PINs are saved as salted hashes
+	func(doc map[string]any) error {
+		hash := func(section map[string]any, key string) error {
+			pin, _ := section[key].(string)
+			hashed, err := hashPIN(pin)
+			if err == nil && pin != "" {
+				section[key] = hashed
+			}
+			return err
+		}
+		if err := hash(doc, "lock_pin"); err != nil {
+			return err
+		}
+		if kiosk, ok := doc["kiosk"].(map[string]any); ok {
+			return hash(kiosk, "pin")
+		}
+		return nil
+	},
+	// 3: the Discord bot token is no longer saved; it comes from envDiscordToken
+	func(doc map[string]any) error {
+		if chat, ok := doc["chat"].(map[string]any); ok {
+			delete(chat, "discord_token")
+		}
+		return nil
+	},
+}
+
+// settingsPINsHashed is the settings schema version from which PINs are saved as hashes
+const settingsPINsHashed = 2
+
+// settingsTokenRemoved is the settings schema version from which the Discord token is not saved
+const settingsTokenRemoved = 3
+
+// schemaVersion returns the version a document was saved with; files from before versioning are 0
+func schemaVersion(doc map[string]any) int {
+	version, _ := doc["schema_version"].(float64)
+	return int(version)
+}
+
+// migrate upgrades a saved document to the latest schema.
+// It reports the version the document had, and whether it was changed.
+func migrate(data []byte, migrations []migration) ([]byte, int, bool, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, false, err
+	}
+	from := schemaVersion(doc)
+	if from > len(migrations) {
+		return nil, from, false, fmt.Errorf("saved by a newer version of Genki Quiz (schema %d, this version reads up to %d)", from, len(migrations))
+	}
+	if from == len(migrations) {
+		return data, from, false, nil
+	}
+	for version := from; version < len(migrations); version++ {
+		if err := migrations[version](doc); err != nil {
+			return nil, from, false, fmt.Errorf("upgrading to schema %d: %w", version+1, err)
+		}
+	}
+	doc["schema_version"] = len(migrations)
+	upgraded, err := json.MarshalIndent(doc, "", "  ")
+	return upgraded, from, true, err
+}
+
+// decodeVersioned upgrades saved data to the latest schema and decodes it into v.
+// raw is the file as stored (data may be decrypted from it). When the data had to be
+// upgraded, raw is kept as <file>.v<version>.bak and true is returned, so the caller
+// can save the new format.
+func decodeVersioned(file string, raw, data []byte, migrations []migration, v any) (bool, error) {
+	from, changed, err := decodeLatest(file, data, migrations, v)
+	if err != nil {
+		return false, err
+	}
+	if changed {
+		if err := os.WriteFile(fmt.Sprintf("%s.v%d.bak", file, from), raw, 0o644); err != nil {
+			return false, err
+		}
+	}
+	return changed, nil
+}
+
+// decodeLatest upgrades saved data to the latest schema in memory only and decodes it
+// into v. changed reports whether it was upgraded, from version from.
+func decodeLatest(file string, data []byte, migrations []migration, v any) (from int, changed bool, err error) {
+	upgraded, from, changed, err := migrate(data, migrations)
+	if err != nil {
+		return 0, false, fmt.Errorf("reading %s: %w", file, err)
+	}
+	if err := json.Unmarshal(upgraded, v); err != nil {
+		return 0, false, fmt.Errorf("reading %s: %w", file, err)
+	}
+	return from, changed, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMigrateSettings(t *testing.T) {
+	data := []byte(`{"lock_pin": "1234", "kiosk": {"pin": "9876"}, "chat": {"discord_token": "secret", "channel": "quiz"}}`)
+	upgraded, from, changed, err := migrate(data, settingsMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != 0 || !changed {
+		t.Errorf("migrate: from %d, changed %v; want 0, true", from, changed)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(upgraded, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if got := schemaVersion(doc); got != len(settingsMigrations) {
+		t.Errorf("schema version %d, want %d", got, len(settingsMigrations))
+	}
+	if pin, _ := doc["lock_pin"].(string); !pinMatches(pin, "1234") {
+		t.Errorf("lock PIN %q does not match", pin)
+	}
+	if pin, _ := doc["kiosk"].(map[string]any)["pin"].(string); !pinMatches(pin, "9876") {
+		t.Errorf("kiosk PIN %q does not match", pin)
+	}
+	chat := doc["chat"].(map[string]any)
+	if _, ok := chat["discord_token"]; ok {
+		t.Error("Discord token kept")
+	}
+	if chat["channel"] != "quiz" {
+		t.Errorf("chat channel %v, want quiz", chat["channel"])
+	}
+
+	// The latest version is left alone, a newer one is refused
+	if _, _, changed, err := migrate(upgraded, settingsMigrations); err != nil || changed {
+		t.Errorf("migrate latest: changed %v, err %v", changed, err)
+	}
+	if _, _, _, err := migrate([]byte(`{"schema_version": 99}`), settingsMigrations); err == nil || !strings.Contains(err.Error(), "newer version") {
+		t.Errorf("migrate newer: err %v", err)
+	}
+}
+
+func TestMigrateProgress(t *testing.T) {
+	data := []byte(`{"items": {
+		"1": {"streak": 3, "last_seen": "2024-05-10T15:00:00Z"},
+		"2": {"streak": 1, "last_seen": "2024-05-10T15:00:00Z"},
+		"3": {"streak": 4, "last_seen": "2024-05-10T15:00:00Z", "mastered_at": "2024-01-01T00:00:00Z"}
+	}}`)
+	var p Progress
+	from, changed, err := decodeLatest(progressFile, data, progressMigrations, &p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != 0 || !changed {
+		t.Errorf("decodeLatest: from %d, changed %v; want 0, true", from, changed)
+	}
+	if got := p.stats("1").MasteredAt; !got.Equal(p.stats("1").LastSeen) {
+		t.Errorf("mastered item: mastered at %v, want its last answer", got)
+	}
+	if got := p.stats("2").MasteredAt; !got.IsZero() {
+		t.Errorf("unmastered item: mastered at %v", got)
+	}
+	if got := p.stats("3").MasteredAt.Format(dateLayout); got != "2024-01-01" {
+		t.Errorf("recorded mastery date changed to %s", got)
+	}
+}
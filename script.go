@@ -0,0 +1,67 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// kanaRules decide which differences in script typed kana answers may have from the deck.
+// Full-width letters and half-width katakana always count as their usual forms.
+type kanaRules struct {
+	StrictScript []string `json:"strict_script,omitempty"` // Question categories whose kana must be typed in the script of the deck, e.g. loanwords in katakana
+}
+
+// strict reports whether the kana of a question must be typed in the script of the deck
+func (r kanaRules) strict(q Question) bool {
+	return slices.Contains(r.StrictScript, questionCategory(q))
+}
+
+// scriptOf tells the scripts kana is written in: "h" for hiragana, "k" for katakana,
+// "hk" for both. The long vowel mark belongs to neither.
+func scriptOf(kana string) string {
+	script := ""
+	if strings.ContainsFunc(kana, func(r rune) bool { return r >= 'ぁ' && r <= 'ゖ' }) {
+		script += "h"
+	}
+	if strings.ContainsFunc(kana, func(r rune) bool { return r >= 'ァ' && r <= 'ヶ' }) {
+		script += "k"
+	}
+	return script
+}
+
+// spellOutLongVowels writes each long vowel mark as the vowel it lengthens, so that
+// コーヒー reads as こおひい
+func spellOutLongVowels(kana string) string {
+	var b strings.Builder
+	prev := ""
+	for _, mora := range splitMora(kana) {
+		if mora == "ー" {
+			if vowel := kanaVowels[moraVowel(prev)]; vowel != "" {
+				mora = vowel
+			}
+		}
+		b.WriteString(mora)
+		prev = mora
+	}
+	return b.String()
+}
+
+// kanaVowels are the hiragana of each vowel
+var kanaVowels = map[byte]string{'a': "あ", 'i': "い", 'u': "う", 'e': "え", 'o': "お"}
+
+// sameKana reports whether two kana spellings read the same, in either script and with
+// long vowel marks spelled out
+func sameKana(a, b string) bool {
+	return spellOutLongVowels(toHiragana(a)) == spellOutLongVowels(toHiragana(b))
+}
+
+// matchesKana reports whether a typed answer spells the kana of a question. Romaji has no
+// script, so it matches either; kana typed with an IME must be in the script of the deck
+// when the rules are strict for the question.
+func (r kanaRules) matchesKana(q Question, input string) bool {
+	if !sameKana(typedKana(input), q.QHirakata) {
+		return false
+	}
+	typed := romajiToKana(strings.TrimSpace(foldInputWidth(input)))
+	return !r.strict(q) || !hasKanaInput(input) || scriptOf(typed) == scriptOf(q.QHirakata)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// screenSession owns the timers and countdowns started for the screen being shown. The
+// router ends it when another screen replaces that one, so none of them fires into a
+// screen that is gone, e.g. the next question of a quiz the learner left.
+type screenSession struct {
+	mu     sync.Mutex
+	ended  bool
+	timers []*time.Timer
+	stops  []func()
+}
+
+// active reports whether the screen of the session is still shown
+func (s *screenSession) active() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.ended
+}
+
+// track stops a timer when the session ends, at once if it already has
+func (s *screenSession) track(timer *time.Timer) *time.Timer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		timer.Stop()
+	} else {
+		s.timers = append(s.timers, timer)
+	}
+	return timer
+}
+
+// onEnd calls stop when the session ends, at once if it already has
+func (s *screenSession) onEnd(stop func()) {
+	s.mu.Lock()
+	if !s.ended {
+		s.stops = append(s.stops, stop)
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	stop()
+}
+
+// end stops the timers and countdowns of the session
+func (s *screenSession) end() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	timers, stops := s.timers, s.stops
+	s.timers, s.stops = nil, nil
+	s.mu.Unlock()
+
+	for _, timer := range timers {
+		timer.Stop()
+	}
+	for _, stop := range stops {
+		stop()
+	}
+}
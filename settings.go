@@ -0,0 +1,533 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Settings holds the user's preferences, saved between sessions
+type Settings struct {
+	SchemaVersion int `json:"schema_version"` // See settingsMigrations
+
+	XAPI  xapiSettings  `json:"xapi"`  // Result reporting to a learning record store
+	Kiosk kioskSettings `json:"kiosk"` // Locked-down mode started with -kiosk
+	Chat  chatSettings  `json:"chat"`  // Stream chat answering along in hosted live quizzes
+	Audio audioSettings `json:"audio"` // Recorded audio packs and speech
+
+	Hotkey string `json:"hotkey"` // Global hotkey for a pop-up question, e.g. "Ctrl+Alt+J"
+
+	MuteNotifications bool `json:"mute_notifications"` // No desktop notifications at the end of a session
+
+	DailyQuota int `json:"daily_quota"` // Planned questions per day, used for goal projections
+	NewPerDay  int `json:"new_per_day"` // Never-seen items introduced per day at most, 0 for no limit
+
+	Schedules map[string]deckSchedule `json:"schedules,omitempty"` // Review schedule overrides by deck file name
+
+	ColumnMappings map[string]map[string]int `json:"column_mappings,omitempty"` // Deck columns the learner confirmed, by headerSignature
+
+	AnswerRules answerRules      `json:"answer_rules"` // What typed English answers may differ in
+	NearMiss    nearMissSettings `json:"near_miss"`    // Grading of typed answers that are almost right
+	KanaRules   kanaRules        `json:"kana_rules"`   // What typed kana answers may differ in
+
+	Progression progressionSettings `json:"progression"` // Chapters unlocking one after another
+
+	RetireAfter int `json:"retire_after"` // On-schedule correct reviews in a row that move an item to the mastered archive, 0 for never
+
+	QuestionMix questionMix  `json:"question_mix,omitempty"` // Share of each question category in a session
+	Presets     []QuizPreset `json:"presets,omitempty"`      // Saved quiz setups offered on the home screen
+
+	ReportURL string `json:"report_url"` // Issue address problem reports also open, with {title}, {body}, {qid} and {deck}; empty for none
+
+	LockPIN string `json:"lock_pin"` // Hash of the PIN required to change settings and decks, empty for no lock, see hashPIN
+
+	RomajiStyle romajiStyle `json:"romaji_style,omitempty"` // Long vowels in romaji made for decks without it
+
+	Dictation dictationSettings `json:"dictation"` // Numbers read out in the numbers dictation drill
+
+	ShowRomaji     bool   `json:"show_romaji"`     // Show the romaji under each question
+	HideTimer      bool   `json:"hide_timer"`      // Do not show how long a question has been open
+	ManualAdvance  bool   `json:"manual_advance"`  // Wait for Next after each answer instead of moving on by itself
+	VerticalText   bool   `json:"vertical_text"`   // Write questions top to bottom (tategaki)
+	Reverse        bool   `json:"reverse"`         // Show the English and pick the Japanese
+	OptionFurigana bool   `json:"option_furigana"` // Show readings over answer options written in kanji
+	Theme          string `json:"theme"`           // "light", "dark" or empty to follow the system
+
+	Fonts fontSettings `json:"fonts"` // Separate fonts for Japanese and Latin text
+
+	DiscordPresence bool `json:"discord_presence"` // Show the study status on Discord
+
+	AnswerToast bool `json:"answer_toast"` // Show 正解!/残念 in large text after each answer
+}
+
+// settingsFile stores the settings between sessions
+const settingsFile = "settings.json"
+
+// loadSettings reads the settings, falling back to defaults if none were saved yet
+func loadSettings() (*Settings, error) {
+	settings := &Settings{}
+	data, err := os.ReadFile(configPath(settingsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	upgraded, err := decodeVersioned(configPath(settingsFile), data, data, settingsMigrations, settings)
+	if err != nil {
+		return nil, err
+	}
+	if upgraded {
+		// Backups from before the PINs were hashed or the Discord token was dropped would
+		// still hold them in the clear
+		var old struct {
+			Chat struct {
+				DiscordToken string `json:"discord_token"`
+			} `json:"chat"`
+		}
+		json.Unmarshal(data, &old)
+		secretsUntil := 0
+		if settings.LockPIN != "" || settings.Kiosk.PIN != "" {
+			secretsUntil = settingsPINsHashed
+		}
+		if old.Chat.DiscordToken != "" {
+			secretsUntil = settingsTokenRemoved
+		}
+		for version := 0; version < secretsUntil; version++ {
+			os.Remove(fmt.Sprintf("%s.v%d.bak", configPath(settingsFile), version))
+		}
+		return settings, settings.save()
+	}
+	return settings, nil
+}
+
+// save writes the settings to settingsFile, or nothing in guest mode
+func (s *Settings) save() error {
+	if guestMode {
+		return nil
+	}
+	s.SchemaVersion = len(settingsMigrations)
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configPath(settingsFile), data, 0o644)
+}
+
+// settingsContent builds the settings form. onClose is called after saving or cancelling.
+func (qa *quizApp) settingsContent(onClose func(saved bool)) fyne.CanvasObject {
+	settings := qa.settings
+
+	// Result reporting
+	xapiEnabled := widget.NewCheck("Report completed quizzes to an LRS (xAPI)", nil)
+	xapiEnabled.SetChecked(settings.XAPI.Enabled)
+	endpointEntry := widget.NewEntry()
+	endpointEntry.SetText(settings.XAPI.Endpoint)
+	endpointEntry.SetPlaceHolder("https://lrs.example.edu/xapi/")
+	usernameEntry := widget.NewEntry()
+	usernameEntry.SetText(settings.XAPI.Username)
+	passwordEntry := widget.NewPasswordEntry()
+	passwordEntry.SetText(settings.XAPI.Password)
+	actorNameEntry := widget.NewEntry()
+	actorNameEntry.SetText(settings.XAPI.ActorName)
+	actorEmailEntry := widget.NewEntry()
+	actorEmailEntry.SetText(settings.XAPI.ActorEmail)
+
+	// Global hotkey
+	hotkeyEntry := widget.NewEntry()
+	hotkeyEntry.SetText(settings.Hotkey)
+	hotkeyEntry.SetPlaceHolder("e.g. Ctrl+Alt+J (Windows only)")
+
+	// Study goal
+	quotaEntry := widget.NewEntry()
+	quotaEntry.SetText(strconv.Itoa(settings.dailyQuota()))
+	newPerDayEntry := widget.NewEntry()
+	newPerDayEntry.SetPlaceHolder("No limit")
+	if settings.NewPerDay > 0 {
+		newPerDayEntry.SetText(strconv.Itoa(settings.NewPerDay))
+	}
+	reportURLEntry := widget.NewEntry()
+	reportURLEntry.SetText(settings.ReportURL)
+	reportURLEntry.SetPlaceHolder("https://github.com/owner/deck/issues/new?title={title}&body={body}")
+	progressionCheck := widget.NewCheck("Unlock chapters one after another", nil)
+	progressionCheck.SetChecked(settings.Progression.On)
+	unlockEntry := widget.NewEntry()
+	unlockEntry.SetPlaceHolder(fmt.Sprintf("%d%%", defaultUnlockAt))
+	if settings.Progression.UnlockAt > 0 {
+		unlockEntry.SetText(fmt.Sprintf("%d%%", settings.Progression.UnlockAt))
+	}
+	retireEntry := widget.NewEntry()
+	retireEntry.SetPlaceHolder("Never")
+	if settings.RetireAfter > 0 {
+		retireEntry.SetText(strconv.Itoa(settings.RetireAfter))
+	}
+
+	// Notifications
+	notificationsCheck := widget.NewCheck("Notify me when a session ends or a quiz is left idle", nil)
+	notificationsCheck.SetChecked(!settings.MuteNotifications)
+
+	// Data protection
+	encryptCheck := widget.NewCheck("Encrypt my progress with a passphrase", nil)
+	encryptCheck.SetChecked(qa.progress.passphrase != "")
+	passphraseEntry := widget.NewPasswordEntry()
+	confirmEntry := widget.NewPasswordEntry()
+	if qa.progress.passphrase != "" {
+		passphraseEntry.SetPlaceHolder("Leave empty to keep the current one")
+	}
+
+	// Answer feedback
+	caseCheck := widget.NewCheck("Ignore capitals", nil)
+	caseCheck.SetChecked(!settings.AnswerRules.StrictCase)
+	articlesCheck := widget.NewCheck("Ignore \"a\", \"an\", \"the\" and the \"to\" of verbs", nil)
+	articlesCheck.SetChecked(!settings.AnswerRules.StrictArticles)
+	punctuationCheck := widget.NewCheck("Ignore punctuation", nil)
+	punctuationCheck.SetChecked(!settings.AnswerRules.StrictPunctuation)
+	var categories []string // Of the deck, and any saved for another deck
+	for category := range qa.index.byType {
+		categories = append(categories, category)
+	}
+	for _, category := range settings.KanaRules.StrictScript {
+		if !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	strictScriptGroup := widget.NewCheckGroup(categories, nil)
+	strictScriptGroup.Horizontal = true
+	strictScriptGroup.SetSelected(settings.KanaRules.StrictScript)
+	nearMissCheck := widget.NewCheck("Recognize near misses", nil)
+	nearMissCheck.SetChecked(!settings.NearMiss.Off)
+	nearMissEntry := widget.NewEntry()
+	nearMissEntry.SetPlaceHolder(strconv.Itoa(defaultNearMiss))
+	if settings.NearMiss.Threshold > 0 {
+		nearMissEntry.SetText(strconv.Itoa(settings.NearMiss.Threshold))
+	}
+	nearMissGrading := widget.NewRadioGroup([]string{nearMissRetry, nearMissHalf}, nil)
+	nearMissGrading.SetSelected(nearMissRetry)
+	if settings.NearMiss.PartialCredit {
+		nearMissGrading.SetSelected(nearMissHalf)
+	}
+	romajiStyleGroup := widget.NewRadioGroup([]string{romajiStyleNames[romajiDoubled], romajiStyleNames[romajiMacron], romajiStyleNames[romajiPlain]}, nil)
+	romajiStyleGroup.SetSelected(romajiStyleNames[settings.RomajiStyle])
+	toastCheck := widget.NewCheck("Show a large 正解! or 残念 after each answer", nil)
+	toastCheck.SetChecked(settings.AnswerToast)
+
+	// Discord
+	discordCheck := widget.NewCheck("Show what I'm studying on my Discord profile", nil)
+	discordCheck.SetChecked(settings.DiscordPresence)
+	if discordClientID == "" {
+		discordCheck.SetText("Show what I'm studying on Discord (not available in this build)")
+		discordCheck.Disable()
+	}
+
+	// Settings lock
+	lockPINInput := newPINInput(settings.LockPIN, "No lock")
+
+	// Fonts
+	latinFontEntry := widget.NewEntry()
+	latinFontEntry.SetText(settings.Fonts.Latin)
+	latinFontEntry.SetPlaceHolder("Default font")
+	japaneseFontEntry := widget.NewEntry()
+	japaneseFontEntry.SetText(settings.Fonts.Japanese)
+	japaneseFontEntry.SetPlaceHolder("System font")
+	textSizeEntry := widget.NewEntry()
+	textSizeEntry.SetPlaceHolder("Default")
+	if settings.Fonts.TextSize > 0 {
+		textSizeEntry.SetText(strconv.FormatFloat(float64(settings.Fonts.TextSize), 'f', -1, 32))
+	}
+	japaneseScaleEntry := widget.NewEntry()
+	japaneseScaleEntry.SetPlaceHolder("100")
+	if settings.Fonts.JapaneseScale > 0 {
+		japaneseScaleEntry.SetText(strconv.Itoa(settings.Fonts.JapaneseScale))
+	}
+
+	// Kiosk mode
+	kioskPINInput := newPINInput(settings.Kiosk.PIN, "Required for kiosk mode")
+	kioskChaptersEntry := widget.NewEntry()
+	kioskChaptersEntry.SetText(strings.Join(settings.Kiosk.Chapters, ", "))
+	kioskChaptersEntry.SetPlaceHolder("All chapters")
+
+	form := widget.NewForm(
+		widget.NewFormItem("", xapiEnabled),
+		widget.NewFormItem("LRS Endpoint", endpointEntry),
+		widget.NewFormItem("Username", usernameEntry),
+		widget.NewFormItem("Password", passwordEntry),
+		widget.NewFormItem("Your Name", actorNameEntry),
+		widget.NewFormItem("Your Email", actorEmailEntry),
+	)
+	kioskForm := widget.NewForm(
+		widget.NewFormItem("Exit PIN", kioskPINInput.content()),
+		widget.NewFormItem("Chapters", kioskChaptersEntry),
+	)
+
+	saveButton := widget.NewButton("Save", func() {
+		if hotkey := strings.TrimSpace(hotkeyEntry.Text); hotkey != "" {
+			if _, err := parseHotkey(hotkey); err != nil {
+				dialog.ShowError(err, qa.window)
+				return
+			}
+		}
+		quota, err := strconv.Atoi(strings.TrimSpace(quotaEntry.Text))
+		if err != nil || quota <= 0 {
+			dialog.ShowInformation("Settings", "The daily quota must be a positive number.", qa.window)
+			return
+		}
+		newPerDay := 0
+		if text := strings.TrimSpace(newPerDayEntry.Text); text != "" {
+			newPerDay, err = strconv.Atoi(text)
+			if err != nil || newPerDay <= 0 {
+				dialog.ShowInformation("Settings", "The new items per day must be a positive number.", qa.window)
+				return
+			}
+		}
+		retireAfter := 0
+		if text := strings.TrimSpace(retireEntry.Text); text != "" {
+			retireAfter, err = strconv.Atoi(text)
+			if err != nil || retireAfter <= 0 {
+				dialog.ShowInformation("Settings", "The reviews before retiring an item must be a positive number.", qa.window)
+				return
+			}
+		}
+		reportTemplate := strings.TrimSpace(reportURLEntry.Text)
+		if reportTemplate != "" {
+			if _, err := reportURL(reportTemplate, questionReport{}); err != nil {
+				dialog.ShowInformation("Settings", "The report address is not valid: "+err.Error(), qa.window)
+				return
+			}
+		}
+		progression := progressionSettings{On: progressionCheck.Checked}
+		if text := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(unlockEntry.Text), "%")); text != "" {
+			progression.UnlockAt, err = strconv.Atoi(text)
+			if err != nil || progression.UnlockAt < minUnlockAt || progression.UnlockAt > maxUnlockAt {
+				dialog.ShowInformation("Settings", fmt.Sprintf("The mastery to unlock a chapter must be between %d%% and %d%%.", minUnlockAt, maxUnlockAt), qa.window)
+				return
+			}
+		}
+		nearMiss := nearMissSettings{
+			Off:           !nearMissCheck.Checked,
+			PartialCredit: nearMissGrading.Selected == nearMissHalf,
+		}
+		if text := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(nearMissEntry.Text), "%")); text != "" {
+			nearMiss.Threshold, err = strconv.Atoi(text)
+			if err != nil || nearMiss.Threshold < minNearMiss || nearMiss.Threshold > maxNearMiss {
+				dialog.ShowInformation("Settings", fmt.Sprintf("The near miss similarity must be between %d%% and %d%%.", minNearMiss, maxNearMiss), qa.window)
+				return
+			}
+		}
+		passphrase := ""
+		if encryptCheck.Checked {
+			passphrase = qa.progress.passphrase
+			if passphraseEntry.Text != "" || passphrase == "" {
+				if passphraseEntry.Text == "" {
+					dialog.ShowInformation("Settings", "Please enter a passphrase to encrypt your progress.", qa.window)
+					return
+				}
+				if passphraseEntry.Text != confirmEntry.Text {
+					dialog.ShowInformation("Settings", "The passphrases do not match.", qa.window)
+					return
+				}
+				passphrase = passphraseEntry.Text
+			}
+		}
+		fonts := fontSettings{
+			Latin:    strings.TrimSpace(latinFontEntry.Text),
+			Japanese: strings.TrimSpace(japaneseFontEntry.Text),
+		}
+		if text := strings.TrimSpace(textSizeEntry.Text); text != "" {
+			size, err := strconv.ParseFloat(text, 32)
+			if err != nil || size < minTextSize || size > maxTextSize {
+				dialog.ShowInformation("Settings", fmt.Sprintf("The text size must be between %d and %d.", minTextSize, maxTextSize), qa.window)
+				return
+			}
+			fonts.TextSize = float32(size)
+		}
+		if text := strings.TrimSpace(japaneseScaleEntry.Text); text != "" {
+			scale, err := strconv.Atoi(strings.TrimSuffix(text, "%"))
+			if err != nil || scale < minJapaneseScale || scale > maxJapaneseScale {
+				dialog.ShowInformation("Settings", fmt.Sprintf("The Japanese text size must be between %d%% and %d%%.", minJapaneseScale, maxJapaneseScale), qa.window)
+				return
+			}
+			fonts.JapaneseScale = scale
+		}
+		for _, path := range []string{fonts.Latin, fonts.Japanese} {
+			if path != "" && loadFont(path) == nil {
+				dialog.ShowInformation("Settings", "Cannot read the font "+path+".", qa.window)
+				return
+			}
+		}
+		lockPIN, err := lockPINInput.value()
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		kioskPIN, err := kioskPINInput.value()
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if err := qa.setProgressPassphrase(passphrase); err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		settings.XAPI = xapiSettings{
+			Enabled:    xapiEnabled.Checked,
+			Endpoint:   endpointEntry.Text,
+			Username:   usernameEntry.Text,
+			Password:   passwordEntry.Text,
+			ActorName:  actorNameEntry.Text,
+			ActorEmail: actorEmailEntry.Text,
+		}
+		settings.DailyQuota = quota
+		settings.NewPerDay = newPerDay
+		settings.RetireAfter = retireAfter
+		settings.Progression = progression
+		settings.ReportURL = reportTemplate
+		settings.Fonts = fonts
+		settings.LockPIN = lockPIN
+		settings.Hotkey = strings.TrimSpace(hotkeyEntry.Text)
+		settings.MuteNotifications = !notificationsCheck.Checked
+		settings.AnswerToast = toastCheck.Checked
+		settings.AnswerRules = answerRules{
+			StrictCase:        !caseCheck.Checked,
+			StrictArticles:    !articlesCheck.Checked,
+			StrictPunctuation: !punctuationCheck.Checked,
+		}
+		settings.NearMiss = nearMiss
+		settings.KanaRules = kanaRules{StrictScript: strictScriptGroup.Selected}
+		for style, name := range romajiStyleNames {
+			if romajiStyleGroup.Selected == name {
+				settings.RomajiStyle = style
+			}
+		}
+		if settings.DiscordPresence && !discordCheck.Checked {
+			qa.updatePresence("", "") // Clear the status before turning it off
+		}
+		settings.DiscordPresence = discordCheck.Checked
+		settings.Kiosk = kioskSettings{
+			PIN:      kioskPIN,
+			Chapters: parseChapterList(kioskChaptersEntry.Text),
+		}
+		if err := settings.save(); err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		qa.registerHotkey()
+		qa.applyTheme()
+		onClose(true)
+	})
+	saveButton.Importance = widget.HighImportance
+
+	return container.NewBorder(
+		nil,
+		container.NewGridWithColumns(2,
+			saveButton,
+			widget.NewButton("Cancel", func() {
+				onClose(false)
+			}),
+		),
+		nil, nil,
+		container.NewVScroll(container.NewVBox(
+			settingsHeading("Result Reporting"),
+			form,
+			settingsHeading("Kiosk Mode (start with -kiosk)"),
+			kioskForm,
+			settingsHeading("Study Goal"),
+			widget.NewForm(
+				widget.NewFormItem("Questions per Day", quotaEntry),
+				widget.NewFormItem("New Items per Day", newPerDayEntry),
+			),
+			widget.NewLabel("Each deck and chapter can have its own review intervals, new items per day and leech threshold."),
+			widget.NewButton("Review Schedule…", qa.showScheduleSettings),
+			settingsHeading("Chapter Progression"),
+			widget.NewLabel("A game mode: the chapters open one at a time on a map, each once enough of the one before is mastered."),
+			progressionCheck,
+			widget.NewForm(widget.NewFormItem("Unlock at (% mastered)", unlockEntry)),
+			settingsHeading("Mastered Archive"),
+			widget.NewLabel("Items answered right at every review, a day or more apart, can retire to an archive.\nArchived items only come back now and then in due reviews, and return to study when missed."),
+			widget.NewForm(widget.NewFormItem("Retire After (reviews)", retireEntry)),
+			widget.NewButton("Mastered Archive…", qa.showArchive),
+			settingsHeading("Problem Reports"),
+			widget.NewLabel("Problems reported in questions are saved in "+dataPath(reportsFile)+".\nThey can also open as a new issue for the deck's maintainers."),
+			widget.NewForm(widget.NewFormItem("Issue Address", reportURLEntry)),
+			settingsHeading("Settings Lock"),
+			widget.NewLabel("A PIN keeps students from changing settings or opening teacher mode."),
+			widget.NewForm(widget.NewFormItem("PIN", lockPINInput.content())),
+			settingsHeading("Data Protection"),
+			encryptCheck,
+			widget.NewForm(
+				widget.NewFormItem("Passphrase", passphraseEntry),
+				widget.NewFormItem("Confirm", confirmEntry),
+			),
+			settingsHeading("Fonts"),
+			widget.NewForm(
+				widget.NewFormItem("Interface Font", qa.fontFileEntry(latinFontEntry)),
+				widget.NewFormItem("Japanese Font", qa.fontFileEntry(japaneseFontEntry)),
+				widget.NewFormItem("Text Size", textSizeEntry),
+				widget.NewFormItem("Japanese Size (%)", japaneseScaleEntry),
+			),
+			settingsHeading("Generated Romaji"),
+			widget.NewLabel("Decks without romaji get it from the kana, with long vowels written as chosen here.\nApplies from the next deck opened."),
+			romajiStyleGroup,
+			settingsHeading("Answer Feedback"),
+			toastCheck,
+			settingsHeading("Typed English Answers"),
+			widget.NewLabel("Answers may list several meanings separated by \";\" in the deck; any of them is accepted."),
+			caseCheck,
+			articlesCheck,
+			punctuationCheck,
+			settingsHeading("Typed Kana Answers"),
+			widget.NewLabel("Hiragana and katakana typed with an IME count as each other, except for the kinds of words\nchecked here, which must be typed in the script of the deck, e.g. loanwords. Romaji counts as either."),
+			strictScriptGroup,
+			settingsHeading("Near Misses in Typed Answers"),
+			nearMissCheck,
+			widget.NewForm(
+				widget.NewFormItem("Similarity (%)", nearMissEntry),
+				widget.NewFormItem("Grading", nearMissGrading),
+			),
+			settingsHeading("Audio"),
+			widget.NewButton("Audio Packs…", qa.showAudioSettings),
+			settingsHeading("Notifications"),
+			notificationsCheck,
+			settingsHeading("Discord"),
+			discordCheck,
+			settingsHeading("Pop-up Question"),
+			widget.NewForm(widget.NewFormItem("Global Hotkey", hotkeyEntry)),
+		)),
+	)
+}
+
+// settingsHeading labels a group of settings
+func settingsHeading(text string) fyne.CanvasObject {
+	return widget.NewLabelWithStyle(text, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+}
+
+// fontFileEntry adds a button to an entry for choosing a font file
+func (qa *quizApp) fontFileEntry(entry *widget.Entry) fyne.CanvasObject {
+	browse := widget.NewButton("Browse…", func() {
+		open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, qa.window)
+				return
+			}
+			if reader == nil {
+				return // Cancelled
+			}
+			defer reader.Close()
+			entry.SetText(reader.URI().Path())
+		}, qa.window)
+		open.SetFilter(storage.NewExtensionFileFilter([]string{".ttf", ".otf"}))
+		open.Show()
+	})
+	return container.NewBorder(nil, nil, nil, browse, entry)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/crypto/scrypt"
+)
+
+// pinHashPrefix starts a PIN saved by hashPIN: scrypt$<salt>$<key>, both in base64
+const pinHashPrefix = "scrypt$"
+
+// hashPIN returns a salted hash of a PIN to save in place of it, or "" for no PIN
+func hashPIN(pin string) (string, error) {
+	if pin == "" {
+		return "", nil
+	}
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := pinKey(pin, salt)
+	if err != nil {
+		return "", err
+	}
+	return pinHashPrefix + base64.RawStdEncoding.EncodeToString(salt) + "$" + base64.RawStdEncoding.EncodeToString(key), nil
+}
+
+// pinKey derives the key a PIN hash compares, with scrypt
+func pinKey(pin string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(pin), salt, 1<<15, 8, 1, 32)
+}
+
+// pinMatches reports whether a PIN is the one hashed by hashPIN
+func pinMatches(hash, pin string) bool {
+	parts := strings.Split(strings.TrimPrefix(hash, pinHashPrefix), "$")
+	if !strings.HasPrefix(hash, pinHashPrefix) || len(parts) != 2 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	key, err := pinKey(pin, salt)
+	return err == nil && subtle.ConstantTimeCompare(key, want) == 1
+}
+
+// pinInput edits a PIN saved as a hash. The PIN cannot be shown, so it is kept unless a
+// new one is typed or removing it is checked.
+type pinInput struct {
+	hash   string
+	entry  *widget.Entry
+	remove *widget.Check
+}
+
+// newPINInput creates an input for the PIN saved as hash, with a placeholder for when there is none
+func newPINInput(hash, none string) *pinInput {
+	p := &pinInput{hash: hash, entry: widget.NewPasswordEntry(), remove: widget.NewCheck("Remove", nil)}
+	p.entry.SetPlaceHolder(none)
+	if hash != "" {
+		p.entry.SetPlaceHolder("Unchanged")
+	} else {
+		p.remove.Hide()
+	}
+	return p
+}
+
+// content lays out the entry and the remove check
+func (p *pinInput) content() fyne.CanvasObject {
+	return container.NewBorder(nil, nil, nil, p.remove, p.entry)
+}
+
+// value returns the hash to save: of a newly typed PIN, the old one, or "" if removed
+func (p *pinInput) value() (string, error) {
+	if pin := strings.TrimSpace(p.entry.Text); pin != "" {
+		return hashPIN(pin)
+	}
+	if p.remove.Checked {
+		return "", nil
+	}
+	return p.hash, nil
+}
+
+// requireLockPIN runs action once the settings lock PIN has been entered, or straight away
+// when no lock is set. It is called holding the UI lock, and action runs holding it.
+func (qa *quizApp) requireLockPIN(title string, action func()) {
+	if qa.settings.LockPIN == "" {
+		action()
+		return
+	}
+	pinEntry := widget.NewPasswordEntry()
+	dialog.ShowForm(title, "Unlock", "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("PIN", pinEntry)},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			if !pinMatches(qa.settings.LockPIN, pinEntry.Text) {
+				dialog.ShowInformation(title, "Incorrect PIN.", qa.window)
+				return
+			}
+			qa.serialized(action)()
+		}, qa.window)
+}
+
+// lockedByPIN wraps a menu action so it asks for the settings lock PIN first
+func (qa *quizApp) lockedByPIN(title string, action func()) func() {
+	return func() {
+		qa.requireLockPIN(title, action)
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// shadowingRounds is the number of sentences in a shadowing session
+const shadowingRounds = 8
+
+// Time the learner has to repeat a sentence before it is shown: a pause, and the time
+// it takes to say it at normal speed
+const (
+	shadowingPause   = 2 * time.Second
+	shadowingPerRune = 200 * time.Millisecond
+)
+
+// exampleRecordingSuffix names the recording of a deck example sentence in an audio pack,
+// after the QID of its word: 123-example.mp3
+const exampleRecordingSuffix = "-example"
+
+// shadowingRating is how well the learner kept up with a sentence, by their own judgement
+type shadowingRating int
+
+// Shadowing self-assessments
+const (
+	shadowingLost   shadowingRating = iota // Could not keep up
+	shadowingPartly                        // Kept up with parts of it
+	shadowingSmooth                        // Said it along smoothly
+)
+
+// shadowingStats adds up the sentences of all shadowing sessions
+type shadowingStats struct {
+	Reps   int `json:"reps"`
+	Smooth int `json:"smooth"`
+	Partly int `json:"partly"`
+	Lost   int `json:"lost"`
+}
+
+// recordShadowing adds a shadowed sentence to the statistics
+func (p *Progress) recordShadowing(rating shadowingRating) {
+	if p.Shadowing == nil {
+		p.Shadowing = &shadowingStats{}
+	}
+	p.Shadowing.Reps++
+	switch rating {
+	case shadowingSmooth:
+		p.Shadowing.Smooth++
+	case shadowingPartly:
+		p.Shadowing.Partly++
+	default:
+		p.Shadowing.Lost++
+	}
+	p.earnXP(answerXP(rating != shadowingLost), time.Now())
+}
+
+// shadowingSentence is a sentence to listen to and repeat
+type shadowingSentence struct {
+	Japanese  string
+	English   string // "" if not known
+	Recording string // Audio pack file, "" to read it aloud
+}
+
+// shadowingSentences returns the sentences of a chapter: the example sentences of the
+// deck, then those of the translation and grammar exercises
+func (qa *quizApp) shadowingSentences(chapter string) []shadowingSentence {
+	var sentences []shadowingSentence
+	seen := make(map[string]bool)
+	add := func(s shadowingSentence) {
+		if s.Japanese != "" && !seen[s.Japanese] {
+			seen[s.Japanese] = true
+			sentences = append(sentences, s)
+		}
+	}
+	for _, q := range qa.index.byChapter[chapter] {
+		if hasJapanese(q.QExample) {
+			add(shadowingSentence{Japanese: q.QExample, Recording: recordingPath(q.QID + exampleRecordingSuffix)})
+		}
+	}
+	for _, e := range translationExercises(chapter) {
+		add(shadowingSentence{Japanese: e.Japanese, English: referenceText(e.References[0])})
+	}
+	for _, e := range grammarExercises(chapter) {
+		add(shadowingSentence{
+			Japanese: e.filled(func(blank int) string { return e.Blanks[blank].Answer }),
+			English:  e.Translation,
+		})
+	}
+	return sentences
+}
+
+// shadowingDelay is how long a sentence stays hidden after it starts playing
+func shadowingDelay(sentence string, rate float64) time.Duration {
+	return shadowingPause + time.Duration(float64(len([]rune(sentence)))*float64(shadowingPerRune)/rate)
+}
+
+// shadowingDrill plays sentences for the learner to repeat along, shows each after a
+// delay and lets them grade how they kept up
+type shadowingDrill struct {
+	qa        *quizApp
+	sentences []shadowingSentence
+	round     int
+	ratings   map[shadowingRating]int
+}
+
+// shadowingButton starts a shadowing session on the current chapter, disabled if it has no sentences
+func (qa *quizApp) shadowingButton() *widget.Button {
+	sentences := qa.shadowingSentences(qa.state.currentChapter)
+	button := widget.NewButton(fmt.Sprintf("Shadowing (%d)", len(sentences)), qa.serialized(func() {
+		if !speechAvailable() && len(audioPacks()) == 0 {
+			dialog.ShowError(errSpeechUnsupported, qa.window)
+			return
+		}
+		sentences := append([]shadowingSentence(nil), sentences...)
+		rand.Shuffle(len(sentences), func(i, j int) { sentences[i], sentences[j] = sentences[j], sentences[i] })
+		if len(sentences) > shadowingRounds {
+			sentences = sentences[:shadowingRounds]
+		}
+		d := &shadowingDrill{qa: qa, sentences: sentences, ratings: make(map[shadowingRating]int)}
+		d.showRound()
+	}))
+	if len(sentences) == 0 {
+		button.Disable()
+	}
+	return button
+}
+
+// showRound plays the current sentence, shows it after a delay and asks how it went
+func (d *shadowingDrill) showRound() {
+	if d.round >= len(d.sentences) {
+		d.showResults()
+		return
+	}
+	s := d.sentences[d.round]
+
+	sentence := canvas.NewText(s.Japanese, theme.ForegroundColor())
+	sentence.Alignment = fyne.TextAlignCenter
+	d.qa.japaneseText(sentence, grammarTextSize)
+	sentence.Hide()
+	english := widget.NewLabelWithStyle(s.English, fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	english.Hide()
+	prompt := widget.NewLabelWithStyle("Listen and say it along, or just after.", fyne.TextAlignCenter, fyne.TextStyle{})
+
+	rate := func(rating shadowingRating) *widget.Button {
+		return widget.NewButton(map[shadowingRating]string{
+			shadowingLost:   "😣 Lost It",
+			shadowingPartly: "🙂 Partly",
+			shadowingSmooth: "😄 Smoothly",
+		}[rating], d.qa.serialized(func() {
+			d.ratings[rating]++
+			d.qa.progress.recordShadowing(rating)
+			if err := d.qa.progress.save(); err != nil {
+				log.Printf("Failed to save progress: %v", err)
+			}
+			d.qa.progressChanged()
+			d.round++
+			d.showRound()
+		}))
+	}
+	ratings := container.NewGridWithColumns(3, rate(shadowingLost), rate(shadowingPartly), rate(shadowingSmooth))
+	ratings.Hide()
+
+	var showButton *widget.Button
+	reveal := func() {
+		sentence.Show()
+		if s.English != "" {
+			english.Show()
+		}
+		prompt.SetText("How did you keep up?")
+		ratings.Show()
+		showButton.Hide()
+	}
+	showButton = widget.NewButton("Show Sentence", d.qa.serialized(reveal))
+	play := func() {
+		d.qa.sayRecorded(s.Japanese, s.Recording)
+	}
+
+	d.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Shadowing — Sentence %d/%d", d.round+1, len(d.sentences)),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		container.NewGridWithColumns(2,
+			widget.NewButton("🔊 Play Again", d.qa.serialized(play)),
+			widget.NewButton("End Shadowing", d.qa.serialized(d.showResults)),
+		),
+		nil, nil,
+		container.NewVBox(
+			layoutSpacer(),
+			prompt,
+			sentence,
+			english,
+			container.NewCenter(container.NewHBox(showButton, widget.NewLabel("Speed"), d.qa.speedSelect())),
+			ratings,
+		),
+	))
+	play()
+	d.qa.afterFunc(shadowingDelay(s.Japanese, d.qa.settings.Audio.rate()), reveal)
+}
+
+// showResults shows how the learner kept up over the session
+func (d *shadowingDrill) showResults() {
+	d.qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Shadowing Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("Sentences shadowed: %d", d.round)),
+		widget.NewLabel(fmt.Sprintf("Smoothly: %d   Partly: %d   Lost: %d",
+			d.ratings[shadowingSmooth], d.ratings[shadowingPartly], d.ratings[shadowingLost])),
+		widget.NewButton("Return to Chapter Selection", d.qa.serialized(func() {
+			d.qa.showChapterSelection()
+		})),
+	)))
+}
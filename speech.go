@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"sync"
+)
+
+// errSpeechUnsupported is returned when no Japanese text-to-speech engine is installed
+var errSpeechUnsupported = errors.New("no text-to-speech engine found (install a Japanese voice)")
+
+// errPlaybackUnsupported is returned when no program to play recordings is installed
+var errPlaybackUnsupported = errors.New("no audio player found (install ffplay, mpv or paplay)")
+
+// speechMu keeps two words from being spoken over each other
+var speechMu sync.Mutex
+
+// speechAvailable reports whether words can be read aloud on this machine
+func speechAvailable() bool {
+	_, err := speakCommand("", 1)
+	return err == nil
+}
+
+// speak reads Japanese text aloud at a rate (1 is normal speed) and returns when it has been spoken
+func speak(text string, rate float64) error {
+	cmd, err := speakCommand(text, rate)
+	if err != nil {
+		return err
+	}
+	speechMu.Lock()
+	defer speechMu.Unlock()
+	return cmd.Run()
+}
+
+// play plays a recording at a rate (1 is normal speed) and returns when it has finished
+func play(file string, rate float64) error {
+	cmd, err := playCommand(file, rate)
+	if err != nil {
+		return err
+	}
+	speechMu.Lock()
+	defer speechMu.Unlock()
+	return cmd.Run()
+}
+
+// say reads text aloud in the background, preferring a recording from an audio pack
+func (qa *quizApp) say(text string) {
+	qa.sayRecorded(text, qa.recordingFor(text))
+}
+
+// sayRecorded plays a recording of text in the background, or reads text aloud if there
+// is none or it cannot be played
+func (qa *quizApp) sayRecorded(text, recording string) {
+	rate := qa.settings.Audio.rate()
+	go func() {
+		if recording != "" {
+			err := play(recording, rate)
+			if err == nil {
+				return
+			}
+			log.Printf("Failed to play %s: %v", recording, err)
+		}
+		if err := speak(text, rate); err != nil {
+			log.Printf("Failed to speak %q: %v", text, err)
+		}
+	}()
+}
@@ -0,0 +1,51 @@
+//go:build !windows
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+// wordsPerMinute is the normal speaking rate of say and espeak-ng
+const wordsPerMinute = 175
+
+// speakCommand builds a command that speaks text: macOS's say with the Kyoko voice,
+// otherwise espeak-ng or speech-dispatcher if one of them is installed
+func speakCommand(text string, rate float64) (*exec.Cmd, error) {
+	wpm := strconv.Itoa(int(math.Round(rate * wordsPerMinute)))
+	if runtime.GOOS == "darwin" {
+		return exec.Command("say", "-v", "Kyoko", "-r", wpm, text), nil
+	}
+	if path, err := exec.LookPath("espeak-ng"); err == nil {
+		return exec.Command(path, "-v", "ja", "-s", wpm, text), nil
+	}
+	if path, err := exec.LookPath("spd-say"); err == nil {
+		// spd-say's rate goes from -100 to 100, 0 being normal
+		spdRate := strconv.Itoa(int(math.Round((rate - 1) * 100)))
+		return exec.Command(path, "--wait", "-l", "ja", "-r", spdRate, text), nil
+	}
+	return nil, errSpeechUnsupported
+}
+
+// playCommand builds a command that plays a recording: macOS's afplay,
+// otherwise ffplay, mpv or PulseAudio's paplay if one of them is installed.
+// paplay cannot change the speed, so it always plays at the normal rate.
+func playCommand(file string, rate float64) (*exec.Cmd, error) {
+	if runtime.GOOS == "darwin" {
+		return exec.Command("afplay", "-r", strconv.FormatFloat(rate, 'f', 2, 64), file), nil
+	}
+	if path, err := exec.LookPath("ffplay"); err == nil {
+		return exec.Command(path, "-nodisp", "-autoexit", "-loglevel", "quiet", "-af", fmt.Sprintf("atempo=%.2f", rate), file), nil
+	}
+	if path, err := exec.LookPath("mpv"); err == nil {
+		return exec.Command(path, "--no-video", "--really-quiet", fmt.Sprintf("--speed=%.2f", rate), file), nil
+	}
+	if path, err := exec.LookPath("paplay"); err == nil {
+		return exec.Command(path, file), nil
+	}
+	return nil, errPlaybackUnsupported
+}
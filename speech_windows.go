@@ -0,0 +1,62 @@
+//go:build windows
+
+package main
+
+import (
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// speakScript reads $env:GENKIQUIZ_SPEAK with the first installed Japanese voice.
+// The text goes through the environment so it never needs quoting.
+const speakScript = `Add-Type -AssemblyName System.Speech
+$s = New-Object System.Speech.Synthesis.SpeechSynthesizer
+$v = $s.GetInstalledVoices() | Where-Object { $_.VoiceInfo.Culture.Name -eq 'ja-JP' } | Select-Object -First 1
+if ($v) { $s.SelectVoice($v.VoiceInfo.Name) }
+$s.Rate = [int]$env:GENKIQUIZ_RATE
+$s.Speak($env:GENKIQUIZ_SPEAK)`
+
+// playScript plays the file in $env:GENKIQUIZ_PLAY at $env:GENKIQUIZ_RATE with the
+// Windows media player and waits until it has finished
+const playScript = `Add-Type -AssemblyName PresentationCore
+$p = New-Object System.Windows.Media.MediaPlayer
+$p.Open([uri]$env:GENKIQUIZ_PLAY)
+while (-not $p.NaturalDuration.HasTimeSpan) { Start-Sleep -Milliseconds 50 }
+$rate = [double]::Parse($env:GENKIQUIZ_RATE, [cultureinfo]::InvariantCulture)
+$p.SpeedRatio = $rate
+$p.Play()
+Start-Sleep -Milliseconds ([int]($p.NaturalDuration.TimeSpan.TotalMilliseconds / $rate) + 100)
+$p.Close()`
+
+// sapiRate converts a speed to the speech synthesizer's rate, which goes from -10 (a third
+// of the normal speed) to 10 (three times the normal speed)
+func sapiRate(rate float64) int {
+	return int(math.Round(10 * math.Log(rate) / math.Log(3)))
+}
+
+// speakCommand builds a command that speaks text with the Windows speech synthesizer
+func speakCommand(text string, rate float64) (*exec.Cmd, error) {
+	powershell, err := exec.LookPath("powershell.exe")
+	if err != nil {
+		return nil, errSpeechUnsupported
+	}
+	cmd := exec.Command(powershell, "-NoProfile", "-NonInteractive", "-Command", speakScript)
+	cmd.Env = append(os.Environ(), "GENKIQUIZ_SPEAK="+text, "GENKIQUIZ_RATE="+strconv.Itoa(sapiRate(rate)))
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	return cmd, nil
+}
+
+// playCommand builds a command that plays a recording with the Windows media player
+func playCommand(file string, rate float64) (*exec.Cmd, error) {
+	powershell, err := exec.LookPath("powershell.exe")
+	if err != nil {
+		return nil, errPlaybackUnsupported
+	}
+	cmd := exec.Command(powershell, "-NoProfile", "-NonInteractive", "-Command", playScript)
+	cmd.Env = append(os.Environ(), "GENKIQUIZ_PLAY="+file, "GENKIQUIZ_RATE="+strconv.FormatFloat(rate, 'f', 2, 64))
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	return cmd, nil
+}
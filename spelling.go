@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// spellingDrillSize is the number of words in a spelling drill
+const spellingDrillSize = 10
+
+// spellingTrap is a near-miss spelling of a word and what is wrong with it
+type spellingTrap struct {
+	spelling string
+	reason   string
+}
+
+// longVowelOf returns the kana that lengthens a mora written in hiragana, or "" if there is none
+func longVowelOf(mora string) string {
+	switch moraVowel(mora) {
+	case 'a':
+		return "あ"
+	case 'i', 'e':
+		return "い"
+	case 'u', 'o':
+		return "う"
+	}
+	return ""
+}
+
+// isLongVowel reports whether mora lengthens the vowel of prev, as in こう or せい
+func isLongVowel(prev, mora string) bool {
+	if mora == "ー" {
+		return true
+	}
+	switch moraVowel(prev) {
+	case 'a':
+		return mora == "あ"
+	case 'i':
+		return mora == "い"
+	case 'u':
+		return mora == "う"
+	case 'e':
+		return mora == "い" || mora == "え"
+	case 'o':
+		return mora == "う" || mora == "お"
+	}
+	return false
+}
+
+// spellingTraps generates the near-miss spellings of a kana word: a missing or extra
+// small っ, a shortened or lengthened vowel and a big ゃ/ゅ/ょ
+func spellingTraps(kana string) []spellingTrap {
+	katakana := isKatakana(kana)
+	morae := splitMora(toHiragana(kana))
+	var traps []spellingTrap
+	seen := map[string]bool{toHiragana(kana): true}
+	add := func(variant []string, reason string) {
+		spelling := strings.Join(variant, "")
+		if seen[spelling] {
+			return
+		}
+		seen[spelling] = true
+		if katakana {
+			spelling = toKatakana(spelling)
+			reason = toKatakana(reason)
+		}
+		traps = append(traps, spellingTrap{spelling: spelling, reason: reason})
+	}
+	without := func(i int) []string {
+		return append(append([]string(nil), morae[:i]...), morae[i+1:]...)
+	}
+	with := func(i int, mora string) []string {
+		variant := append([]string(nil), morae[:i]...)
+		return append(append(variant, mora), morae[i:]...)
+	}
+	replaced := func(i int, mora string) []string {
+		variant := append([]string(nil), morae...)
+		variant[i] = mora
+		return variant
+	}
+
+	for i, mora := range morae {
+		lengthens := i > 0 && isLongVowel(morae[i-1], mora)
+		switch {
+		case mora == "っ" && i+1 < len(morae):
+			add(without(i), fmt.Sprintf("The small っ before %s is missing: the consonant is doubled.", morae[i+1]))
+		case lengthens:
+			add(without(i), fmt.Sprintf("%s%s is a long vowel.", morae[i-1], mora))
+		}
+
+		if r := []rune(mora); len(r) == 2 && strings.ContainsRune("ゃゅょ", r[1]) {
+			big := string(r[0]) + string(r[1]+1)
+			add(replaced(i, big), fmt.Sprintf("%s is one mora, written with a small %c.", mora, r[1]))
+		}
+
+		// Near misses the other way: a doubled consonant or a long vowel that is not there
+		if romaji := kanaRomaji[mora]; i > 0 && romaji != "" && strings.IndexByte("kstp", romaji[0]) >= 0 &&
+			morae[i-1] != "っ" && morae[i-1] != "ん" {
+			add(with(i, "っ"), fmt.Sprintf("There is no small っ before %s.", mora))
+		}
+		lengthened := longVowelOf(mora)
+		if katakana && lengthened != "" {
+			lengthened = "ー"
+		}
+		if lengthened != "" && !lengthens && (i+1 == len(morae) || !isLongVowel(mora, morae[i+1])) {
+			add(with(i+1, lengthened), fmt.Sprintf("%s is a short vowel.", mora))
+		}
+	}
+	return traps
+}
+
+// hasSpellingTrap reports whether a word contains a small っ, a small ゃ/ゅ/ょ or a long vowel
+func hasSpellingTrap(kana string) bool {
+	if !isKana(kana) {
+		return false
+	}
+	morae := splitMora(toHiragana(kana))
+	for i, mora := range morae {
+		if mora == "っ" || len([]rune(mora)) == 2 || i > 0 && isLongVowel(morae[i-1], mora) {
+			return true
+		}
+	}
+	return false
+}
+
+// moraDifference explains the first place where two kana spellings differ
+func moraDifference(expected, typed string) string {
+	want, got := splitMora(expected), splitMora(typed)
+	prefix := 0
+	for prefix < len(want) && prefix < len(got) && want[prefix] == got[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(want)-prefix && suffix < len(got)-prefix &&
+		want[len(want)-1-suffix] == got[len(got)-1-suffix] {
+		suffix++
+	}
+	wantPart := strings.Join(want[prefix:len(want)-suffix], "")
+	gotPart := strings.Join(got[prefix:len(got)-suffix], "")
+	switch {
+	case gotPart == "":
+		return fmt.Sprintf("Mora %d: %s is missing.", prefix+1, wantPart)
+	case wantPart == "":
+		return fmt.Sprintf("Mora %d: %s should not be there.", prefix+1, gotPart)
+	}
+	return fmt.Sprintf("Mora %d: expected %s, not %s.", prefix+1, wantPart, gotPart)
+}
+
+// checkSpelling grades a typed spelling under the learner's kana rules, naming the mora that was wrong
+func (qa *quizApp) checkSpelling(q Question, input string) (bool, string) {
+	expected := toHiragana(q.QHirakata)
+	typed := typedKana(input)
+	answer := fmt.Sprintf("%s (%s)", q.QHirakata, q.QRomaji)
+	if qa.settings.KanaRules.matchesKana(q, input) {
+		return true, answer
+	}
+	if sameKana(typed, expected) {
+		script := "hiragana"
+		if isKatakana(q.QHirakata) {
+			script = "katakana"
+		}
+		return false, fmt.Sprintf("Right kana, wrong script: it is written in %s, %s.", script, answer)
+	}
+	difference := moraDifference(expected, typed)
+	if isKatakana(q.QHirakata) {
+		difference = toKatakana(difference)
+	}
+	for _, trap := range spellingTraps(q.QHirakata) {
+		if toHiragana(trap.spelling) == typed {
+			return false, fmt.Sprintf("So close! %s It is spelled %s.", trap.reason, answer)
+		}
+	}
+	return false, fmt.Sprintf("%s It is spelled %s.", difference, answer)
+}
+
+// startSpellingDrill drills the words of the current chapter that contain spelling traps
+func (qa *quizApp) startSpellingDrill() {
+	var words []Question
+	for _, q := range qa.state.chapterQuestions {
+		if hasSpellingTrap(q.QHirakata) {
+			words = append(words, q)
+		}
+	}
+	if len(words) > 0 && len(qa.newItemsPool(words)) == 0 {
+		qa.showNewItemsLimit()
+		return
+	}
+	words = qa.newItemsPool(words)
+	rand.Shuffle(len(words), func(i, j int) {
+		words[i], words[j] = words[j], words[i]
+	})
+	if len(words) > spellingDrillSize {
+		words = words[:spellingDrillSize]
+	}
+
+	qa.startTypedDrill(&typedDrill{
+		name:      "Spelling Traps",
+		questions: words,
+		prompt: func(q Question) string {
+			return "Spell in kana: " + q.QAnswer
+		},
+		answer: func(q Question) string {
+			return q.QHirakata
+		},
+		check: qa.checkSpelling,
+		closeness: func(q Question, input string) float64 {
+			return kanaSimilarity(q.QHirakata, typedKana(input))
+		},
+	})
+}
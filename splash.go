@@ -0,0 +1,84 @@
+package main
+
+import (
+	"log"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xuri/excelize/v2"
+)
+
+// splashScreen is shown while the deck loads, and tells why if it cannot
+type splashScreen struct {
+	content *fyne.Container
+	status  *widget.Label
+	spinner *widget.ProgressBarInfinite
+}
+
+// newSplashScreen creates the splash screen with a status line
+func newSplashScreen(status string) *splashScreen {
+	s := &splashScreen{
+		status:  widget.NewLabelWithStyle(status, fyne.TextAlignCenter, fyne.TextStyle{}),
+		spinner: widget.NewProgressBarInfinite(),
+	}
+	s.status.Wrapping = fyne.TextWrapWord
+	s.content = container.NewVBox(
+		widget.NewLabelWithStyle("Genki Quiz", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		s.status,
+		s.spinner,
+	)
+	return s
+}
+
+// fail stops the spinner and shows an error with a way out
+func (s *splashScreen) fail(message string, quit func()) {
+	s.spinner.Stop()
+	s.spinner.Hide()
+	s.status.SetText(message)
+	s.content.Add(widget.NewButton("Quit", quit))
+}
+
+// loadDeckInBackground shows a splash screen while the deck is found and read in a
+// goroutine, then calls ready, holding the UI lock, with the deck loaded into qa
+func (qa *quizApp) loadDeckInBackground(path string, ready func()) {
+	splash := newSplashScreen("Loading the questions…")
+	qa.window.SetContent(container.NewPadded(container.NewCenter(splash.content)))
+
+	go func() {
+		deck, err := resolveDeck(path)
+		if err != nil {
+			log.Printf("Failed to load quiz questions: %v", err)
+			splash.fail("Failed to load quiz questions: "+err.Error(), qa.app.Quit)
+			return
+		}
+		splash.status.SetText("Loading " + filepath.Base(deck) + "…")
+		troubleshoot := func(err error) {
+			if err != nil {
+				log.Printf("Failed to load quiz questions: %v", err)
+			}
+			// Most problems are in how the spreadsheet is laid out, so show it
+			qa.window.SetContent(container.NewBorder(nil, widget.NewButton("Quit", qa.app.Quit), nil, nil,
+				container.NewPadded(deckTroubleshooting(filepath.Base(deck), err, diagnoseDeckFile(deck)))))
+			qa.window.Resize(fyne.NewSize(900, 560))
+		}
+		f, err := excelize.OpenFile(deck)
+		if err != nil {
+			troubleshoot(err)
+			return
+		}
+		qa.readDeckConfirmed(f, filepath.Base(deck), func(questions []Question, err error) {
+			f.Close()
+			if err != nil || len(questions) == 0 {
+				troubleshoot(err)
+				return
+			}
+			qa.serialized(func() {
+				qa.setQuestions(questions)
+				qa.deckName = filepath.Base(deck)
+				ready()
+			})()
+		})
+	}()
+}
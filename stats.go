@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// defaultDailyQuota is the number of questions per day assumed when none is set
+const defaultDailyQuota = 20
+
+// dailyQuota returns the learner's planned number of questions per day
+func (s *Settings) dailyQuota() int {
+	if s.DailyQuota > 0 {
+		return s.DailyQuota
+	}
+	return defaultDailyQuota
+}
+
+// projectCompletion returns the day by which remaining items are mastered at the given
+// rate (items per day). ok is false if the rate is zero; a zero date means nothing remains.
+func projectCompletion(remaining int, rate float64, now time.Time) (time.Time, bool) {
+	if remaining <= 0 {
+		return time.Time{}, true
+	}
+	if rate <= 0 {
+		return time.Time{}, false
+	}
+	return now.AddDate(0, 0, int(math.Ceil(float64(remaining)/rate))), true
+}
+
+// projectionText formats a projected completion date
+func projectionText(date time.Time, ok bool) string {
+	switch {
+	case !ok:
+		return "—"
+	case date.IsZero():
+		return "done"
+	}
+	return date.Format("Jan 2, 2006")
+}
+
+// statsContent builds the statistics and projected completion dates
+func (qa *quizApp) statsContent() fyne.CanvasObject {
+	progress := qa.progress
+	now := time.Now()
+
+	// Each item needs masteryStreak correct reviews, so the quota masters quota/masteryStreak items a day
+	quotaRate := float64(qa.settings.dailyQuota()) / masteryStreak
+	paceRate := masteryPace(progress, now)
+
+	seen, correct, timed := 0, 0, 0
+	var answerTime int64 // Milliseconds
+	for _, stats := range progress.Items {
+		seen += stats.Seen
+		correct += stats.Correct
+		timed += stats.Timed
+		answerTime += stats.TotalTime
+	}
+	accuracy := 0.0
+	if seen > 0 {
+		accuracy = float64(correct) / float64(seen) * 100
+	}
+
+	// Chapters are assumed to be studied in order, so each projection includes the chapters before it
+	grid := container.NewGridWithColumns(4,
+		widget.NewLabelWithStyle("Chapter", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Mastered", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("At Your Quota", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("At Current Pace", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+	)
+	remaining, total, mastered := 0, 0, 0
+	for _, chapter := range qa.availableChapters() {
+		questions := qa.index.byChapter[chapter]
+		chapterMastered := countMastered(questions, progress)
+		remaining += len(questions) - chapterMastered
+		total += len(questions)
+		mastered += chapterMastered
+
+		grid.Add(widget.NewLabel(chapter))
+		grid.Add(widget.NewLabel(fmt.Sprintf("%d/%d", chapterMastered, len(questions))))
+		grid.Add(widget.NewLabel(projectionText(projectCompletion(remaining, quotaRate, now))))
+		grid.Add(widget.NewLabel(projectionText(projectCompletion(remaining, paceRate, now))))
+	}
+	grid.Add(widget.NewLabelWithStyle("Whole book", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	grid.Add(widget.NewLabel(fmt.Sprintf("%d/%d", mastered, total)))
+	grid.Add(widget.NewLabel(projectionText(projectCompletion(remaining, quotaRate, now))))
+	grid.Add(widget.NewLabel(projectionText(projectCompletion(remaining, paceRate, now))))
+
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Answers: %d   Accuracy: %.1f%%", seen, accuracy)),
+		widget.NewLabel(fmt.Sprintf("Daily quota: %d questions (≈%.1f items mastered/day)   Current pace: %.1f items mastered/day",
+			qa.settings.dailyQuota(), quotaRate, paceRate)),
+	)
+	if record := progress.Decks[qa.deckName]; record != nil {
+		content.Add(widget.NewLabel(fmt.Sprintf("Deck: %s, version %d (%s)", qa.deckName, record.Version, record.Hash)))
+	}
+	if limit := qa.schedule("").NewPerDay; limit > 0 {
+		content.Add(widget.NewLabel(fmt.Sprintf("New items today: %d of %d", progress.newItemsOn(now), limit)))
+	}
+	if timed > 0 {
+		content.Add(widget.NewLabel(fmt.Sprintf("Average answer time: %.1fs", float64(answerTime)/float64(timed)/1000)))
+	}
+	if l := progress.Listening; l != nil && l.Words > 0 {
+		content.Add(widget.NewLabel(fmt.Sprintf("Listening: %d words, %.1f%% heard right, %d replays (%.1f per word)",
+			l.Words, float64(l.Correct)/float64(l.Words)*100, l.Replays, float64(l.Replays)/float64(l.Words))))
+	}
+	if s := progress.Shadowing; s != nil && s.Reps > 0 {
+		content.Add(widget.NewLabel(fmt.Sprintf("Shadowing: %d sentences, %d kept up smoothly, %d partly, %d lost",
+			s.Reps, s.Smooth, s.Partly, s.Lost)))
+	}
+	if leeches := qa.leeches(qa.questions); len(leeches) > 0 {
+		content.Add(widget.NewLabel(fmt.Sprintf("Leeches: %d items missed too often, marked in Browse", len(leeches))))
+	}
+	if len(progress.Retired) > 0 {
+		content.Add(widget.NewLabel(fmt.Sprintf("Mastered archive: %d items retired from study", len(progress.Retired))))
+	}
+	content.Add(settingsHeading(fmt.Sprintf("Experience: Level %d, %d XP", xpLevel(progress.XP), progress.XP)))
+	content.Add(qa.xpHistory(now))
+	content.Add(settingsHeading("Projected Completion"))
+	content.Add(grid)
+	return container.NewVScroll(content)
+}
+
+// statsTabContent shows the statistics with a button to detach them into their own window,
+// and one to export the mistake notebook except in kiosk mode
+func (qa *quizApp) statsTabContent() fyne.CanvasObject {
+	open := widget.NewButton("Open in Window", func() {
+		qa.openStatsWindow()
+	})
+	buttons := container.NewGridWithColumns(1, open)
+	if !qa.kiosk {
+		buttons = container.NewGridWithColumns(2, open, widget.NewButton("Export Mistake Notebook", qa.exportMistakes))
+	}
+	return container.NewBorder(
+		nil,
+		buttons,
+		nil, nil,
+		qa.statsContent(),
+	)
+}
+
+// openStatsWindow shows the statistics in a window of their own, which can be moved
+// to another monitor and is updated after every answer
+func (qa *quizApp) openStatsWindow() {
+	if qa.statsWindow != nil {
+		qa.statsWindow.RequestFocus()
+		return
+	}
+	w := qa.app.NewWindow("Genki Quiz Statistics")
+	w.SetContent(qa.statsContent())
+	w.Resize(fyne.NewSize(560, 360))
+	w.SetOnClosed(func() {
+		qa.statsWindow = nil
+	})
+	qa.statsWindow = w
+	w.Show()
+}
+
+// progressChanged updates the views that follow the progress, after an answer was recorded
+func (qa *quizApp) progressChanged() {
+	if qa.statsWindow != nil {
+		qa.statsWindow.SetContent(qa.statsContent())
+	}
+}
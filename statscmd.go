@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// ChapterSummary is the mastery of one chapter, as printed by the stats subcommand
+type ChapterSummary struct {
+	Chapter   string `json:"chapter"`
+	Questions int    `json:"questions"`
+	Studied   int    `json:"studied"`
+	Mastered  int    `json:"mastered"`
+	Due       int    `json:"due"`
+}
+
+// ProgressSummary is the progress report printed by the stats subcommand
+type ProgressSummary struct {
+	Profile     string           `json:"profile,omitempty"`
+	Questions   int              `json:"questions"`
+	Mastered    int              `json:"mastered"`
+	DueToday    int              `json:"due_today"`
+	Streak      int              `json:"streak"`       // Days in a row with a completed daily challenge
+	DailyPlayed bool             `json:"daily_played"` // Whether today's challenge was completed
+	Chapters    []ChapterSummary `json:"chapters"`
+}
+
+// summarizeProgress builds the progress report of a deck
+func summarizeProgress(questions []Question, progress *Progress, now time.Time) ProgressSummary {
+	dueBy := endOfDay(now)
+	_, played := progress.dailyRecordFor(now)
+	summary := ProgressSummary{
+		Questions:   len(questions),
+		Streak:      progress.dailyStreak(now),
+		DailyPlayed: played,
+		Chapters:    []ChapterSummary{},
+	}
+	for _, chapter := range deckChapters(questions) {
+		chapterQuestions := getQuestionsByChapter(questions, chapter)
+		studied := 0
+		for _, q := range chapterQuestions {
+			if progress.stats(q.QID) != nil {
+				studied++
+			}
+		}
+		c := ChapterSummary{
+			Chapter:   chapter,
+			Questions: len(chapterQuestions),
+			Studied:   studied,
+			Mastered:  countMastered(chapterQuestions, progress),
+			Due:       countDue(chapterQuestions, progress, dueBy),
+		}
+		summary.Mastered += c.Mastered
+		summary.DueToday += c.Due
+		summary.Chapters = append(summary.Chapters, c)
+	}
+	return summary
+}
+
+// percent returns part as a whole-number percentage of total
+func percent(part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return part * 100 / total
+}
+
+// runStats implements "GenkiQuiz stats", printing the learner's progress without the GUI
+func runStats(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	portable := flags.Bool("portable", false, "read the files kept beside the executable")
+	profile := flags.String("profile", "", "learner whose progress is shown (or set "+envProfile+")")
+	deckPath := flags.String("deck", "", "question sheet to report on (or set "+envDeck+")")
+	format := flags.String("format", "text", `output format: "text", "json" or "short" (one line, for shell prompts)`)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: GenkiQuiz stats [flags]")
+		fmt.Fprintln(stderr, "Encrypted progress is unlocked with the passphrase in "+envPassphrase+".")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "text" && *format != "json" && *format != "short" {
+		fmt.Fprintf(stderr, "unknown format %q\n", *format)
+		return 2
+	}
+
+	if err := setupStorage(*portable, *profile); err != nil {
+		fmt.Fprintf(stderr, "Failed to set up data folders: %v\n", err)
+		return 1
+	}
+	deck, err := resolveDeck(*deckPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load quiz questions: %v\n", err)
+		return 1
+	}
+	questions, err := loadQuestionsFromExcel(deck)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load quiz questions: %v\n", err)
+		return 1
+	}
+	progress, err := readProgress(os.Getenv(envPassphrase))
+	if errors.Is(err, errProgressLocked) {
+		fmt.Fprintf(stderr, "Your progress is encrypted; set %s to read it\n", envPassphrase)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load progress: %v\n", err)
+		return 1
+	}
+
+	summary := summarizeProgress(questions, progress, time.Now())
+	summary.Profile = flagOrEnv(*profile, envProfile)
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(summary); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	case "short":
+		daily := ""
+		if !summary.DailyPlayed {
+			daily = ", daily challenge open"
+		}
+		fmt.Fprintf(stdout, "%d due, %d-day streak%s, %d/%d mastered\n",
+			summary.DueToday, summary.Streak, daily, summary.Mastered, summary.Questions)
+	default:
+		w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Chapter\tMastered\tStudied\tDue Today")
+		for _, c := range summary.Chapters {
+			fmt.Fprintf(w, "%s\t%d/%d (%d%%)\t%d\t%d\n",
+				c.Chapter, c.Mastered, c.Questions, percent(c.Mastered, c.Questions), c.Studied, c.Due)
+		}
+		fmt.Fprintf(w, "Total\t%d/%d (%d%%)\t\t%d\n",
+			summary.Mastered, summary.Questions, percent(summary.Mastered, summary.Questions), summary.DueToday)
+		w.Flush()
+		daily := "not played yet"
+		if summary.DailyPlayed {
+			daily = "done"
+		}
+		fmt.Fprintf(stdout, "\nDaily challenge streak: %d day(s), today's challenge %s\n", summary.Streak, daily)
+	}
+	return 0
+}
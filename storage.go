@@ -0,0 +1,242 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// portableMarker turns on portable mode when it is found next to the executable
+const portableMarker = "portable.txt"
+
+// logFile receives the log messages, in the logs folder
+const logFile = "genkiquiz.log"
+
+// Environment variables for scripted and classroom setups. They win over the settings
+// and a portableMarker, and a command-line flag that is given wins over them.
+const (
+	envData         = "GENKIQUIZ_DATA"          // Folder for all files, like portable mode but anywhere
+	envDeck         = "GENKIQUIZ_DECK"          // Question sheet to load
+	envProfile      = "GENKIQUIZ_PROFILE"       // Learner whose settings and progress are used
+	envPassphrase   = "GENKIQUIZ_PASSPHRASE"    // Unlocks encrypted progress for subcommands
+	envDiscordToken = "GENKIQUIZ_DISCORD_TOKEN" // Discord bot token for stream chat, never saved in the settings
+)
+
+// flagOrEnv returns the value of a command-line flag, or the environment variable name
+// when the flag was not given
+func flagOrEnv(value, name string) string {
+	if value != "" {
+		return value
+	}
+	return os.Getenv(name)
+}
+
+// validProfile reports whether a profile name can be used as a folder name
+func validProfile(name string) bool {
+	return name != "." && name != ".." && filepath.Base(name) == name && filepath.IsLocal(name)
+}
+
+// appStorage knows the folders the app keeps its files in
+type appStorage struct {
+	config string // Settings
+	data   string // Progress, class data and blueprints
+	decks  string // Question sheets
+	logs   string
+}
+
+// store holds the folders in use, set up by setupStorage
+var store appStorage
+
+// guestMode keeps progress, settings and other app data in memory only, for trying the app on
+// someone else's computer or demonstrating it. Decks are read as usual. Set with -guest.
+var guestMode bool
+
+// errGuestMode is returned for actions that must write app data in guest mode
+var errGuestMode = errors.New("not available in guest mode, which saves nothing")
+
+// configPath returns the location of a configuration file
+func configPath(name string) string {
+	return filepath.Join(store.config, name)
+}
+
+// dataPath returns the location of a data file
+func dataPath(name string) string {
+	return filepath.Join(store.data, name)
+}
+
+// logPath returns the location of a log file
+func logPath(name string) string {
+	return filepath.Join(store.logs, name)
+}
+
+// executableDir returns the folder the running binary is in
+func executableDir() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return "", err
+	}
+	return filepath.Dir(exe), nil
+}
+
+// envDir returns an environment variable holding a folder, or fallback joined with elem if it is unset
+func envDir(name, fallback string, elem ...string) string {
+	if dir := os.Getenv(name); filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(append([]string{fallback}, elem...)...)
+}
+
+// platformStorage returns the usual folders of the platform: the XDG base directories
+// on Linux and other Unix systems, AppData on Windows and Library on macOS
+func platformStorage() (appStorage, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return appStorage{}, err
+	}
+	var s appStorage
+	switch runtime.GOOS {
+	case "windows":
+		roaming := envDir("APPDATA", home, "AppData", "Roaming")
+		local := envDir("LOCALAPPDATA", home, "AppData", "Local")
+		s.config = filepath.Join(roaming, "GenkiQuiz")
+		s.data = s.config
+		s.logs = filepath.Join(local, "GenkiQuiz", "Logs")
+	case "darwin":
+		s.config = filepath.Join(home, "Library", "Application Support", "GenkiQuiz")
+		s.data = s.config
+		s.logs = filepath.Join(home, "Library", "Logs", "GenkiQuiz")
+	default:
+		s.config = filepath.Join(envDir("XDG_CONFIG_HOME", home, ".config"), "genkiquiz")
+		s.data = filepath.Join(envDir("XDG_DATA_HOME", home, ".local", "share"), "genkiquiz")
+		s.logs = filepath.Join(envDir("XDG_STATE_HOME", home, ".local", "state"), "genkiquiz")
+	}
+	s.decks = filepath.Join(s.data, "decks")
+	return s, nil
+}
+
+// setupStorage chooses the folders for all files and creates them. Portable mode, asked
+// for with -portable or by a portableMarker beside the executable, keeps everything
+// in the executable's folder, for running from a USB stick. The -portable flag wins
+// over envData, which wins over the marker. A profile keeps its own settings and
+// progress in a subfolder, sharing the decks.
+func setupStorage(portable bool, profile string) error {
+	exeDir, err := executableDir()
+	if err != nil {
+		return err
+	}
+	dataOverride := ""
+	if !portable {
+		dataOverride = os.Getenv(envData)
+	}
+	if _, err := os.Stat(filepath.Join(exeDir, portableMarker)); err == nil {
+		portable = portable || dataOverride == ""
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	switch {
+	case dataOverride != "":
+		dir, err := filepath.Abs(dataOverride)
+		if err != nil {
+			return err
+		}
+		store = appStorage{config: dir, data: dir, decks: dir, logs: dir}
+	case portable:
+		store = appStorage{config: exeDir, data: exeDir, decks: exeDir, logs: exeDir}
+	default:
+		if store, err = platformStorage(); err != nil {
+			return err
+		}
+	}
+
+	if profile = flagOrEnv(profile, envProfile); profile != "" {
+		if !validProfile(profile) {
+			return fmt.Errorf("invalid profile name %q", profile)
+		}
+		store.config = filepath.Join(store.config, "profiles", profile)
+		store.data = filepath.Join(store.data, "profiles", profile)
+	}
+	if guestMode {
+		return nil
+	}
+	for _, dir := range []string{store.config, store.data, store.decks, store.logs} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	if !portable && dataOverride == "" && profile == "" {
+		migrateWorkingDirFiles()
+	}
+	return nil
+}
+
+// migrateWorkingDirFiles copies files that older versions kept in the working directory
+// to their new folders, unless they exist there already. The old files are left alone.
+func migrateWorkingDirFiles() {
+	for name, dest := range map[string]string{
+		settingsFile:  configPath(settingsFile),
+		progressFile:  dataPath(progressFile),
+		teacherFile:   dataPath(teacherFile),
+		blueprintFile: dataPath(blueprintFile),
+	} {
+		if _, err := os.Stat(dest); !errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		data, err := os.ReadFile(name)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err == nil {
+			err = os.WriteFile(dest, data, 0o644)
+		}
+		if err != nil {
+			log.Printf("Failed to move %s to %s: %v", name, dest, err)
+		}
+	}
+}
+
+// findDeck locates a question sheet: in the decks folder, else the one shipped
+// beside the executable, else in the working directory
+func findDeck(name string) (string, error) {
+	candidates := []string{filepath.Join(store.decks, name)}
+	if exeDir, err := executableDir(); err == nil {
+		candidates = append(candidates, filepath.Join(exeDir, name))
+	}
+	candidates = append(candidates, name)
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%s not found; put it in %s", name, store.decks)
+}
+
+// resolveDeck returns the question sheet to load: the given path, else the envDeck
+// override, else deckFile found by findDeck
+func resolveDeck(path string) (string, error) {
+	if deck := flagOrEnv(path, envDeck); deck != "" {
+		return deck, nil
+	}
+	return findDeck(deckFile)
+}
+
+// openLog sends log messages to the log file as well as to standard error.
+// In guest mode they only go to standard error.
+func openLog() {
+	if guestMode {
+		return
+	}
+	f, err := os.OpenFile(logPath(logFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		log.Printf("Failed to open log file: %v", err)
+		return
+	}
+	log.SetOutput(io.MultiWriter(os.Stderr, f))
+}
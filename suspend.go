@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// suspended reports whether a question was taken out of study
+func (p *Progress) suspended(qid string) bool {
+	_, ok := p.Suspended[qid]
+	return ok
+}
+
+// suspend takes a question out of study without removing it from the deck
+func (p *Progress) suspend(qid string) {
+	if p.Suspended == nil {
+		p.Suspended = make(map[string]time.Time)
+	}
+	if _, ok := p.Suspended[qid]; !ok {
+		p.Suspended[qid] = time.Now().Truncate(time.Second)
+	}
+}
+
+// unsuspend puts a suspended question back into study
+func (p *Progress) unsuspend(qid string) {
+	delete(p.Suspended, qid)
+}
+
+// activeQuestions leaves out the suspended questions and those in the mastered archive
+func activeQuestions(questions []Question, progress *Progress) []Question {
+	if len(progress.Suspended) == 0 && len(progress.Retired) == 0 {
+		return questions
+	}
+	var active []Question
+	for _, q := range questions {
+		if !progress.suspended(q.QID) && !progress.retired(q.QID) {
+			active = append(active, q)
+		}
+	}
+	return active
+}
+
+// studyPool returns the questions of a deck quizzes draw from: those unlocked,
+// and neither suspended nor archived
+func studyPool(questions []Question, progress *Progress) []Question {
+	return activeQuestions(unlockedQuestions(questions, progress), progress)
+}
+
+// studyQuestions returns the questions of the deck quizzes draw from, see studyPool
+func (qa *quizApp) studyQuestions() []Question {
+	return studyPool(qa.questions, qa.progress)
+}
+
+// suspendQuestion suspends a question of the quiz going on. The question being shown can
+// still be answered, but no suspended question is drawn again in this quiz or any later one.
+func (qa *quizApp) suspendQuestion(q Question) {
+	state := qa.state
+	qa.progress.suspend(q.QID)
+	if err := qa.progress.save(); err != nil {
+		log.Printf("Failed to save progress: %v", err)
+	}
+	if pool := activeQuestions(state.chapterQuestions, qa.progress); len(pool) > 0 {
+		state.chapterQuestions = pool
+	}
+	if q.QID == state.current.QID {
+		qa.answerInfo.SetText("Suspended — this question will not be asked again. See Quiz ▸ Suspended Questions to bring it back.")
+	} else {
+		qa.answerInfo.SetText(fmt.Sprintf("Suspended %s — it will not be asked again. See Quiz ▸ Suspended Questions to bring it back.", q.QHirakata))
+	}
+	qa.progressChanged()
+}
+
+// setAsideQuestions returns the questions of the deck in a set of QIDs with the time they were
+// set aside, such as Progress.Suspended, most recently set aside first
+func (qa *quizApp) setAsideQuestions(set map[string]time.Time) []Question {
+	var questions []Question
+	for _, q := range qa.questions {
+		if _, ok := set[q.QID]; ok {
+			questions = append(questions, q)
+		}
+	}
+	sort.SliceStable(questions, func(i, j int) bool {
+		return set[questions[i].QID].After(set[questions[j].QID])
+	})
+	return questions
+}
+
+// showSetAside lists the questions of a set taken out of study, each with a button labelled
+// restore to bring it back with unset. what describes when a question was set aside, e.g. "suspended".
+func (qa *quizApp) showSetAside(title, empty, what, restore string, set map[string]time.Time, unset func(qid string)) {
+	list := container.NewVBox()
+	save := func() {
+		if err := qa.progress.save(); err != nil {
+			dialog.ShowError(err, qa.window)
+		}
+		qa.progressChanged()
+	}
+	var refresh func()
+	refresh = func() {
+		list.Objects = nil
+		questions := qa.setAsideQuestions(set)
+		if len(questions) == 0 {
+			list.Add(widget.NewLabel(empty))
+		}
+		for _, q := range questions {
+			q := q
+			list.Add(container.NewBorder(nil, nil, nil,
+				widget.NewButton(restore, func() {
+					unset(q.QID)
+					save()
+					refresh()
+				}),
+				widget.NewLabel(fmt.Sprintf("Ch. %s: %s (%s) — %s, %s %s", q.QChapter, q.QHirakata, q.QRomaji, q.QAnswer,
+					what, set[q.QID].Format("Jan 2"))),
+			))
+		}
+		if len(questions) > 1 {
+			list.Add(widget.NewButton(restore+" All", func() {
+				for _, q := range questions {
+					unset(q.QID)
+				}
+				save()
+				refresh()
+			}))
+		}
+		list.Refresh()
+	}
+	refresh()
+
+	d := dialog.NewCustom(title, "Close", container.NewVScroll(list), qa.window)
+	d.Resize(fyne.NewSize(560, 420))
+	d.Show()
+}
+
+// showSuspended lists the suspended questions, each with a button to bring it back into study
+func (qa *quizApp) showSuspended() {
+	qa.showSetAside("Suspended Questions",
+		"No questions are suspended. Right-click or long-press a question during a quiz to suspend it.",
+		"suspended", "Unsuspend",
+		qa.progress.Suspended, qa.progress.unsuspend)
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// mainTabs builds the tabs of the main window. The quiz tab holds the screen router,
+// so a quiz in progress is kept while browsing or looking at statistics.
+func (qa *quizApp) mainTabs() *container.AppTabs {
+	quizTab := container.NewTabItemWithIcon("Quiz", theme.HomeIcon(), qa.router.container)
+	qa.browseTab = container.NewTabItemWithIcon("Browse", theme.SearchIcon(), qa.browseContent())
+	qa.statsTab = container.NewTabItemWithIcon("Stats", theme.InfoIcon(), qa.statsTabContent())
+	qa.tabs = container.NewAppTabs(quizTab, qa.browseTab, qa.statsTab)
+
+	// Settings are not available in kiosk mode
+	if !qa.kiosk {
+		qa.settingsTab = container.NewTabItemWithIcon("Settings", theme.SettingsIcon(), qa.settingsTabContent())
+		qa.tabs.Append(qa.settingsTab)
+	}
+
+	qa.tabs.OnSelected = func(tab *container.TabItem) {
+		// Statistics are rebuilt so they include the latest answers. Only choosing the tab
+		// by hand selects it, never a serialized quiz action, so the lock can be taken here.
+		if tab == qa.statsTab {
+			qa.serialized(func() {
+				tab.Content = qa.statsTabContent()
+				qa.tabs.Refresh()
+			})()
+		}
+	}
+	return qa.tabs
+}
+
+// settingsTabContent shows the settings form, or an unlock button if they are locked by a PIN.
+// Saving or cancelling locks them again.
+func (qa *quizApp) settingsTabContent() fyne.CanvasObject {
+	form := func() fyne.CanvasObject {
+		return qa.settingsContent(func(saved bool) {
+			if saved {
+				dialog.ShowInformation("Settings", "Your settings were saved.", qa.window)
+			}
+			qa.settingsTab.Content = qa.settingsTabContent()
+			qa.tabs.Refresh()
+		})
+	}
+	if qa.settings.LockPIN == "" {
+		return form()
+	}
+	return container.NewCenter(container.NewVBox(
+		widget.NewLabel("Settings are locked."),
+		widget.NewButton("Unlock", qa.serialized(func() {
+			qa.requireLockPIN("Settings", func() {
+				qa.settingsTab.Content = form()
+				qa.tabs.Refresh()
+			})
+		})),
+	))
+}
+
+// browseContent lists the words of the deck, filtered by chapter and a search term
+func (qa *quizApp) browseContent() fyne.CanvasObject {
+	var shown []Question
+	chapterSelect := widget.NewSelect(append([]string{"All Chapters"}, qa.availableChapters()...), nil)
+	searchEntry := widget.NewEntry()
+	searchEntry.SetPlaceHolder("Search kana, romaji or meaning, or a #tag such as #verb")
+	detail := widget.NewLabel("")
+	detail.Wrapping = fyne.TextWrapWord
+
+	list := widget.NewList(
+		func() int {
+			return len(shown)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			q := shown[id]
+			mark := ""
+			if q.KanaCheck != "" {
+				mark = "⚠ " // Kana made from romaji that needs checking
+			}
+			item.(*widget.Label).SetText(fmt.Sprintf("%s%s (%s) — %s", mark, q.QHirakata, q.QRomaji, q.QAnswer))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		q := shown[id]
+		text := fmt.Sprintf("Chapter %s: %s (%s) — %s", q.QChapter, q.QHirakata, q.QRomaji, q.QAnswer)
+		if q.QKanji != "" {
+			text += "\nKanji: " + q.QKanji
+		}
+		if q.QExample != "" {
+			text += "\nExample: " + q.QExample
+		}
+		if hint := loanwordHint(q); hint != "" {
+			text += "\n" + hint
+		}
+		if q.KanaFromRomaji {
+			text += "\nThe kana was made from the romaji, the deck having none."
+		}
+		if q.KanaCheck != "" {
+			text += "\nPlease check it: " + q.KanaCheck + "."
+		}
+		if missing := missingPrerequisites(q, qa.index.byQID, qa.progress); len(missing) > 0 {
+			var words []string
+			for _, p := range missing {
+				words = append(words, p.QHirakata)
+			}
+			text += "\nLocked until you master: " + strings.Join(words, ", ")
+		}
+		if s := qa.progress.stats(q.QID); s != nil {
+			text += fmt.Sprintf("\nNext review: %s, ease %.2f", s.dueAt().Format("Jan 2, 2006"), s.ease())
+		}
+		if s := qa.progress.stats(q.QID); s.leech(qa.schedule(q.QChapter).LeechThreshold) {
+			text += fmt.Sprintf("\nLeech: missed %d times. A mnemonic or the example sentence may help.", s.Seen-s.Correct)
+		}
+		detail.SetText(text)
+	}
+
+	filter := func() {
+		chapters := qa.availableChapters()
+		if chapterSelect.Selected != "" && chapterSelect.Selected != "All Chapters" {
+			chapters = []string{chapterSelect.Selected}
+		}
+		term := strings.ToLower(strings.TrimSpace(searchEntry.Text))
+		candidates := qa.index.inChapters(chapters)
+		// Tags are those of exported Quizlet sets, see questionTags
+		if tag, ok := strings.CutPrefix(term, "#"); ok {
+			inChapters := make(map[string]bool)
+			for _, chapter := range chapters {
+				inChapters[chapter] = true
+			}
+			candidates = nil
+			for _, q := range qa.index.byTag[tag] {
+				if inChapters[q.QChapter] {
+					candidates = append(candidates, q)
+				}
+			}
+			term = ""
+		}
+		kana := typedKana(term)
+		shown = shown[:0]
+		for _, q := range candidates {
+			if term == "" ||
+				strings.Contains(toHiragana(q.QHirakata), kana) ||
+				strings.Contains(strings.ToLower(q.QRomaji), term) ||
+				strings.Contains(strings.ToLower(q.QAnswer), term) {
+				shown = append(shown, q)
+			}
+		}
+		list.UnselectAll()
+		status := fmt.Sprintf("%d words", len(shown))
+		checks := 0
+		for _, q := range shown {
+			if q.KanaCheck != "" {
+				checks++
+			}
+		}
+		if checks > 0 {
+			status += fmt.Sprintf(", %d with kana made from romaji to check (⚠)", checks)
+		}
+		detail.SetText(status)
+		list.Refresh()
+	}
+	chapterSelect.OnChanged = func(string) {
+		filter()
+	}
+	searchEntry.OnChanged = func(string) {
+		filter()
+	}
+	chapterSelect.SetSelected("All Chapters")
+
+	return container.NewBorder(
+		container.NewBorder(nil, nil, chapterSelect, nil, searchEntry),
+		detail,
+		nil, nil,
+		list,
+	)
+}
@@ -0,0 +1,449 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Assignment is a quiz configuration handed out to a class
+type Assignment struct {
+	Name      string   `json:"name"`
+	Chapters  []string `json:"chapters"`
+	Questions int      `json:"questions"` // Number of questions in the quiz
+}
+
+// classData is everything teacher mode keeps: the roster, assignments and imported results
+type classData struct {
+	Roster      []string     `json:"roster"`
+	Assignments []Assignment `json:"assignments"`
+	Results     []quizResult `json:"results"`
+}
+
+// teacherFile stores the class data between sessions
+const teacherFile = "teacher.json"
+
+// loadClassData reads the class data, starting empty if none was saved yet
+func loadClassData() (*classData, error) {
+	class := &classData{}
+	data, err := os.ReadFile(dataPath(teacherFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return class, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, class); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", teacherFile, err)
+	}
+	return class, nil
+}
+
+// save writes the class data to teacherFile, or nothing in guest mode
+func (c *classData) save() error {
+	if guestMode {
+		return nil
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dataPath(teacherFile), data, 0o644)
+}
+
+// hasStudent reports whether a student is on the roster (case-insensitive)
+func (c *classData) hasStudent(name string) bool {
+	for _, student := range c.Roster {
+		if strings.EqualFold(student, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// importResults adds results not already imported and returns how many were new
+func (c *classData) importResults(results []quizResult) int {
+	added := 0
+	for _, r := range results {
+		duplicate := false
+		for _, existing := range c.Results {
+			if existing.sameAttempt(r) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			c.Results = append(c.Results, r)
+			added++
+		}
+	}
+	return added
+}
+
+// latestResult finds a student's most recent result for an assignment
+func (c *classData) latestResult(student, quiz string) (quizResult, bool) {
+	var latest quizResult
+	found := false
+	for _, r := range c.Results {
+		if strings.EqualFold(r.Student, student) && r.Quiz == quiz {
+			if !found || r.CompletedAt.After(latest.CompletedAt) {
+				latest = r
+				found = true
+			}
+		}
+	}
+	return latest, found
+}
+
+// unknownStudents lists result submitters who are not on the roster
+func (c *classData) unknownStudents() []string {
+	seen := make(map[string]bool)
+	var unknown []string
+	for _, r := range c.Results {
+		key := strings.ToLower(r.Student)
+		if !c.hasStudent(r.Student) && !seen[key] {
+			seen[key] = true
+			unknown = append(unknown, r.Student)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// writeGradebookCSV writes one row per student with the latest score, percentage and
+// completion time of every assignment, ready to paste into a school gradebook
+func writeGradebookCSV(w io.Writer, class *classData) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"Student"}
+	for _, a := range class.Assignments {
+		header = append(header, a.Name+" Score", a.Name+" %", a.Name+" Completed")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, student := range class.Roster {
+		record := []string{student}
+		for _, a := range class.Assignments {
+			r, ok := class.latestResult(student, a.Name)
+			if !ok {
+				record = append(record, "", "", "")
+				continue
+			}
+			record = append(record,
+				fmt.Sprintf("%d/%d", r.Score, r.Total),
+				fmt.Sprintf("%.1f", r.percent()),
+				r.CompletedAt.Local().Format("2006-01-02 15:04"),
+			)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportGradebook asks for a destination and writes the class gradebook
+func (qa *quizApp) exportGradebook(class *classData) {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		if err := writeGradebookCSV(writer, class); err != nil {
+			dialog.ShowError(err, qa.window)
+		}
+	}, qa.window)
+	save.SetFileName(fmt.Sprintf("gradebook-%s.csv", time.Now().Format("2006-01-02")))
+	save.Show()
+}
+
+// parseChapterList splits a comma separated chapter list like "1, 2, 3"
+func parseChapterList(text string) []string {
+	var chapters []string
+	for _, part := range strings.Split(text, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			chapters = append(chapters, part)
+		}
+	}
+	return chapters
+}
+
+// startAssignment runs an assignment as a quiz
+func (qa *quizApp) startAssignment(a Assignment) {
+	state := qa.state
+	state.chapterQuestions = qa.studyChapters(a.Chapters)
+	state.currentChapter = strings.Join(a.Chapters, ", ")
+	state.quizName = a.Name
+	state.totalQuestions = a.Questions
+	if len(state.chapterQuestions) < state.totalQuestions {
+		state.totalQuestions = len(state.chapterQuestions)
+	}
+	if state.totalQuestions == 0 {
+		dialog.ShowInformation("Assignment", "No questions available for this assignment.", qa.window)
+		return
+	}
+	qa.startQuiz()
+}
+
+// openAssignment lets a student pick an assignment file from their teacher
+func (qa *quizApp) openAssignment() {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+		defer reader.Close()
+
+		var a Assignment
+		if err := json.NewDecoder(reader).Decode(&a); err != nil {
+			dialog.ShowError(fmt.Errorf("invalid assignment file: %w", err), qa.window)
+			return
+		}
+		qa.startAssignment(a)
+	}, qa.window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	open.Show()
+}
+
+// showTeacherMode shows the roster, assignments and class overview
+func (qa *quizApp) showTeacherMode() {
+	class, err := loadClassData()
+	if err != nil {
+		dialog.ShowError(err, qa.window)
+		return
+	}
+
+	save := func() {
+		if err := class.save(); err != nil {
+			dialog.ShowError(err, qa.window)
+		}
+	}
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Overview", qa.classOverview(class)),
+		container.NewTabItem("Roster", qa.rosterEditor(class, save)),
+		container.NewTabItem("Assignments", qa.assignmentEditor(class, save)),
+	)
+
+	importButton := widget.NewButton("Import Result CSV", qa.serialized(func() {
+		open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, qa.window)
+				return
+			}
+			if reader == nil {
+				return // Cancelled
+			}
+			defer reader.Close()
+
+			results, err := readResultsCSV(reader)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("%s: %w", reader.URI().Name(), err), qa.window)
+				return
+			}
+			added := class.importResults(results)
+			save()
+			dialog.ShowInformation("Import Results",
+				fmt.Sprintf("Imported %d new result(s) from %s.", added, reader.URI().Name()), qa.window)
+			qa.showTeacherMode()
+		}, qa.window)
+		open.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+		open.Show()
+	}))
+
+	qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle("Teacher Mode", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		container.NewGridWithColumns(3,
+			importButton,
+			widget.NewButton("Export Gradebook", qa.serialized(func() {
+				qa.exportGradebook(class)
+			})),
+			widget.NewButton("Back to Chapter Selection", qa.serialized(func() {
+				qa.showChapterSelection()
+			})),
+		),
+		nil, nil,
+		tabs,
+	))
+}
+
+// classOverview shows the latest score of every student for every assignment
+func (qa *quizApp) classOverview(class *classData) fyne.CanvasObject {
+	if len(class.Roster) == 0 || len(class.Assignments) == 0 {
+		return widget.NewLabel("Add students and assignments, then import their result files.")
+	}
+
+	grid := container.NewGridWithColumns(len(class.Assignments) + 1)
+	grid.Add(widget.NewLabelWithStyle("Student", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for _, a := range class.Assignments {
+		grid.Add(widget.NewLabelWithStyle(a.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	}
+
+	for _, student := range class.Roster {
+		grid.Add(widget.NewLabel(student))
+		for _, a := range class.Assignments {
+			cell := "—"
+			if r, ok := class.latestResult(student, a.Name); ok {
+				cell = fmt.Sprintf("%d/%d (%.0f%%)", r.Score, r.Total, r.percent())
+			}
+			grid.Add(widget.NewLabel(cell))
+		}
+	}
+
+	content := container.NewVBox(grid)
+	if unknown := class.unknownStudents(); len(unknown) > 0 {
+		content.Add(widget.NewLabel("Results from students not on the roster: " + strings.Join(unknown, ", ")))
+	}
+	return container.NewScroll(content)
+}
+
+// rosterEditor lists the class roster with controls to add and remove students
+func (qa *quizApp) rosterEditor(class *classData, save func()) fyne.CanvasObject {
+	list := container.NewVBox()
+	var refresh func()
+	refresh = func() {
+		list.Objects = nil
+		for i, student := range class.Roster {
+			i := i
+			list.Add(container.NewBorder(nil, nil, nil,
+				widget.NewButton("Remove", qa.serialized(func() {
+					class.Roster = append(class.Roster[:i], class.Roster[i+1:]...)
+					save()
+					refresh()
+				})),
+				widget.NewLabel(student),
+			))
+		}
+		list.Refresh()
+	}
+	refresh()
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Student name")
+	add := func() {
+		name := strings.TrimSpace(nameEntry.Text)
+		if name == "" || class.hasStudent(name) {
+			return
+		}
+		class.Roster = append(class.Roster, name)
+		nameEntry.SetText("")
+		save()
+		refresh()
+	}
+	nameEntry.OnSubmitted = func(string) { add() }
+
+	return container.NewBorder(nil,
+		container.NewBorder(nil, nil, nil, widget.NewButton("Add", qa.serialized(add)), nameEntry),
+		nil, nil,
+		container.NewScroll(list),
+	)
+}
+
+// assignmentEditor lists assignments with controls to add, export and remove them
+func (qa *quizApp) assignmentEditor(class *classData, save func()) fyne.CanvasObject {
+	list := container.NewVBox()
+	var refresh func()
+	refresh = func() {
+		list.Objects = nil
+		for i, a := range class.Assignments {
+			i, a := i, a
+			list.Add(container.NewBorder(nil, nil, nil,
+				container.NewHBox(
+					widget.NewButton("Export", qa.serialized(func() {
+						qa.exportAssignment(a)
+					})),
+					widget.NewButton("Remove", qa.serialized(func() {
+						class.Assignments = append(class.Assignments[:i], class.Assignments[i+1:]...)
+						save()
+						refresh()
+					})),
+				),
+				widget.NewLabel(fmt.Sprintf("%s — chapters %s, %d questions",
+					a.Name, strings.Join(a.Chapters, ", "), a.Questions)),
+			))
+		}
+		list.Refresh()
+	}
+	refresh()
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Assignment name")
+	chaptersEntry := widget.NewEntry()
+	chaptersEntry.SetPlaceHolder("Chapters, e.g. 1, 2")
+	countEntry := widget.NewEntry()
+	countEntry.SetText("10")
+
+	addButton := widget.NewButton("Add Assignment", qa.serialized(func() {
+		name := strings.TrimSpace(nameEntry.Text)
+		chapters := parseChapterList(chaptersEntry.Text)
+		count, err := strconv.Atoi(strings.TrimSpace(countEntry.Text))
+		if name == "" || len(chapters) == 0 || err != nil || count <= 0 {
+			dialog.ShowInformation("Assignment", "Enter a name, at least one chapter and a question count.", qa.window)
+			return
+		}
+		class.Assignments = append(class.Assignments, Assignment{Name: name, Chapters: chapters, Questions: count})
+		nameEntry.SetText("")
+		chaptersEntry.SetText("")
+		save()
+		refresh()
+	}))
+
+	return container.NewBorder(nil,
+		container.NewVBox(
+			widget.NewForm(
+				widget.NewFormItem("Name", nameEntry),
+				widget.NewFormItem("Chapters", chaptersEntry),
+				widget.NewFormItem("Questions", countEntry),
+			),
+			addButton,
+		),
+		nil, nil,
+		container.NewScroll(list),
+	)
+}
+
+// exportAssignment saves an assignment file for students to open
+func (qa *quizApp) exportAssignment(a Assignment) {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, qa.window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		enc := json.NewEncoder(writer)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(a); err != nil {
+			dialog.ShowError(err, qa.window)
+		}
+	}, qa.window)
+	save.SetFileName(a.Name + ".json")
+	save.Show()
+}
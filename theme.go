@@ -0,0 +1,45 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+)
+
+// variantTheme is the default theme locked to the light or dark variant
+type variantTheme struct {
+	fyne.Theme
+	variant fyne.ThemeVariant
+}
+
+// Color returns the colour of the chosen variant, whatever the system prefers
+func (t variantTheme) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	return t.Theme.Color(name, t.variant)
+}
+
+// applyTheme switches to the light or dark theme and the fonts from the settings
+func (qa *quizApp) applyTheme() {
+	base := theme.DefaultTheme()
+	switch qa.settings.Theme {
+	case "light":
+		base = variantTheme{theme.DefaultTheme(), theme.VariantLight}
+	case "dark":
+		base = variantTheme{theme.DefaultTheme(), theme.VariantDark}
+	}
+	qa.app.Settings().SetTheme(qa.settings.Fonts.withFonts(base))
+	qa.japaneseText(qa.questionLabel, questionTextSize)
+	if qa.verticalQuestion != nil {
+		qa.verticalQuestion.Refresh()
+	}
+}
+
+// setTheme changes and saves the theme setting
+func (qa *quizApp) setTheme(name string) {
+	qa.settings.Theme = name
+	if err := qa.settings.save(); err != nil {
+		dialog.ShowError(err, qa.window)
+	}
+	qa.applyTheme()
+}
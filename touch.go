@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// thumbButtonHeight is the height of answer buttons on phones and tablets, easy to hit with a thumb
+const thumbButtonHeight = 64
+
+// swipeDistance is how far a drag has to go sideways to count as a swipe
+const swipeDistance = 80
+
+// isMobile reports whether the app runs on a phone or tablet
+func isMobile() bool {
+	return fyne.CurrentDevice().IsMobile()
+}
+
+// swipeArea wraps content and calls onSwipeLeft when it is swiped to the left.
+// Taps still reach the buttons inside, as they do not take drags.
+type swipeArea struct {
+	widget.BaseWidget
+	content     fyne.CanvasObject
+	onSwipeLeft func()
+	dragged     float32 // Sideways distance of the drag in progress
+}
+
+// newSwipeArea wraps content so it can be swiped
+func newSwipeArea(content fyne.CanvasObject, onSwipeLeft func()) *swipeArea {
+	area := &swipeArea{content: content, onSwipeLeft: onSwipeLeft}
+	area.ExtendBaseWidget(area)
+	return area
+}
+
+// CreateRenderer draws the wrapped content
+func (a *swipeArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(a.content)
+}
+
+// Dragged adds up how far the finger moved sideways
+func (a *swipeArea) Dragged(e *fyne.DragEvent) {
+	a.dragged += e.Dragged.DX
+}
+
+// DragEnd calls onSwipeLeft if the drag went far enough to the left
+func (a *swipeArea) DragEnd() {
+	if a.dragged < -swipeDistance && a.onSwipeLeft != nil {
+		a.onSwipeLeft()
+	}
+	a.dragged = 0
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"regexp"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+//go:embed data/translation_exercises.json
+var bundledTranslationsJSON []byte
+
+// translationFile holds custom translation exercises, loaded from the data folder if present
+const translationFile = "translations.json"
+
+// translationDrillSize is the number of sentences in a translation drill
+const translationDrillSize = 5
+
+// keywordPattern finds the keywords of a reference translation: [every day], or
+// [drink|have] for words that may take each other's place
+var keywordPattern = regexp.MustCompile(`\[([^\]]+)\]`)
+
+// TranslationExercise is a Japanese sentence to translate into English. A translation
+// is right when it has every keyword of one of the references.
+type TranslationExercise struct {
+	Chapter    string   `json:"chapter"`
+	Japanese   string   `json:"japanese"`
+	References []string `json:"references"` // English translations with their keywords in brackets
+}
+
+// referenceText writes a reference translation without its brackets, each keyword as its first choice
+func referenceText(reference string) string {
+	return keywordPattern.ReplaceAllStringFunc(reference, func(keyword string) string {
+		choice, _, _ := strings.Cut(strings.Trim(keyword, "[]"), "|")
+		return choice
+	})
+}
+
+// missingKeywords returns the keywords of a reference a translation does not have, each
+// as its first choice. Words are compared under the learner's answer rules.
+func (r answerRules) missingKeywords(translation, reference string) []string {
+	text := " " + r.normalize(translation) + " "
+	var missing []string
+	for _, match := range keywordPattern.FindAllStringSubmatch(reference, -1) {
+		choices := strings.Split(match[1], "|")
+		found := false
+		for _, choice := range choices {
+			// Articles are kept in keywords, which are not answers of their own
+			keyword := answerRules{StrictCase: r.StrictCase, StrictArticles: true, StrictPunctuation: r.StrictPunctuation}.normalize(choice)
+			if keyword != "" && strings.Contains(text, " "+keyword+" ") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, strings.TrimSpace(choices[0]))
+		}
+	}
+	return missing
+}
+
+// gradeTranslation compares a translation with each reference and returns the keywords
+// missing from the closest one, none if the translation is right
+func (r answerRules) gradeTranslation(translation string, e TranslationExercise) []string {
+	var closest []string
+	for i, reference := range e.References {
+		missing := r.missingKeywords(translation, reference)
+		if i == 0 || len(missing) < len(closest) {
+			closest = missing
+		}
+	}
+	return closest
+}
+
+// loadTranslationExercises returns the bundled translation exercises followed by any
+// custom ones from translationFile. Exercises without a reference are left out.
+func loadTranslationExercises() []TranslationExercise {
+	var exercises []TranslationExercise
+	if err := json.Unmarshal(bundledTranslationsJSON, &exercises); err != nil {
+		log.Printf("Invalid bundled translation exercises: %v", err)
+	}
+	if data, err := os.ReadFile(dataPath(translationFile)); err == nil {
+		var custom []TranslationExercise
+		if err := json.Unmarshal(data, &custom); err != nil {
+			log.Printf("Ignoring %s: %v", translationFile, err)
+		}
+		exercises = append(exercises, custom...)
+	}
+
+	var valid []TranslationExercise
+	for _, e := range exercises {
+		if e.Japanese == "" || len(e.References) == 0 {
+			log.Printf("Skipping translation exercise %q: it has no reference translation", e.Japanese)
+			continue
+		}
+		valid = append(valid, e)
+	}
+	return valid
+}
+
+// translationExercises returns the translation exercises of a chapter
+func translationExercises(chapter string) []TranslationExercise {
+	var exercises []TranslationExercise
+	for _, e := range loadTranslationExercises() {
+		if e.Chapter == chapter {
+			exercises = append(exercises, e)
+		}
+	}
+	return exercises
+}
+
+// translationDrill asks for the English of Japanese sentences, graded by keywords
+type translationDrill struct {
+	qa        *quizApp
+	exercises []TranslationExercise
+	index     int
+	answered  int // Sentences checked
+	score     int
+}
+
+// translationButton starts a translation drill on the current chapter, disabled if it has no exercises
+func (qa *quizApp) translationButton() *widget.Button {
+	exercises := translationExercises(qa.state.currentChapter)
+	button := widget.NewButton(fmt.Sprintf("Translate Sentences (%d)", len(exercises)), qa.serialized(func() {
+		exercises := append([]TranslationExercise(nil), exercises...)
+		rand.Shuffle(len(exercises), func(i, j int) { exercises[i], exercises[j] = exercises[j], exercises[i] })
+		if len(exercises) > translationDrillSize {
+			exercises = exercises[:translationDrillSize]
+		}
+		d := &translationDrill{qa: qa, exercises: exercises}
+		d.showExercise()
+	}))
+	if len(exercises) == 0 {
+		button.Disable()
+	}
+	return button
+}
+
+// showExercise shows the current sentence with a field for its translation
+func (d *translationDrill) showExercise() {
+	if d.index >= len(d.exercises) {
+		d.showResults()
+		return
+	}
+	e := d.exercises[d.index]
+
+	sentence := canvas.NewText(e.Japanese, theme.ForegroundColor())
+	sentence.Alignment = fyne.TextAlignCenter
+	d.qa.japaneseText(sentence, grammarTextSize)
+
+	entry := widget.NewMultiLineEntry()
+	entry.Wrapping = fyne.TextWrapWord
+	entry.SetPlaceHolder("Type the English translation")
+	feedback := wrappedLabel("")
+	overrule := widget.NewButton("I Was Right", nil)
+	overrule.Hide()
+
+	var checkButton *widget.Button
+	answered := false
+	checkButton = widget.NewButton("Check", d.qa.serialized(func() {
+		if answered {
+			d.index++
+			d.showExercise()
+			return
+		}
+		if strings.TrimSpace(entry.Text) == "" {
+			return
+		}
+		answered = true
+		d.answered++
+		entry.Disable()
+		references := make([]string, len(e.References))
+		for i, reference := range e.References {
+			references[i] = referenceText(reference)
+		}
+		reference := "Reference: " + strings.Join(references, "\nor: ")
+		if missing := d.qa.settings.AnswerRules.gradeTranslation(entry.Text, e); len(missing) == 0 {
+			d.score++
+			feedback.SetText("✅ Correct!\n" + reference)
+		} else {
+			feedback.SetText(fmt.Sprintf("❌ Missing: %s\n%s\nCompare your translation: if it means the same, count it as right.",
+				strings.Join(missing, ", "), reference))
+			// Keywords cannot recognize every good translation, so the learner judges too
+			overrule.OnTapped = func() {
+				d.score++
+				overrule.Hide()
+				feedback.SetText("✅ Counted as right.\n" + reference)
+			}
+			overrule.Show()
+		}
+		checkButton.SetText("Next")
+	}))
+	checkButton.Importance = widget.HighImportance
+
+	d.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Translate Sentences — Sentence %d/%d — Score: %d", d.index+1, len(d.exercises), d.score),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		container.NewGridWithColumns(2,
+			checkButton,
+			widget.NewButton("End Drill", d.qa.serialized(d.showResults)),
+		),
+		nil, nil,
+		container.NewVBox(layoutSpacer(), sentence, entry, feedback, overrule),
+	))
+	d.qa.window.Canvas().Focus(entry)
+}
+
+// showResults shows the sentences translated right
+func (d *translationDrill) showResults() {
+	if d.answered == len(d.exercises) {
+		d.qa.earnPerfectXP(float64(d.score), d.answered)
+	}
+	d.qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle("Translate Sentences Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("Your score: %d/%d", d.score, d.answered)),
+		widget.NewButton("Return to Chapter Selection", d.qa.serialized(func() {
+			d.qa.showChapterSelection()
+		})),
+	)))
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xuri/excelize/v2"
+)
+
+// diagnosisRows is how many rows of the question sheet the troubleshooting screen shows, header included
+const diagnosisRows = 6
+
+// questionSheet is the sheet readQuestions loads the questions from
+const questionSheet = "Sheet1"
+
+// deckDiagnosis shows what a workbook looks like and how it differs from what readQuestions expects
+type deckDiagnosis struct {
+	Sheets   []string
+	Sheet    string         // Sheet shown: questionSheet, or the first one if there is none
+	Layout   deckLayout     // Header and columns of Sheet, see detectLayout
+	Rows     [][]string     // Rows of Sheet from the top to diagnosisRows rows from the header
+	RowNotes map[int]string // What is wrong with each of Rows, by index
+	Total    int            // Rows of Sheet below the header
+	Complete int            // Rows readQuestions takes a question from
+	Notes    []string       // What is wrong with the workbook
+}
+
+// diagnoseDeck looks into a workbook that gave no questions
+func diagnoseDeck(r io.Reader) deckDiagnosis {
+	d := deckDiagnosis{RowNotes: make(map[int]string)}
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		d.Notes = append(d.Notes, fmt.Sprintf("The file cannot be read as an Excel workbook (.xlsx): %v. Save it from Excel or another spreadsheet app as an Excel Workbook.", err))
+		return d
+	}
+	defer f.Close()
+
+	d.Sheets = f.GetSheetList()
+	d.Sheet = questionSheet
+	if !slices.Contains(d.Sheets, questionSheet) {
+		if len(d.Sheets) == 0 {
+			d.Notes = append(d.Notes, "The workbook has no sheets.")
+			return d
+		}
+		d.Sheet = d.Sheets[0]
+		d.Notes = append(d.Notes, fmt.Sprintf("The questions must be on a sheet named %s. This workbook has: %s. Rename the sheet with the questions to %s.",
+			questionSheet, strings.Join(d.Sheets, ", "), questionSheet))
+	}
+
+	d.Layout, err = sheetLayout(f, d.Sheet, nil)
+	if errors.Is(err, errEmptySheet) {
+		d.Notes = append(d.Notes, fmt.Sprintf("Sheet %s is empty.", d.Sheet))
+		return d
+	}
+	if err == nil {
+		err = eachRow(f, d.Sheet, func(number int, row []string) {
+			if number > d.Layout.HeaderRow {
+				d.Total++
+				if _, ok := d.Layout.question(number, row); ok {
+					d.Complete++
+				}
+			}
+			if number < d.Layout.HeaderRow+diagnosisRows {
+				d.Rows = append(d.Rows, row)
+				if note := d.rowNote(number, row); note != "" {
+					d.RowNotes[number-1] = note
+				}
+			}
+		})
+	}
+	if err != nil {
+		d.Notes = append(d.Notes, fmt.Sprintf("Sheet %s cannot be read: %v", d.Sheet, err))
+		return d
+	}
+
+	switch {
+	case d.Total == 0:
+		d.Notes = append(d.Notes, fmt.Sprintf("Sheet %s has a header row but no questions below it.", d.Sheet))
+	case d.Complete == 0 && d.Layout.Named:
+		d.Notes = append(d.Notes, fmt.Sprintf("None of the %d rows below the header has an answer and kana or romaji, so every row is skipped.", d.Total))
+	case d.Complete == 0:
+		d.Notes = append(d.Notes, fmt.Sprintf("None of the %d rows below the header fills the %d required columns, so every row is skipped.", d.Total, len(requiredColumns)))
+	}
+	if d.RowNotes[d.Layout.HeaderRow-1] != "" {
+		if d.Layout.Named {
+			d.Notes = append(d.Notes, fmt.Sprintf("The header is in row %d. Name the kana column e.g. Hiragana or Kana, or a romaji column Romaji, and the answer column e.g. English or Answer.", d.Layout.HeaderRow))
+		} else {
+			d.Notes = append(d.Notes, "The first row must be the header, naming the columns, e.g. Chapter, Hiragana and English, or with the columns "+strings.Join(requiredColumns, ", ")+" first and in this order.")
+		}
+	}
+	return d
+}
+
+// diagnoseDeckFile looks into a deck file that gave no questions
+func diagnoseDeckFile(path string) deckDiagnosis {
+	f, err := os.Open(path)
+	if err != nil {
+		return deckDiagnosis{Notes: []string{fmt.Sprintf("The file cannot be opened: %v", err)}}
+	}
+	defer f.Close()
+	return diagnoseDeck(f)
+}
+
+// rowNote tells what is wrong with a row, by its 1-based number
+func (d *deckDiagnosis) rowNote(number int, row []string) string {
+	if number < d.Layout.HeaderRow {
+		return "Above the header, skipped"
+	}
+	if number == d.Layout.HeaderRow && d.Layout.Named {
+		var wrong []string
+		for _, field := range d.Layout.missingColumns() {
+			wrong = append(wrong, "no column for "+columnLabels[field])
+		}
+		return strings.Join(wrong, "; ")
+	}
+	if number == d.Layout.HeaderRow {
+		var wrong []string
+		for i, name := range requiredColumns {
+			switch {
+			case i >= len(row) || strings.TrimSpace(row[i]) == "":
+				wrong = append(wrong, fmt.Sprintf("column %d should be %s", i+1, name))
+			case strings.TrimSpace(row[i]) != name:
+				wrong = append(wrong, fmt.Sprintf("column %d should be %s, not %q", i+1, name, row[i]))
+			}
+		}
+		return strings.Join(wrong, "; ")
+	}
+	if cleanText(strings.Join(row, "")) == "" {
+		return "Blank, skipped"
+	}
+	if _, ok := d.Layout.question(number, row); !ok {
+		if d.Layout.Named {
+			return "Skipped: no kana or romaji, or no answer"
+		}
+		return fmt.Sprintf("Skipped: %d of %d required cells", len(row), len(requiredColumns))
+	}
+	return ""
+}
+
+// deckTroubleshooting shows why no questions were loaded from a deck: the notes on the
+// workbook, then its first rows with what is wrong with each
+func deckTroubleshooting(name string, loadErr error, d deckDiagnosis) fyne.CanvasObject {
+	title := widget.NewLabelWithStyle("No questions could be loaded from "+name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	notes := container.NewVBox(title)
+	if loadErr != nil {
+		notes.Add(wrappedLabel("Error: " + loadErr.Error()))
+	}
+	for _, note := range d.Notes {
+		notes.Add(wrappedLabel("• " + note))
+	}
+	if len(d.Sheets) > 0 {
+		notes.Add(wrappedLabel(fmt.Sprintf("Sheets: %s. Showing %s: %d rows below the header, %d with every required column.",
+			strings.Join(d.Sheets, ", "), d.Sheet, d.Total, d.Complete)))
+	}
+	if len(d.Rows) == 0 {
+		return notes
+	}
+
+	// One column per cell of the widest row shown, after the row number and before the notes
+	width := len(requiredColumns)
+	for _, row := range d.Rows {
+		width = max(width, len(row))
+	}
+	grid := container.NewGridWithColumns(width + 2)
+	grid.Add(widget.NewLabelWithStyle("Row", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for i := 0; i < width; i++ {
+		expected := ""
+		if !d.Layout.Named && i < len(requiredColumns) {
+			expected = requiredColumns[i]
+		}
+		for field, column := range d.Layout.Columns {
+			if d.Layout.Named && column == i {
+				expected = columnLabels[field]
+			}
+		}
+		grid.Add(widget.NewLabelWithStyle(expected, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	}
+	grid.Add(widget.NewLabelWithStyle("Problem", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for i, row := range d.Rows {
+		grid.Add(widget.NewLabel(fmt.Sprint(i + 1)))
+		for j := 0; j < width; j++ {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			grid.Add(widget.NewLabel(cell))
+		}
+		note := d.RowNotes[i]
+		if note == "" {
+			note = "OK"
+		}
+		grid.Add(wrappedLabel(note))
+	}
+	return container.NewBorder(notes, widget.NewLabel("The bold row shows the column each cell is read as. Press F1 for the deck format."), nil, nil,
+		container.NewScroll(grid))
+}
+
+// wrappedLabel is a label that wraps its text at word boundaries
+func wrappedLabel(text string) *widget.Label {
+	label := widget.NewLabel(text)
+	label.Wrapping = fyne.TextWrapWord
+	return label
+}
+
+// showDeckTroubleshooting shows why an opened deck gave no questions
+func (qa *quizApp) showDeckTroubleshooting(name string, loadErr error, d deckDiagnosis) {
+	box := dialog.NewCustom("Deck Troubleshooting", "Close", deckTroubleshooting(name, loadErr, d), qa.window)
+	box.Resize(fyne.NewSize(900, 560))
+	box.Show()
+}
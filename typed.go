@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// typedDrill asks questions that are answered by typing instead of picking an option
+type typedDrill struct {
+	qa        *quizApp
+	name      string
+	questions []Question
+	index     int
+	graded    bool    // The current question was graded, so it counts even if the drill ends before the next
+	score     float64 // Near misses may earn half a point
+
+	english   func(Question) bool // Whether a question is answered in English, so typed romaji is not previewed as kana; nil for none
+	timeLimit time.Duration       // Time to answer each question, 0 for no limit
+
+	prompt func(Question) string                         // What the learner sees
+	answer func(Question) string                         // The right answer, for the mistake notebook
+	check  func(q Question, input string) (bool, string) // Grades an answer and explains mistakes
+
+	closeness func(q Question, input string) float64 // Similarity of an answer to the right one from 0 to 1, nil for no near misses
+
+	onFinish func(score float64, answered int) // Called when the results are shown, nil for nothing
+}
+
+// startTypedDrill shows the first question of a typed drill
+func (qa *quizApp) startTypedDrill(d *typedDrill) {
+	if len(d.questions) == 0 {
+		return
+	}
+	d.qa = qa
+	d.showQuestion()
+}
+
+// showQuestion shows the current question with an answer field
+func (d *typedDrill) showQuestion() {
+	if d.index >= len(d.questions) {
+		d.showResults()
+		return
+	}
+	q := d.questions[d.index]
+
+	promptText := canvas.NewText(d.prompt(q), theme.ForegroundColor())
+	d.qa.japaneseText(promptText, questionTextSize)
+	promptText.TextStyle = fyne.TextStyle{Bold: true}
+	promptText.Alignment = fyne.TextAlignCenter
+
+	feedback := widget.NewLabel("")
+	feedback.Wrapping = fyne.TextWrapWord
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("Type in kana or romaji, then press Enter")
+	preview := widget.NewLabel("")
+	entry.OnChanged = func(text string) {
+		preview.SetText(inputPreview(text))
+	}
+	if d.english != nil && d.english(q) {
+		entry.SetPlaceHolder("Type the answer, then press Enter")
+		entry.OnChanged = nil
+	}
+
+	answered, retried := false, false
+	var checkButton *widget.Button
+	countdown := widget.NewLabel("")
+	stopCountdown := func() {}
+	grade := func(input string, timedOut bool) {
+		correct, explanation := d.check(q, input)
+		near := !correct && !timedOut && d.nearMiss(q, input)
+		switch {
+		case timedOut:
+			correct = false
+			feedback.SetText("⏰ Time is up! The answer is " + d.answer(q) + ".")
+		case correct:
+			d.score++
+			feedback.SetText("✅ Correct! " + explanation)
+		case near && !d.qa.settings.NearMiss.PartialCredit && !retried:
+			// One more try, without giving the answer away
+			retried = true
+			feedback.SetText("🟡 Close — check your spelling and try again.")
+			return
+		case near && d.qa.settings.NearMiss.PartialCredit:
+			d.score += 0.5
+			feedback.SetText("🟡 Close — half a point. " + explanation)
+		default:
+			feedback.SetText("❌ " + explanation)
+		}
+		if hint := loanwordHint(q); hint != "" {
+			feedback.SetText(feedback.Text + "\n" + hint)
+		}
+		answered = true
+		d.graded = true
+		stopCountdown()
+		d.qa.answered(q, correct)
+		if !correct {
+			d.qa.progress.recordMistake(mistake{QID: q.QID, Question: d.prompt(q), Picked: strings.TrimSpace(input), Answer: d.answer(q)})
+		}
+		if err := d.qa.progress.save(); err != nil {
+			log.Printf("Failed to save progress: %v", err)
+		}
+		d.qa.progressChanged()
+		checkButton.SetText("Next")
+	}
+	submit := func(input string) {
+		if answered {
+			d.index++
+			d.graded = false
+			d.showQuestion()
+			return
+		}
+		if strings.TrimSpace(input) == "" {
+			return
+		}
+		grade(input, false)
+	}
+	// The countdown may grade the question meanwhile
+	entry.OnSubmitted = func(input string) {
+		d.qa.serialized(func() {
+			submit(input)
+		})()
+	}
+	checkButton = widget.NewButton("Check", d.qa.serialized(func() {
+		submit(entry.Text)
+	}))
+	checkButton.Importance = widget.HighImportance
+
+	screen := container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("%s — Question %d/%d — Score: %g", d.name, d.index+1, len(d.questions), d.score),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		container.NewGridWithColumns(2,
+			checkButton,
+			widget.NewButton("End Drill", d.qa.serialized(func() {
+				stopCountdown()
+				d.showResults()
+			})),
+		),
+		nil, nil,
+		container.NewVBox(
+			layoutSpacer(),
+			promptText,
+			countdown,
+			entry,
+			preview,
+			feedback,
+		),
+	)
+	d.qa.showScreen(screen)
+	d.qa.window.Canvas().Focus(entry)
+	if d.timeLimit > 0 {
+		session := d.qa.router.currentSession()
+		stopCountdown = d.startCountdown(countdown, d.qa.serialized(func() {
+			// The learner may have left the drill from the menu in the meantime
+			if !answered && session.active() {
+				grade(entry.Text, true)
+			}
+		}))
+		session.onEnd(stopCountdown)
+	}
+}
+
+// startCountdown shows the time left for the current question and calls expire when it
+// runs out. The returned func stops it.
+func (d *typedDrill) startCountdown(label *widget.Label, expire func()) func() {
+	deadline := time.Now().Add(d.timeLimit)
+	label.SetText(fmt.Sprintf("⏳ %d s", int(d.timeLimit/time.Second)))
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				left := deadline.Sub(now).Round(time.Second)
+				if left <= 0 {
+					label.SetText("⏳ 0 s")
+					expire()
+					return
+				}
+				label.SetText(fmt.Sprintf("⏳ %d s", int(left/time.Second)))
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// showResults shows the score of the drill
+func (d *typedDrill) showResults() {
+	answered := d.index
+	if d.graded {
+		answered++
+	}
+	answered = min(answered, len(d.questions))
+	if answered == len(d.questions) {
+		d.qa.earnPerfectXP(d.score, answered)
+	}
+	if d.onFinish != nil {
+		d.onFinish(d.score, answered)
+	}
+	d.qa.showScreen(container.NewCenter(container.NewVBox(
+		widget.NewLabelWithStyle(d.name+" Complete", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(fmt.Sprintf("Your score: %g/%d", d.score, answered)),
+		widget.NewButton("Return to Chapter Selection", d.qa.serialized(func() {
+			d.qa.showChapterSelection()
+		})),
+	)))
+}
+
+// typedQuizSize is the number of questions in a typed quiz
+const typedQuizSize = 10
+
+// checkReading grades the Japanese typed for an English prompt. Kana follows the learner's
+// kana rules, and the romaji of the deck is accepted ignoring case and the spaces around it.
+func (qa *quizApp) checkReading(q Question, input string) (bool, string) {
+	answer := fmt.Sprintf("%s is %s (%s).", q.QAnswer, q.QHirakata, q.QRomaji)
+	typed := strings.TrimSpace(input)
+	switch {
+	case q.QRomaji != "" && strings.EqualFold(typed, q.QRomaji),
+		q.QKanji != "" && typed == q.QKanji,
+		qa.settings.KanaRules.matchesKana(q, input):
+		return true, answer
+	}
+	return false, answer
+}
+
+// startTypedQuiz quizzes the current chapter with the answers typed instead of picked,
+// in the direction of the Reverse setting
+func (qa *quizApp) startTypedQuiz() {
+	questions := append([]Question(nil), qa.newItemsPool(qa.state.chapterQuestions)...)
+	if len(questions) == 0 && len(qa.state.chapterQuestions) > 0 {
+		qa.showNewItemsLimit()
+		return
+	}
+	rand.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+	if len(questions) > typedQuizSize {
+		questions = questions[:typedQuizSize]
+	}
+
+	if qa.settings.Reverse {
+		qa.startTypedDrill(&typedDrill{
+			name:      "Typed Quiz",
+			questions: questions,
+			prompt: func(q Question) string {
+				return q.QAnswer
+			},
+			answer: func(q Question) string {
+				return q.QHirakata
+			},
+			check: qa.checkReading,
+			closeness: func(q Question, input string) float64 {
+				return kanaSimilarity(q.QHirakata, typedKana(input))
+			},
+		})
+		return
+	}
+	qa.startTypedDrill(&typedDrill{
+		name:      "Typed Quiz",
+		questions: questions,
+		english: func(Question) bool {
+			return true
+		},
+		prompt: func(q Question) string {
+			return q.QHirakata
+		},
+		answer: func(q Question) string {
+			return q.QAnswer
+		},
+		check: qa.checkMeaning,
+		closeness: func(q Question, input string) float64 {
+			return qa.settings.AnswerRules.meaningSimilarity(input, q.QAnswer)
+		},
+	})
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// updateKey is the base64 Ed25519 public key release binaries are signed with.
+// It is set at build time with -ldflags "-X main.updateKey=..."; without it updates
+// can only be downloaded by hand.
+var updateKey = ""
+
+// maxUpdateSize limits how much an update download may be
+const maxUpdateSize = 200 << 20
+
+// updateDownloadTimeout limits how long downloading an update may take
+const updateDownloadTimeout = 5 * time.Minute
+
+// updateAssetName is the release file for this platform, e.g. GenkiQuiz-windows-amd64.exe.
+// Its signature is published beside it with a .sig suffix.
+func updateAssetName() string {
+	name := fmt.Sprintf("GenkiQuiz-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// updateAssets finds the binary and signature for this platform in a release
+func updateAssets(r release) (binary, signature releaseAsset, ok bool) {
+	name := updateAssetName()
+	var foundBinary, foundSignature bool
+	for _, asset := range r.Assets {
+		switch asset.Name {
+		case name:
+			binary, foundBinary = asset, true
+		case name + ".sig":
+			signature, foundSignature = asset, true
+		}
+	}
+	return binary, signature, foundBinary && foundSignature
+}
+
+// canSelfUpdate reports whether a release can be installed by the updater
+func canSelfUpdate(r release) bool {
+	_, _, ok := updateAssets(r)
+	return ok && updateKey != ""
+}
+
+// download fetches a release file, refusing anything larger than maxUpdateSize
+func download(url string) ([]byte, error) {
+	client := &http.Client{Timeout: updateDownloadTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxUpdateSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxUpdateSize {
+		return nil, errors.New("the update is too large")
+	}
+	return data, nil
+}
+
+// signedUpdate is what the signature of a release covers: its tag, a newline and the
+// binary, so a signed binary cannot be passed off as another release
+func signedUpdate(tag string, binary []byte) []byte {
+	return append([]byte(tag+"\n"), binary...)
+}
+
+// verifyUpdate checks the Ed25519 signature of a downloaded binary of the release tag.
+// The signature file holds the base64 signature of signedUpdate.
+func verifyUpdate(tag string, binary, signature []byte) error {
+	key, err := base64.StdEncoding.DecodeString(updateKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New("this build has no valid update signing key")
+	}
+	sig, err := base64.StdEncoding.DecodeString(string(signature))
+	if err != nil {
+		return fmt.Errorf("invalid update signature: %w", err)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), signedUpdate(tag, binary), sig) {
+		return errors.New("the update's signature does not match; it was not installed")
+	}
+	return nil
+}
+
+// oldBinaryPath is where the replaced binary is kept until the next start
+func oldBinaryPath(exe string) string {
+	return exe + ".old"
+}
+
+// installUpdate downloads, verifies and swaps in the release binary for this platform.
+// The running binary is renamed out of the way, which also works on Windows.
+func installUpdate(r release) (string, error) {
+	binaryAsset, signatureAsset, ok := updateAssets(r)
+	if !ok {
+		return "", fmt.Errorf("release %s has no signed build for %s/%s", r.TagName, runtime.GOOS, runtime.GOARCH)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return "", err
+	}
+
+	binary, err := download(binaryAsset.DownloadURL)
+	if err != nil {
+		return "", err
+	}
+	signature, err := download(signatureAsset.DownloadURL)
+	if err != nil {
+		return "", err
+	}
+	if err := verifyUpdate(r.TagName, binary, signature); err != nil {
+		return "", err
+	}
+
+	// Write beside the binary so the final rename stays on one file system
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".GenkiQuiz-update-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return "", err
+	}
+
+	old := oldBinaryPath(exe)
+	os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		// Put the working binary back
+		os.Rename(old, exe)
+		return "", err
+	}
+	return exe, nil
+}
+
+// removeOldBinary deletes the binary left behind by the last update
+func removeOldBinary() {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return
+	}
+	if err := os.Remove(oldBinaryPath(exe)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to remove the previous version: %v", err)
+	}
+}
+
+// updateNow installs a release and offers to restart into it
+func (qa *quizApp) updateNow(r release) {
+	progress := dialog.NewCustomWithoutButtons("Updating", widget.NewProgressBarInfinite(), qa.window)
+	progress.Show()
+	go func() {
+		exe, err := installUpdate(r)
+		qa.serialized(func() {
+			progress.Hide()
+			if err != nil {
+				dialog.ShowError(err, qa.window)
+				return
+			}
+			dialog.ShowConfirm("Update Installed",
+				fmt.Sprintf("Genki Quiz %s was installed. Restart now?", r.TagName),
+				func(restart bool) {
+					if !restart {
+						return
+					}
+					cmd := exec.Command(exe, os.Args[1:]...)
+					cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+					if err := cmd.Start(); err != nil {
+						dialog.ShowError(err, qa.window)
+						return
+					}
+					qa.app.Quit()
+				}, qa.window)
+		})()
+	}()
+}
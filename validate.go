@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// requiredColumns are the columns every deck needs, in this order
+var requiredColumns = deckColumns[:6]
+
+// mediaColumns are optional columns that refer to files next to the deck
+var mediaColumns = []string{"QAudio", "QImage"}
+
+// genkiChapters is the number of chapters in Genki I and II
+const genkiChapters = 23
+
+// deckIssue is one problem found in a deck
+type deckIssue struct {
+	Row      int    `json:"row,omitempty"` // Spreadsheet row, 1 being the header
+	Column   string `json:"column,omitempty"`
+	Severity string `json:"severity"` // "error" or "warning"
+	Message  string `json:"message"`
+}
+
+// deckReport is the result of validating a deck
+type deckReport struct {
+	File      string      `json:"file"`
+	Questions int         `json:"questions"`
+	Errors    int         `json:"errors"`
+	Warnings  int         `json:"warnings"`
+	Issues    []deckIssue `json:"issues"`
+}
+
+// add records an issue and counts it
+func (r *deckReport) add(row int, column, severity, format string, args ...any) {
+	r.Issues = append(r.Issues, deckIssue{
+		Row:      row,
+		Column:   column,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+	if severity == "error" {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// validateDeck checks a question sheet for problems that would break or confuse a quiz
+func validateDeck(path string) deckReport {
+	report := deckReport{File: path, Issues: []deckIssue{}}
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		report.add(0, "", "error", "cannot open deck: %v", err)
+		return report
+	}
+	defer f.Close()
+
+	layout, err := sheetLayout(f, questionSheet, nil)
+	if errors.Is(err, errEmptySheet) {
+		report.add(0, "", "error", "the sheet is empty")
+		return report
+	}
+	if err != nil {
+		report.add(0, "", "error", "cannot read Sheet1: %v", err)
+		return report
+	}
+	columns := make(map[string]int)
+	for field, i := range layout.Columns {
+		columns[field] = i
+	}
+	for i, name := range layout.Header {
+		if slices.Contains(mediaColumns, strings.TrimSpace(name)) {
+			columns[strings.TrimSpace(name)] = i
+		}
+	}
+	cell := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return cleanText(row[i])
+		}
+		return ""
+	}
+
+	firstRow := make(map[string]int)
+	requires := make(map[string][]string) // QID → prerequisites, checked once all IDs are known
+	requiresRow := make(map[string]int)
+	missing := false
+	err = eachRow(f, questionSheet, func(rowNumber int, row []string) {
+		if rowNumber < layout.HeaderRow {
+			return // Above the header, e.g. a title
+		}
+		if rowNumber == layout.HeaderRow {
+			missing = checkHeader(&report, layout, row)
+			return
+		}
+		if missing {
+			return
+		}
+		if cleanText(strings.Join(row, "")) == "" {
+			return // Blank rows are ignored when loading
+		}
+		report.Questions++
+
+		needed := requiredColumns
+		if layout.Named {
+			needed = neededColumns // The others are filled in when loading
+		}
+		for _, name := range needed {
+			if name == "QHirakata" && cell(row, "QRomaji") != "" {
+				continue // Made from the romaji
+			}
+			if name != "QType" && cell(row, name) == "" {
+				report.add(rowNumber, name, "error", "empty %s", name)
+			}
+		}
+		if q, ok := layout.question(rowNumber, row); ok && q.KanaCheck != "" {
+			report.add(rowNumber, "QHirakata", "warning", "kana %s made from the romaji: %s", q.QHirakata, q.KanaCheck)
+		}
+		if !layout.Named && len(row) < len(requiredColumns) {
+			report.add(rowNumber, "", "error", "row has %d of %d required cells and will be skipped", len(row), len(requiredColumns))
+		}
+
+		if id := cell(row, "QID"); id != "" {
+			if first, ok := firstRow[id]; ok {
+				report.add(rowNumber, "QID", "error", "duplicate ID %s, first used in row %d", id, first)
+			} else {
+				firstRow[id] = rowNumber
+			}
+		}
+		if id, prerequisites := cell(row, "QID"), requiredQIDs(Question{QRequires: cell(row, "QRequires")}); id != "" && len(prerequisites) > 0 {
+			requires[id] = prerequisites
+			requiresRow[id] = rowNumber
+		}
+		if chapter := cell(row, "QChapter"); chapter != "" {
+			if n, err := strconv.Atoi(chapter); err != nil || n < 1 || n > genkiChapters {
+				report.add(rowNumber, "QChapter", "warning", "unknown chapter %q", chapter)
+			}
+		}
+		for _, name := range mediaColumns {
+			ref := cell(row, name)
+			if ref == "" {
+				continue
+			}
+			media := ref
+			if !filepath.IsAbs(media) {
+				media = filepath.Join(filepath.Dir(path), media)
+			}
+			if _, err := os.Stat(media); err != nil {
+				report.add(rowNumber, name, "error", "missing file %s", ref)
+			}
+		}
+	})
+	if err != nil {
+		report.add(0, "", "error", "cannot read Sheet1: %v", err)
+		return report
+	}
+	if missing {
+		return report
+	}
+	if report.Questions == 0 {
+		report.add(0, "", "error", "the deck has no questions")
+	}
+	checkPrerequisites(&report, requires, requiresRow, firstRow)
+	return report
+}
+
+// checkHeader reports columns the header of a deck lacks or leaves open, and whether the
+// questions cannot be read at all
+func checkHeader(report *deckReport, layout deckLayout, header []string) bool {
+	missing := false
+	if !layout.Named {
+		columns := make(map[string]int)
+		for i, name := range header {
+			columns[strings.TrimSpace(name)] = i
+		}
+		for i, name := range requiredColumns {
+			if at, ok := columns[name]; !ok {
+				report.add(layout.HeaderRow, name, "error", "missing column %s", name)
+				missing = true
+			} else if at != i {
+				report.add(layout.HeaderRow, name, "error", "column %s must be column %d", name, i+1)
+				missing = true
+			}
+		}
+		return missing
+	}
+	for _, field := range deckColumns {
+		i, found := layout.Columns[field]
+		switch {
+		case slices.Contains(layout.missingColumns(), field):
+			report.add(layout.HeaderRow, field, "error", "no column is named like %s", columnLabels[field])
+			missing = true
+		case found && len(layout.Choices[field]) > 1:
+			report.add(layout.HeaderRow, field, "warning", "%d columns could hold %s, column %s is used", len(layout.Choices[field]), columnLabels[field], layout.columnName(i))
+		}
+	}
+	return missing
+}
+
+// checkPrerequisites reports QRequires entries naming unknown IDs, and items that
+// can never be unlocked because they require themselves, directly or in a cycle
+func checkPrerequisites(report *deckReport, requires map[string][]string, rows map[string]int, known map[string]int) {
+	var ids []string
+	for id := range requires {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return rows[ids[i]] < rows[ids[j]] })
+
+	for _, id := range ids {
+		for _, prerequisite := range requires[id] {
+			if _, ok := known[prerequisite]; !ok {
+				report.add(rows[id], "QRequires", "error", "unknown prerequisite ID %s", prerequisite)
+			}
+		}
+	}
+
+	// An item is in a cycle if it can be reached from its own prerequisites
+	reaches := func(from, target string) bool {
+		seen := make(map[string]bool)
+		stack := []string{from}
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if id == target {
+				return true
+			}
+			if !seen[id] {
+				seen[id] = true
+				stack = append(stack, requires[id]...)
+			}
+		}
+		return false
+	}
+	for _, id := range ids {
+		for _, prerequisite := range requires[id] {
+			if reaches(prerequisite, id) {
+				report.add(rows[id], "QRequires", "error", "ID %s requires itself through its prerequisites and can never be unlocked", id)
+				break
+			}
+		}
+	}
+}
+
+// runValidate implements "GenkiQuiz validate deck.xlsx…", printing a JSON report.
+// It returns the exit code: 1 if any deck has errors, 2 for usage errors.
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	strict := flags.Bool("strict", false, "treat warnings as errors")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: GenkiQuiz validate [-strict] deck.xlsx...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	code := 0
+	var reports []deckReport
+	for _, path := range flags.Args() {
+		report := validateDeck(path)
+		if report.Errors > 0 || *strict && report.Warnings > 0 {
+			code = 1
+		}
+		reports = append(reports, report)
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(reports); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	return code
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// versusRounds is the number of questions in a versus game
+const versusRounds = 10
+
+// versusRoundTime is how long players have to answer before the round ends
+const versusRoundTime = 10 * time.Second
+
+// versusKeys are the answer keys of each player: the left and right halves of the number row
+var versusKeys = [2][]rune{
+	{'1', '2', '3', '4'},
+	{'7', '8', '9', '0'},
+}
+
+// versusPlayer tracks one side of a versus game
+type versusPlayer struct {
+	name      string
+	correct   int
+	totalTime time.Duration // Summed response time of correct answers
+	answered  bool          // Whether the player answered the current round
+	buttons   []*widget.Button
+	status    *widget.Label
+}
+
+// averageTime returns the mean response time of correct answers
+func (p *versusPlayer) averageTime() time.Duration {
+	if p.correct == 0 {
+		return 0
+	}
+	return p.totalTime / time.Duration(p.correct)
+}
+
+// versusGame is a split-screen race where two players answer the same questions
+type versusGame struct {
+	qa        *quizApp
+	questions []Question
+	round     int
+	players   [2]*versusPlayer
+	options   []string
+	correct   string // Correct answer of the current round
+	shownAt   time.Time
+	roundOver bool
+	timer     *time.Timer
+}
+
+// showVersusMode starts a two-player game on the selected chapter
+func (qa *quizApp) showVersusMode() {
+	if len(qa.state.chapterQuestions) == 0 {
+		return
+	}
+	questions := make([]Question, len(qa.state.chapterQuestions))
+	copy(questions, qa.state.chapterQuestions)
+	rand.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+	if len(questions) > versusRounds {
+		questions = questions[:versusRounds]
+	}
+
+	game := &versusGame{
+		qa:        qa,
+		questions: questions,
+		players: [2]*versusPlayer{
+			{name: "Player 1"},
+			{name: "Player 2"},
+		},
+	}
+	game.showRound()
+}
+
+// typedRune maps the number row to each player's answers. It holds the UI lock, as the round timer may end the round meanwhile.
+func (g *versusGame) typedRune(r rune) {
+	g.qa.ui.Lock()
+	defer g.qa.ui.Unlock()
+	for p, keys := range versusKeys {
+		for i, key := range keys {
+			if r == key && i < len(g.options) {
+				g.answer(p, i)
+			}
+		}
+	}
+}
+
+// end leaves versus mode
+func (g *versusGame) end() {
+	if g.timer != nil {
+		g.timer.Stop()
+	}
+	g.qa.showChapterSelection()
+}
+
+// pane builds one player's half of the screen
+func (g *versusGame) pane(p int, q Question) fyne.CanvasObject {
+	player := g.players[p]
+	player.answered = false
+	player.buttons = nil
+	player.status = widget.NewLabel("")
+
+	questionText := canvas.NewText(q.QHirakata, theme.ForegroundColor())
+	g.qa.japaneseText(questionText, questionTextSize)
+	questionText.TextStyle = fyne.TextStyle{Bold: true}
+
+	options := container.NewVBox()
+	for i, opt := range g.options {
+		i := i
+		button := widget.NewButton(fmt.Sprintf("%c. %s", versusKeys[p][i], opt), g.qa.serialized(func() {
+			g.answer(p, i)
+		}))
+		player.buttons = append(player.buttons, button)
+		options.Add(button)
+	}
+
+	return container.NewVBox(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("%s — %d correct", player.name, player.correct),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{Bold: true},
+		),
+		container.NewCenter(questionText),
+		options,
+		player.status,
+	)
+}
+
+// showRound shows the next question to both players
+func (g *versusGame) showRound() {
+	if g.round >= len(g.questions) {
+		g.showResults()
+		return
+	}
+	q := g.questions[g.round]
+	g.correct = q.QAnswer
+	g.options = append(g.qa.state.answerPool().random(q.QAnswer, 3), q.QAnswer)
+	rand.Shuffle(len(g.options), func(i, j int) {
+		g.options[i], g.options[j] = g.options[j], g.options[i]
+	})
+	g.roundOver = false
+
+	g.qa.showScreen(container.NewBorder(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("Round %d/%d — Player 1 keys 1-4, Player 2 keys 7-0", g.round+1, len(g.questions)),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{},
+		),
+		widget.NewButton("End Game", g.qa.serialized(g.end)),
+		nil, nil,
+		container.NewGridWithColumns(2, g.pane(0, q), g.pane(1, q)),
+	))
+	// The keys answer only while the round is shown, however it is left
+	g.qa.window.Canvas().SetOnTypedRune(g.typedRune)
+	g.qa.router.currentSession().onEnd(func() {
+		g.qa.window.Canvas().SetOnTypedRune(nil)
+	})
+
+	g.shownAt = time.Now()
+	g.timer = g.qa.afterFunc(versusRoundTime, g.finishRound)
+}
+
+// answer records a player's choice for the current round
+func (g *versusGame) answer(p, option int) {
+	player := g.players[p]
+	if g.roundOver || player.answered {
+		return
+	}
+	player.answered = true
+	elapsed := time.Since(g.shownAt)
+
+	button := player.buttons[option]
+	if g.options[option] == g.correct {
+		player.correct++
+		player.totalTime += elapsed
+		button.SetText("✅ " + button.Text)
+		player.status.SetText(fmt.Sprintf("Correct in %.1fs", elapsed.Seconds()))
+	} else {
+		button.SetText("❌ " + button.Text)
+		player.status.SetText("Wrong")
+	}
+
+	if g.players[0].answered && g.players[1].answered {
+		g.timer.Stop()
+		g.finishRound()
+	}
+}
+
+// finishRound reveals the answer on both sides and moves on after a short delay
+func (g *versusGame) finishRound() {
+	if g.roundOver {
+		return
+	}
+	g.roundOver = true
+
+	for _, player := range g.players {
+		if !player.answered {
+			player.status.SetText("Time's up")
+		}
+		for i, button := range player.buttons {
+			if g.options[i] == g.correct && !strings.HasPrefix(button.Text, "✅") {
+				button.SetText("✅ " + button.Text)
+			}
+			button.OnTapped = nil
+		}
+	}
+
+	g.round++
+	g.timer = g.qa.afterFunc(2*time.Second, g.showRound)
+}
+
+// showResults shows accuracy and speed of both players and the winner
+func (g *versusGame) showResults() {
+	p1, p2 := g.players[0], g.players[1]
+	winner := "It's a tie!"
+	switch {
+	case p1.correct > p2.correct:
+		winner = p1.name + " wins!"
+	case p2.correct > p1.correct:
+		winner = p2.name + " wins!"
+	case p1.correct > 0 && p1.averageTime() < p2.averageTime():
+		winner = p1.name + " wins on speed!"
+	case p2.correct > 0 && p2.averageTime() < p1.averageTime():
+		winner = p2.name + " wins on speed!"
+	}
+
+	results := container.NewVBox(widget.NewLabelWithStyle(winner, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))
+	for _, player := range g.players {
+		results.Add(widget.NewLabel(fmt.Sprintf("%s: %d/%d correct (%.0f%%), average %.1fs per correct answer",
+			player.name,
+			player.correct,
+			len(g.questions),
+			float64(player.correct)/float64(len(g.questions))*100,
+			player.averageTime().Seconds(),
+		)))
+	}
+	results.Add(widget.NewButton("Return to Chapter Selection", g.qa.serialized(func() {
+		g.qa.showChapterSelection()
+	})))
+	g.qa.showScreen(container.NewCenter(results))
+}
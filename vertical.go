@@ -0,0 +1,146 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+)
+
+// verticalRows is how many characters a column of vertical text holds before the next column starts
+const verticalRows = 8
+
+// verticalForms replaces characters that are written differently in vertical text
+var verticalForms = map[rune]string{
+	'ー': "丨",
+	'〜': "≀",
+	'、': "︑",
+	'。': "︒",
+	'（': "︵",
+	'）': "︶",
+	'「': "﹁",
+	'」': "﹂",
+	'…': "︙",
+}
+
+// verticalText draws Japanese text top to bottom in columns running right to left (tategaki).
+// It takes its text, size, colour and font from a canvas.Text, so it can stand in for one.
+type verticalText struct {
+	widget.BaseWidget
+	source *canvas.Text
+}
+
+// newVerticalText shows the text of source vertically
+func newVerticalText(source *canvas.Text) *verticalText {
+	v := &verticalText{source: source}
+	v.ExtendBaseWidget(v)
+	return v
+}
+
+// CreateRenderer builds one text object per character
+func (v *verticalText) CreateRenderer() fyne.WidgetRenderer {
+	r := &verticalTextRenderer{v: v}
+	r.Refresh()
+	return r
+}
+
+// verticalTextRenderer lays out the characters of a verticalText in cells
+type verticalTextRenderer struct {
+	v     *verticalText
+	chars []*canvas.Text
+	small []bool // Small kana sit in the upper right of their cell
+}
+
+// cellSize is the square each character takes up
+func (r *verticalTextRenderer) cellSize() float32 {
+	return r.v.source.TextSize * 1.15
+}
+
+// columns returns the number of columns the text needs
+func (r *verticalTextRenderer) columns() int {
+	return max(1, (len(r.chars)+verticalRows-1)/verticalRows)
+}
+
+// Layout places the characters, the first column on the right
+func (r *verticalTextRenderer) Layout(size fyne.Size) {
+	cell := r.cellSize()
+	columns := r.columns()
+	left := (size.Width - float32(columns)*cell) / 2
+	for i, char := range r.chars {
+		column, row := i/verticalRows, i%verticalRows
+		pos := fyne.NewPos(left+float32(columns-1-column)*cell, float32(row)*cell)
+		if r.small[i] {
+			pos = pos.Add(fyne.NewPos(cell*0.15, -cell*0.15))
+		}
+		char.Move(pos)
+		char.Resize(fyne.NewSize(cell, cell))
+	}
+}
+
+// MinSize fits every column, as tall as the longest one
+func (r *verticalTextRenderer) MinSize() fyne.Size {
+	cell := r.cellSize()
+	rows := min(len(r.chars), verticalRows)
+	return fyne.NewSize(float32(r.columns())*cell, float32(max(rows, 1))*cell)
+}
+
+// Refresh rebuilds the characters from the source text
+func (r *verticalTextRenderer) Refresh() {
+	source := r.v.source
+	r.chars = r.chars[:0]
+	r.small = r.small[:0]
+	for _, c := range source.Text {
+		text := string(c)
+		if form, ok := verticalForms[c]; ok {
+			text = form
+		}
+		char := canvas.NewText(text, source.Color)
+		char.TextSize = source.TextSize
+		char.TextStyle = source.TextStyle
+		char.FontSource = source.FontSource
+		char.Alignment = fyne.TextAlignCenter
+		r.chars = append(r.chars, char)
+		r.small = append(r.small, strings.ContainsRune(smallKana+"っッ", c))
+	}
+	r.Layout(r.v.Size())
+	canvas.Refresh(r.v)
+}
+
+// Objects returns the characters
+func (r *verticalTextRenderer) Objects() []fyne.CanvasObject {
+	objects := make([]fyne.CanvasObject, len(r.chars))
+	for i, char := range r.chars {
+		objects[i] = char
+	}
+	return objects
+}
+
+// Destroy has nothing to release
+func (r *verticalTextRenderer) Destroy() {}
+
+// setVerticalText switches the quiz question between vertical and horizontal text and remembers the choice
+func (qa *quizApp) setVerticalText(vertical bool) {
+	qa.settings.VerticalText = vertical
+	if err := qa.settings.save(); err != nil {
+		log.Printf("Failed to save settings: %v", err)
+	}
+	qa.updateVerticalText()
+}
+
+// updateVerticalText applies the vertical text setting to the quiz screen
+func (qa *quizApp) updateVerticalText() {
+	if qa.settings.VerticalText {
+		qa.questionLabel.Hide()
+		qa.verticalQuestion.Show()
+	} else {
+		qa.verticalQuestion.Hide()
+		qa.questionLabel.Show()
+	}
+	qa.verticalQuestion.Refresh()
+	if qa.verticalMenuItem != nil {
+		qa.verticalMenuItem.Checked = qa.settings.VerticalText
+		qa.window.MainMenu().Refresh()
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// wordOfTheDay picks one not-yet-mastered question for the day, the same one all day long.
+// Each candidate is ranked by a hash of the date and its QID and the lowest wins, so
+// mastering other items or loading the deck in another order does not change the word.
+func wordOfTheDay(questions []Question, progress *Progress, day time.Time) (Question, bool) {
+	var word Question
+	var best uint64
+	found := false
+	for _, q := range questions {
+		if progress.stats(q.QID).mastered() {
+			continue
+		}
+		h := fnv.New64a()
+		h.Write([]byte("word-of-the-day " + day.Format(dateLayout) + " " + q.QID))
+		if rank := h.Sum64(); !found || rank < best {
+			word, best, found = q, rank, true
+		}
+	}
+	return word, found
+}
+
+// quizOnQuestion starts a one-question quiz on a single item
+func (qa *quizApp) quizOnQuestion(q Question) {
+	state := qa.state
+	state.currentChapter = q.QChapter
+	state.chapterQuestions = qa.index.inChapters([]string{q.QChapter})
+	state.quizQuestions = []Question{q}
+	state.totalQuestions = 1
+	state.quizName = "Word of the Day"
+	qa.startQuiz()
+}
+
+// wordOfTheDayPanel shows today's word on the home screen
+func (qa *quizApp) wordOfTheDayPanel() fyne.CanvasObject {
+	chapterQuestions := qa.studyChapters(qa.availableChapters())
+	q, ok := wordOfTheDay(chapterQuestions, qa.progress, time.Now())
+	if !ok {
+		return widget.NewLabel("Word of the Day: everything is mastered — well done!")
+	}
+
+	word := widget.NewLabelWithStyle(
+		fmt.Sprintf("%s (%s) — %s", q.QHirakata, q.QRomaji, q.QAnswer),
+		fyne.TextAlignCenter,
+		fyne.TextStyle{Bold: true},
+	)
+	panel := container.NewVBox(
+		widget.NewLabelWithStyle("Word of the Day", fyne.TextAlignCenter, fyne.TextStyle{Italic: true}),
+		word,
+	)
+	if q.QExample != "" {
+		panel.Add(widget.NewLabelWithStyle(q.QExample, fyne.TextAlignCenter, fyne.TextStyle{}))
+	}
+	quizButton := widget.NewButton("Quiz me on this", qa.serialized(func() {
+		qa.quizOnQuestion(q)
+	}))
+	quizButton.Importance = widget.LowImportance
+	panel.Add(container.NewCenter(quizButton))
+
+	return widget.NewCard("", "", panel)
+}
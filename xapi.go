@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// xapiSettings configures reporting completed quizzes to a learning record store
+type xapiSettings struct {
+	Enabled    bool   `json:"enabled"`
+	Endpoint   string `json:"endpoint"` // LRS base URL, statements are posted to <endpoint>/statements
+	Username   string `json:"username"` // Basic auth key
+	Password   string `json:"password"` // Basic auth secret
+	ActorName  string `json:"actor_name"`
+	ActorEmail string `json:"actor_email"`
+}
+
+// xapiActivityBase prefixes the activity IDs of reported quizzes
+const xapiActivityBase = "https://github.com/karlabo93/Genki-Quiz/quiz/"
+
+// xapiPassThreshold is the scaled score counted as a success
+const xapiPassThreshold = 0.7
+
+// xapiStatement is the subset of an xAPI statement used for quiz results
+type xapiStatement struct {
+	Actor     xapiActor    `json:"actor"`
+	Verb      xapiVerb     `json:"verb"`
+	Object    xapiActivity `json:"object"`
+	Result    xapiResult   `json:"result"`
+	Timestamp string       `json:"timestamp"`
+}
+
+// xapiActor identifies the learner by email
+type xapiActor struct {
+	ObjectType string `json:"objectType"`
+	Name       string `json:"name,omitempty"`
+	Mbox       string `json:"mbox"`
+}
+
+// xapiVerb is the action being reported
+type xapiVerb struct {
+	ID      string            `json:"id"`
+	Display map[string]string `json:"display"`
+}
+
+// xapiActivity identifies the quiz that was taken
+type xapiActivity struct {
+	ObjectType string                 `json:"objectType"`
+	ID         string                 `json:"id"`
+	Definition xapiActivityDefinition `json:"definition"`
+}
+
+// xapiActivityDefinition names and classifies an activity
+type xapiActivityDefinition struct {
+	Name map[string]string `json:"name"`
+	Type string            `json:"type"`
+}
+
+// xapiResult holds the outcome of the quiz
+type xapiResult struct {
+	Score      xapiScore `json:"score"`
+	Success    bool      `json:"success"`
+	Completion bool      `json:"completion"`
+}
+
+// xapiScore is the quiz score in xAPI form
+type xapiScore struct {
+	Raw    int     `json:"raw"`
+	Min    int     `json:"min"`
+	Max    int     `json:"max"`
+	Scaled float64 `json:"scaled"`
+}
+
+// newXAPIStatement builds a "completed" statement for a quiz result
+func newXAPIStatement(cfg xapiSettings, result quizResult) xapiStatement {
+	scaled := result.percent() / 100
+	return xapiStatement{
+		Actor: xapiActor{
+			ObjectType: "Agent",
+			Name:       cfg.ActorName,
+			Mbox:       "mailto:" + cfg.ActorEmail,
+		},
+		Verb: xapiVerb{
+			ID:      "http://adlnet.gov/expapi/verbs/completed",
+			Display: map[string]string{"en-US": "completed"},
+		},
+		Object: xapiActivity{
+			ObjectType: "Activity",
+			ID:         xapiActivityBase + url.PathEscape(result.Quiz),
+			Definition: xapiActivityDefinition{
+				Name: map[string]string{"en-US": fmt.Sprintf("Genki Quiz: %s", result.Quiz)},
+				Type: "http://adlnet.gov/expapi/activities/assessment",
+			},
+		},
+		Result: xapiResult{
+			Score: xapiScore{
+				Raw:    result.Score,
+				Min:    0,
+				Max:    result.Total,
+				Scaled: scaled,
+			},
+			Success:    scaled >= xapiPassThreshold,
+			Completion: true,
+		},
+		Timestamp: result.CompletedAt.Format(time.RFC3339),
+	}
+}
+
+// postXAPIStatement sends a statement to the configured LRS
+func postXAPIStatement(cfg xapiSettings, statement xapiStatement) error {
+	body, err := json.Marshal(statement)
+	if err != nil {
+		return err
+	}
+
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/") + "/statements"
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Experience-API-Version", "1.0.3")
+	if cfg.Username != "" {
+		req.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("LRS returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// reportResult posts a completed quiz to the LRS in the background, if enabled
+func (qa *quizApp) reportResult(result quizResult) {
+	cfg := qa.settings.XAPI
+	if !cfg.Enabled || cfg.Endpoint == "" || cfg.ActorEmail == "" {
+		return
+	}
+	go func() {
+		if err := postXAPIStatement(cfg, newXAPIStatement(cfg, result)); err != nil {
+			log.Printf("xAPI report failed: %v", err)
+		}
+	}()
+}
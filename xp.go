@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// XP earned for each activity
+const (
+	xpCorrect   = 10 // A right answer
+	xpWrong     = 2  // A wrong answer, for the effort
+	xpMastered  = 25 // An item mastered for the first time
+	xpPerfect   = 50 // A quiz or drill without a mistake
+	xpStreakDay = 5  // The first answer of a day, for each day in a row studied before it
+)
+
+// maxStreakBonusDays caps the days counted for the daily streak bonus
+const maxStreakBonusDays = 10
+
+// xpHistoryShown is how many days of XP the statistics list
+const xpHistoryShown = 14
+
+// answerXP returns the XP for an answer
+func answerXP(correct bool) int {
+	if correct {
+		return xpCorrect
+	}
+	return xpWrong
+}
+
+// earnXP adds XP to the total and to the day of now. The first XP of a day earns a bonus
+// for the days in a row studied before it.
+func (p *Progress) earnXP(points int, now time.Time) {
+	if p.XPByDay == nil {
+		p.XPByDay = make(map[string]int)
+	}
+	today := now.Format(dateLayout)
+	if p.XPByDay[today] == 0 {
+		points += xpStreakDay * min(p.xpStreak(now), maxStreakBonusDays)
+	}
+	p.XP += points
+	p.XPByDay[today] += points
+}
+
+// xpStreak counts the days in a row before the day of now on which XP was earned
+func (p *Progress) xpStreak(now time.Time) int {
+	days := 0
+	for day := now.AddDate(0, 0, -1); p.XPByDay[day.Format(dateLayout)] > 0; day = day.AddDate(0, 0, -1) {
+		days++
+	}
+	return days
+}
+
+// levelXP returns the total XP needed to reach a level: 0 for level 1, then 100, 300, 600…
+func levelXP(level int) int {
+	return 50 * level * (level - 1)
+}
+
+// xpLevel returns the level reached with an XP total
+func xpLevel(xp int) int {
+	level := 1
+	for levelXP(level+1) <= xp {
+		level++
+	}
+	return level
+}
+
+// earnPerfectXP rewards a finished quiz or drill without a mistake
+func (qa *quizApp) earnPerfectXP(score float64, total int) {
+	if total == 0 || score < float64(total) {
+		return
+	}
+	qa.progress.earnXP(xpPerfect, time.Now())
+	if err := qa.progress.save(); err != nil {
+		log.Printf("Failed to save progress: %v", err)
+	}
+	qa.progressChanged()
+}
+
+// levelPanel shows the learner's level and the XP still needed for the next one
+func (qa *quizApp) levelPanel() fyne.CanvasObject {
+	xp := qa.progress.XP
+	level := xpLevel(xp)
+	bar := widget.NewProgressBar()
+	bar.Min = float64(levelXP(level))
+	bar.Max = float64(levelXP(level + 1))
+	bar.SetValue(float64(xp))
+	bar.TextFormatter = func() string {
+		return fmt.Sprintf("%d XP to level %d", levelXP(level+1)-xp, level+1)
+	}
+	return container.NewBorder(nil, nil,
+		widget.NewLabelWithStyle(fmt.Sprintf("Level %d · %d XP", level, xp), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		nil, bar)
+}
+
+// xpHistory lists the XP earned on each of the last days, today first
+func (qa *quizApp) xpHistory(now time.Time) fyne.CanvasObject {
+	best := 1
+	for _, points := range qa.progress.XPByDay {
+		best = max(best, points)
+	}
+	grid := container.NewGridWithColumns(2)
+	for i := 0; i < xpHistoryShown; i++ {
+		day := now.AddDate(0, 0, -i)
+		points := qa.progress.XPByDay[day.Format(dateLayout)]
+		bar := widget.NewProgressBar()
+		bar.Max = float64(best)
+		bar.SetValue(float64(points))
+		bar.TextFormatter = func() string {
+			return fmt.Sprintf("%d XP", points)
+		}
+		grid.Add(widget.NewLabel(day.Format("Mon, Jan 2")))
+		grid.Add(bar)
+	}
+	return grid
+}